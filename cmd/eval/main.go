@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aria3ppp/rag-server/internal/eval"
+	internal_config "github.com/aria3ppp/rag-server/internal/pkg/config"
+	"github.com/aria3ppp/rag-server/internal/pkg/pii"
+	rag_config "github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/clock"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/openai"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/reranker"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/screening"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/tokenizer"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/uuid"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/vectorstore"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+
+	otel_metric_noop "go.opentelemetry.io/otel/metric/noop"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	configFlags      = internal_config.RegisterFlags("RAG_CONFIG_FILE")
+	datasetPath      = flag.String("dataset", "", "path to the JSONL dataset (required)")
+	k                = flag.Int("k", 5, "cutoff of recall@k and nDCG@k")
+	sourceIDField    = flag.String("source-id-field", "source_id", "metadata field of the texts holding their source id")
+	baselinePath     = flag.String("baseline", "", "path to a JSON report to compare with")
+	tolerance        = flag.Float64("tolerance", 0.01, "allowed metric drop below the baseline before it counts as a regression")
+	jsonOutPath      = flag.String("json-out", "eval-report.json", "path to write the JSON report to")
+	markdownOutPath  = flag.String("markdown-out", "", "path to write the Markdown report to (stdout if empty)")
+	failOnRegression = flag.Bool("fail-on-regression", false, "exit with status 2 when a metric regresses against the baseline")
+)
+
+func main() {
+	flag.Parse()
+
+	internal_config.CheckToRunCheckConfig[rag_config.Config](configFlags)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	report, err := run(ctx, logger)
+	if err != nil {
+		logger.ErrorContext(ctx, "eval failed", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	if *failOnRegression && report.Regressed() {
+		logger.ErrorContext(ctx, "metrics regressed against the baseline")
+		os.Exit(2)
+	}
+}
+
+func run(ctx context.Context, logger *slog.Logger) (*eval.Report, error) {
+	if *datasetPath == "" {
+		return nil, fmt.Errorf("-dataset is required")
+	}
+
+	examples, err := eval.ReadDatasetFile(*datasetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config rag_config.Config
+	if err := internal_config.Load(configFlags.Path, &config); err != nil {
+		return nil, fmt.Errorf("failed to load configs: %w", err)
+	}
+
+	tracer := otel_trace_noop.NewTracerProvider().Tracer("")
+
+	vectorstore, err := vectorstore.NewVectorStore(ctx, &config, tracer, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to vectorstore.NewVectorStore: %w", err)
+	}
+	defer vectorstore.Close()
+
+	reranker, err := reranker.NewReranker(ctx, &config, tracer, logger, http.DefaultClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reranker.NewReranker: %w", err)
+	}
+
+	llm, err := openai.NewLLM(ctx, &config, tracer, logger, http.DefaultClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to openai.NewLLM: %w", err)
+	}
+
+	// screen as the server does so the answers are evaluated on the same context
+	var screener usecase.Screener
+	if config.ScreeningConfig.Enabled {
+		if screener, err = screening.NewScreener(ctx, &config, llm, tracer, logger); err != nil {
+			return nil, fmt.Errorf("failed to screening.NewScreener: %w", err)
+		}
+	}
+
+	// redact as the server does so the retrieval runs on the same queries
+	var redactor usecase.Redactor
+	if config.PIIConfig.Enabled {
+		redactor, err = pii.NewRedactor(
+			pii.Policy{
+				Strategy: config.PIIConfig.Strategy,
+				Types:    config.PIIConfig.Types,
+				Allow:    config.PIIConfig.Allow,
+			},
+			config.PIIConfig.Tenants,
+			config.PIIConfig.HashKey,
+			otel_metric_noop.NewMeterProvider().Meter(""),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pii.NewRedactor: %w", err)
+		}
+	}
+
+	var historyTokenizer usecase.Tokenizer = tokenizer.NewHeuristicTokenizer()
+	if config.TokenizerConfig.BaseURL != "" {
+		if historyTokenizer, err = tokenizer.NewLlamaCPPTokenizer(ctx, &config, tracer, logger, http.DefaultClient); err != nil {
+			return nil, fmt.Errorf("failed to tokenizer.NewLlamaCPPTokenizer: %w", err)
+		}
+	}
+
+	useCase := usecase.NewUseCase(
+		vectorstore,
+		reranker,
+		llm,
+		clock.NewClock(),
+		uuid.NewIDGenerator(),
+		// the evaluation queries are neither recorded for feedback nor audited
+		nil,
+		nil,
+		screener,
+		redactor,
+		historyTokenizer,
+		// the evaluation queries are single turn
+		nil,
+		internal_config.NewReloadable(&config),
+		tracer,
+		logger,
+	)
+
+	evaluator := eval.NewEvaluator(
+		useCase,
+		&eval.Config{
+			K:             *k,
+			SourceIDField: *sourceIDField,
+		},
+		logger,
+	)
+
+	report := evaluator.Run(ctx, examples)
+
+	if *baselinePath != "" {
+		baseline, err := eval.ReadReportFile(*baselinePath)
+		if err != nil {
+			return nil, err
+		}
+		report.Compare(baseline, *tolerance)
+	}
+
+	if err := writeFile(*jsonOutPath, report.WriteJSON); err != nil {
+		return nil, err
+	}
+
+	if *markdownOutPath == "" {
+		if err := report.WriteMarkdown(os.Stdout); err != nil {
+			return nil, err
+		}
+	} else if err := writeFile(*markdownOutPath, report.WriteMarkdown); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/fakemodel"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	addr              = flag.String("addr", ":8080", "address to listen on")
+	embeddingSize     = flag.Int("embedding-size", fakemodel.DefaultEmbeddingSize, "size of the embedding vectors")
+	completionsPath   = flag.String("completions", "", "path to a YAML list of scripted completions ({match, response})")
+	defaultCompletion = flag.String("default-completion", fakemodel.DefaultCompletion, "completion answered when no scripted completion matches")
+	latency           = flag.Duration("latency", 0, "delay of every response")
+	chunkLatency      = flag.Duration("chunk-latency", 0, "delay of every streamed completion chunk")
+	maxBatchSize      = flag.Int("max-batch-size", 0, "maximum inputs of an embeddings request, 0 for no limit")
+	maxBatchTokens    = flag.Int("max-batch-tokens", 0, "maximum tokens of an embeddings request, 0 for no limit")
+	errorRate         = flag.Float64("error-rate", 0, "probability of failing a request")
+	errorStatusCode   = flag.Int("error-status-code", http.StatusServiceUnavailable, "status code of the failed requests")
+	healthcheck       = flag.Bool("healthcheck", false, "check the health of a running server on -addr and exit")
+)
+
+func main() {
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *healthcheck {
+		if err := runHealthcheck(); err != nil {
+			fmt.Fprintf(os.Stderr, "healthcheck failed: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	if err := run(ctx, logger); err != nil {
+		logger.ErrorContext(ctx, "fake model server failed", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, logger *slog.Logger) error {
+	opts := &fakemodel.Opts{
+		EmbeddingSize:     *embeddingSize,
+		DefaultCompletion: *defaultCompletion,
+		Latency:           *latency,
+		ChunkLatency:      *chunkLatency,
+		MaxBatchSize:      *maxBatchSize,
+		MaxBatchTokens:    *maxBatchTokens,
+		Logger:            logger,
+	}
+
+	if *completionsPath != "" {
+		content, err := os.ReadFile(*completionsPath)
+		if err != nil {
+			return fmt.Errorf("failed to read completions: %w", err)
+		}
+		if err := yaml.Unmarshal(content, &opts.Completions); err != nil {
+			return fmt.Errorf("failed to decode completions: %w", err)
+		}
+	}
+
+	if *errorRate > 0 {
+		opts.Fault = fakemodel.FailRandomly(*errorRate, *errorStatusCode)
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           fakemodel.NewHandler(opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.InfoContext(ctx, "fake model server listening", slog.String("addr", *addr), slog.Int("embedding_size", *embeddingSize))
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func runHealthcheck() error {
+	_, port, err := net.SplitHostPort(*addr)
+	if err != nil {
+		return err
+	}
+
+	response, err := http.Get(fmt.Sprintf("http://localhost:%s/health", port))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("got status code %d", response.StatusCode)
+	}
+
+	return nil
+}
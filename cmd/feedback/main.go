@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aria3ppp/rag-server/internal/eval"
+	rag_config "github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/bbolt"
+
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	storePath     = flag.String("store", "feedback.db", "path to the feedback store (stop the rag server or copy the file first: it is locked while open)")
+	outPath       = flag.String("out", "", "path to write the JSONL dataset to (stdout if empty)")
+	sourceIDField = flag.String("source-id-field", "source_id", "metadata field of the sources holding their source id")
+	minRating     = flag.Int("min-rating", 4, "minimum rating of the exported answers")
+)
+
+func main() {
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	if err := run(ctx, logger); err != nil {
+		logger.ErrorContext(ctx, "feedback export failed", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, logger *slog.Logger) error {
+	config := &rag_config.Config{
+		FeedbackConfig: rag_config.FeedbackConfig{StorePath: *storePath},
+	}
+
+	store, err := bbolt.NewFeedbackStore(ctx, config, otel_trace_noop.NewTracerProvider().Tracer(""), logger)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var (
+		examples []*eval.Example
+		total    int
+	)
+	if err := store.ForEachFeedback(ctx, func(record *domain.FeedbackRecord) bool {
+		total++
+		if example := eval.ExampleFromFeedback(record, *sourceIDField, *minRating); example != nil {
+			examples = append(examples, example)
+		}
+		return true
+	}); err != nil {
+		return fmt.Errorf("failed to read feedback: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := eval.WriteDataset(out, examples); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "feedback exported", slog.Int("feedback", total), slog.Int("examples", len(examples)))
+
+	return nil
+}
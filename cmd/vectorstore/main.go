@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	internal_config "github.com/aria3ppp/rag-server/internal/pkg/config"
 	"github.com/aria3ppp/rag-server/internal/pkg/prob"
 	"github.com/aria3ppp/rag-server/internal/pkg/server"
 	vectorstore_app "github.com/aria3ppp/rag-server/internal/vectorstore/app"
@@ -19,22 +21,38 @@ import (
 	"github.com/aria3ppp/rag-server/pkg/opentelemetry"
 	"github.com/aria3ppp/rag-server/pkg/profile"
 
-	"github.com/caarlos0/env/v11"
+	"github.com/samber/lo"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
+var (
+	configFlags             = internal_config.RegisterFlags("VECTORSTORE_CONFIG_FILE")
+	migrateLegacyCollection = flag.Bool("migrate-legacy-collection", false, "copy the collections without sparse vectors into their _hybrid copies with the sparse vectors and exit")
+)
+
 func init() {
-	var config vectorstore_config.ServerConfig
-	if err := env.Parse(&config); err != nil {
+	flag.Parse()
+
+	internal_config.CheckToRunCheckConfig[vectorstore_config.Config](configFlags)
+
+	// only the server config is needed by the probe
+	var config vectorstore_config.Config
+	fileFields, err := internal_config.DecodeFile(configFlags.Path, &config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to decode config file: %s\n", err)
+		os.Exit(1)
+	}
+	if err := internal_config.ApplyEnv(&config.ServerConfig, fileFields.Sub("ServerConfig")); err != nil {
 		fmt.Fprintf(os.Stderr, "failed to parse envs: %s\n", err)
 		os.Exit(1)
 	}
 
 	prob.CheckToRunProbe(
 		server.Config{
-			GRPCPort: config.GRPCConfig.Port,
-			HTTPPort: config.GatewayConfig.Port,
+			GRPCPort: config.ServerConfig.GRPCConfig.Port,
+			HTTPPort: config.ServerConfig.GatewayConfig.Port,
 		},
 	)
 }
@@ -43,13 +61,15 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	logLevel := &slog.LevelVar{}
+
 	var slogHandler slog.Handler
 	if profile.IsDebug {
 		slogHandler = slog.NewTextHandler(
 			os.Stdout,
 			&slog.HandlerOptions{
 				AddSource: true,
-				Level:     slog.LevelDebug,
+				Level:     logLevel,
 			},
 		)
 	} else {
@@ -57,7 +77,7 @@ func main() {
 			os.Stdout,
 			&slog.HandlerOptions{
 				AddSource: true,
-				Level:     slog.LevelInfo,
+				Level:     logLevel,
 			},
 		)
 	}
@@ -80,23 +100,65 @@ func main() {
 	}
 	defer otelInitShutdown(ctx)
 
+	meterShutdown, err := opentelemetry.InitMeter()
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to init meter", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer meterShutdown(ctx)
+
 	tracer := otel.Tracer(
 		"vectorstore",
 		trace.WithInstrumentationVersion(otel.Version()),
 	)
 
+	meter := otel.Meter(
+		"vectorstore",
+		metric.WithInstrumentationVersion(otel.Version()),
+	)
+
 	var config vectorstore_config.Config
-	if err := env.Parse(&config); err != nil {
-		logger.ErrorContext(ctx, "failed to parse env configs", slog.String("error", err.Error()))
+	if err := internal_config.Load(configFlags.Path, &config); err != nil {
+		logger.ErrorContext(ctx, "failed to load configs", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+	setLogLevel(logLevel, config.LogConfig.Level)
+
+	if *migrateLegacyCollection {
+		if err := vectorstore_app.MigrateLegacyCollections(ctx, &config, tracer, logger); err != nil {
+			logger.ErrorContext(ctx, "failed to migrate legacy collections", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
+	reloadableConfig := internal_config.NewReloadable(&config)
+
+	// reload the reloadable configs on SIGHUP or config file change
+	go internal_config.Watch(ctx, configFlags.Path, nil, func() {
+		var next vectorstore_config.Config
+		if err := internal_config.Load(configFlags.Path, &next); err != nil {
+			logger.ErrorContext(ctx, "failed to reload configs", slog.String("error", err.Error()))
+			return
+		}
+
+		merged, changed, restartRequired := internal_config.MergeReloadable(reloadableConfig.Load(), &next)
+		reloadableConfig.Store(merged)
+		setLogLevel(logLevel, merged.LogConfig.Level)
+
+		logger.InfoContext(ctx, "configs reloaded", slog.Any("changed", changed))
+		if len(restartRequired) > 0 {
+			logger.WarnContext(ctx, "changed configs need a restart to take effect", slog.Any("configs", restartRequired))
+		}
+	})
 
 	// create and initialize vectorstore app
 	app, err := vectorstore_app.New(
 		ctx,
-		&config,
+		reloadableConfig,
 		slogHandler,
 		tracer,
+		meter,
 		http.DefaultClient,
 	)
 	if err != nil {
@@ -110,3 +172,13 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func setLogLevel(logLevel *slog.LevelVar, level string) {
+	if level == "" {
+		logLevel.Set(lo.Ternary(profile.IsDebug, slog.LevelDebug, slog.LevelInfo))
+		return
+	}
+
+	// the level is validated by the config
+	_ = logLevel.UnmarshalText([]byte(level))
+}
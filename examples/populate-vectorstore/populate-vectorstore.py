@@ -113,16 +113,21 @@ def chunk_text(paragraphs, max_bytes=2000):
     
     return chunks
 
-def insert_chunks(chunks, url, api_url):
+def insert_chunks(chunks, url, api_url, dedup_threshold=0):
     """Insert chunks into vector store with metadata"""
     parsed_url = urlparse(url)
     payload = {
+        # the ids hash the url and the chunk so re-running on a page replaces its chunks
+        "id_mode": "content",
+        "id_namespace": url,
+        "dedup_threshold": dedup_threshold,
         "texts": [{
             "text": chunk,
             "metadata": {
                 "source": parsed_url.netloc,
                 "path": parsed_url.path,
-                "chunk_id": idx
+                "document_id": url,
+                "chunk_index": idx
             }
         } for idx, chunk in enumerate(chunks)]
     }
@@ -142,6 +147,8 @@ def main():
                        help="Maximum chunk size in bytes (default: 2000)")
     parser.add_argument("--api-url", default="http://localhost:8080/api/v1/insert_texts",
                        help="Vector store API endpoint (default: http://localhost:8080/api/v1/insert_texts)")
+    parser.add_argument("--dedup-threshold", type=float, default=0,
+                       help="Skip chunks at least this similar to a stored chunk, 0 disables (default: 0)")
     args = parser.parse_args()
 
     print(f"Processing: {args.url}")
@@ -160,7 +167,7 @@ def main():
         if chunk_bytes > args.max_chunk_bytes:
             print(f"Warning: Chunk {i} exceeds limit ({chunk_bytes}/{args.max_chunk_bytes} bytes)")
     
-    result = insert_chunks(chunks, args.url, args.api_url)
+    result = insert_chunks(chunks, args.url, args.api_url, args.dedup_threshold)
     print("Insertion result:", result)
 
 if __name__ == "__main__":
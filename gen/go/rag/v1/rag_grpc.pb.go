@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RAGService_Query_FullMethodName       = "/rag.v1.RAGService/Query"
-	RAGService_QueryStream_FullMethodName = "/rag.v1.RAGService/QueryStream"
+	RAGService_Query_FullMethodName              = "/rag.v1.RAGService/Query"
+	RAGService_QueryStream_FullMethodName        = "/rag.v1.RAGService/QueryStream"
+	RAGService_SubmitFeedback_FullMethodName     = "/rag.v1.RAGService/SubmitFeedback"
+	RAGService_ExportAuditRecords_FullMethodName = "/rag.v1.RAGService/ExportAuditRecords"
 )
 
 // RAGServiceClient is the client API for RAGService service.
@@ -29,6 +31,9 @@ const (
 type RAGServiceClient interface {
 	Query(ctx context.Context, in *RAGServiceQueryRequest, opts ...grpc.CallOption) (*RAGServiceQueryResponse, error)
 	QueryStream(ctx context.Context, in *RAGServiceQueryStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RAGServiceQueryStreamResponse], error)
+	SubmitFeedback(ctx context.Context, in *RAGServiceSubmitFeedbackRequest, opts ...grpc.CallOption) (*RAGServiceSubmitFeedbackResponse, error)
+	// ExportAuditRecords streams the audit records created in the time range. It requires the admin scope.
+	ExportAuditRecords(ctx context.Context, in *RAGServiceExportAuditRecordsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RAGServiceExportAuditRecordsResponse], error)
 }
 
 type rAGServiceClient struct {
@@ -68,12 +73,44 @@ func (c *rAGServiceClient) QueryStream(ctx context.Context, in *RAGServiceQueryS
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type RAGService_QueryStreamClient = grpc.ServerStreamingClient[RAGServiceQueryStreamResponse]
 
+func (c *rAGServiceClient) SubmitFeedback(ctx context.Context, in *RAGServiceSubmitFeedbackRequest, opts ...grpc.CallOption) (*RAGServiceSubmitFeedbackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RAGServiceSubmitFeedbackResponse)
+	err := c.cc.Invoke(ctx, RAGService_SubmitFeedback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rAGServiceClient) ExportAuditRecords(ctx context.Context, in *RAGServiceExportAuditRecordsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RAGServiceExportAuditRecordsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RAGService_ServiceDesc.Streams[1], RAGService_ExportAuditRecords_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RAGServiceExportAuditRecordsRequest, RAGServiceExportAuditRecordsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RAGService_ExportAuditRecordsClient = grpc.ServerStreamingClient[RAGServiceExportAuditRecordsResponse]
+
 // RAGServiceServer is the server API for RAGService service.
 // All implementations must embed UnimplementedRAGServiceServer
 // for forward compatibility.
 type RAGServiceServer interface {
 	Query(context.Context, *RAGServiceQueryRequest) (*RAGServiceQueryResponse, error)
 	QueryStream(*RAGServiceQueryStreamRequest, grpc.ServerStreamingServer[RAGServiceQueryStreamResponse]) error
+	SubmitFeedback(context.Context, *RAGServiceSubmitFeedbackRequest) (*RAGServiceSubmitFeedbackResponse, error)
+	// ExportAuditRecords streams the audit records created in the time range. It requires the admin scope.
+	ExportAuditRecords(*RAGServiceExportAuditRecordsRequest, grpc.ServerStreamingServer[RAGServiceExportAuditRecordsResponse]) error
 	mustEmbedUnimplementedRAGServiceServer()
 }
 
@@ -90,6 +127,12 @@ func (UnimplementedRAGServiceServer) Query(context.Context, *RAGServiceQueryRequ
 func (UnimplementedRAGServiceServer) QueryStream(*RAGServiceQueryStreamRequest, grpc.ServerStreamingServer[RAGServiceQueryStreamResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
 }
+func (UnimplementedRAGServiceServer) SubmitFeedback(context.Context, *RAGServiceSubmitFeedbackRequest) (*RAGServiceSubmitFeedbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitFeedback not implemented")
+}
+func (UnimplementedRAGServiceServer) ExportAuditRecords(*RAGServiceExportAuditRecordsRequest, grpc.ServerStreamingServer[RAGServiceExportAuditRecordsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditRecords not implemented")
+}
 func (UnimplementedRAGServiceServer) mustEmbedUnimplementedRAGServiceServer() {}
 func (UnimplementedRAGServiceServer) testEmbeddedByValue()                    {}
 
@@ -140,6 +183,35 @@ func _RAGService_QueryStream_Handler(srv interface{}, stream grpc.ServerStream)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type RAGService_QueryStreamServer = grpc.ServerStreamingServer[RAGServiceQueryStreamResponse]
 
+func _RAGService_SubmitFeedback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RAGServiceSubmitFeedbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RAGServiceServer).SubmitFeedback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RAGService_SubmitFeedback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RAGServiceServer).SubmitFeedback(ctx, req.(*RAGServiceSubmitFeedbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RAGService_ExportAuditRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RAGServiceExportAuditRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RAGServiceServer).ExportAuditRecords(m, &grpc.GenericServerStream[RAGServiceExportAuditRecordsRequest, RAGServiceExportAuditRecordsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RAGService_ExportAuditRecordsServer = grpc.ServerStreamingServer[RAGServiceExportAuditRecordsResponse]
+
 // RAGService_ServiceDesc is the grpc.ServiceDesc for RAGService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -151,6 +223,10 @@ var RAGService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Query",
 			Handler:    _RAGService_Query_Handler,
 		},
+		{
+			MethodName: "SubmitFeedback",
+			Handler:    _RAGService_SubmitFeedback_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -158,6 +234,11 @@ var RAGService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RAGService_QueryStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportAuditRecords",
+			Handler:       _RAGService_ExportAuditRecords_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rag/v1/rag.proto",
 }
@@ -23,9 +23,13 @@ const (
 )
 
 type VectorStoreServiceInsertTextsRequestText struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
-	Metadata      *structpb.Struct       `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Text     string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Metadata *structpb.Struct       `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// id is a stable id of the text replacing the text inserted with it before,
+	// a generated one when empty. The ids which are not uuids are stored as
+	// their uuid v5.
+	Id            string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,11 +78,27 @@ func (x *VectorStoreServiceInsertTextsRequestText) GetMetadata() *structpb.Struc
 	return nil
 }
 
+func (x *VectorStoreServiceInsertTextsRequestText) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type VectorStoreServiceInsertTextsRequest struct {
-	state         protoimpl.MessageState                      `protogen:"open.v1"`
-	Texts         []*VectorStoreServiceInsertTextsRequestText `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState                      `protogen:"open.v1"`
+	Texts []*VectorStoreServiceInsertTextsRequestText `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
+	// id_mode derives the ids of the texts without an id: random (the default)
+	// or content, a hash of the id_namespace and the text so inserting the
+	// same text again replaces it.
+	IdMode string `protobuf:"bytes,2,opt,name=id_mode,proto3" json:"id_mode,omitempty"`
+	// id_namespace scopes the content ids, e.g. to the source url of the texts.
+	IdNamespace string `protobuf:"bytes,3,opt,name=id_namespace,proto3" json:"id_namespace,omitempty"`
+	// dedup_threshold skips the texts whose similarity to a stored text or to
+	// a preceding text of the request is at least the threshold, 0 disables it.
+	DedupThreshold float32 `protobuf:"fixed32,4,opt,name=dedup_threshold,proto3" json:"dedup_threshold,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *VectorStoreServiceInsertTextsRequest) Reset() {
@@ -118,15 +138,103 @@ func (x *VectorStoreServiceInsertTextsRequest) GetTexts() []*VectorStoreServiceI
 	return nil
 }
 
+func (x *VectorStoreServiceInsertTextsRequest) GetIdMode() string {
+	if x != nil {
+		return x.IdMode
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceInsertTextsRequest) GetIdNamespace() string {
+	if x != nil {
+		return x.IdNamespace
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceInsertTextsRequest) GetDedupThreshold() float32 {
+	if x != nil {
+		return x.DedupThreshold
+	}
+	return 0
+}
+
+type VectorStoreServiceInsertTextsResponseSkipped struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index is the index of the skipped text in the request texts.
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// duplicate_id is the id of the stored or preceding text it duplicates.
+	DuplicateId   string  `protobuf:"bytes,2,opt,name=duplicate_id,proto3" json:"duplicate_id,omitempty"`
+	Score         float32 `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceInsertTextsResponseSkipped) Reset() {
+	*x = VectorStoreServiceInsertTextsResponseSkipped{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceInsertTextsResponseSkipped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceInsertTextsResponseSkipped) ProtoMessage() {}
+
+func (x *VectorStoreServiceInsertTextsResponseSkipped) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceInsertTextsResponseSkipped.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceInsertTextsResponseSkipped) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VectorStoreServiceInsertTextsResponseSkipped) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceInsertTextsResponseSkipped) GetDuplicateId() string {
+	if x != nil {
+		return x.DuplicateId
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceInsertTextsResponseSkipped) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
 type VectorStoreServiceInsertTextsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ids are the stored ids of the texts in the order of the request, the id
+	// of their duplicate for the skipped texts.
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// skipped are the texts skipped as near duplicates.
+	Skipped       []*VectorStoreServiceInsertTextsResponseSkipped `protobuf:"bytes,2,rep,name=skipped,proto3" json:"skipped,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *VectorStoreServiceInsertTextsResponse) Reset() {
 	*x = VectorStoreServiceInsertTextsResponse{}
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[2]
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -138,7 +246,7 @@ func (x *VectorStoreServiceInsertTextsResponse) String() string {
 func (*VectorStoreServiceInsertTextsResponse) ProtoMessage() {}
 
 func (x *VectorStoreServiceInsertTextsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[2]
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,34 +259,50 @@ func (x *VectorStoreServiceInsertTextsResponse) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use VectorStoreServiceInsertTextsResponse.ProtoReflect.Descriptor instead.
 func (*VectorStoreServiceInsertTextsResponse) Descriptor() ([]byte, []int) {
-	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{2}
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{3}
 }
 
-type VectorStoreServiceSearchTextRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
-	TopK          int64                  `protobuf:"varint,2,opt,name=top_k,proto3" json:"top_k,omitempty"`
-	MinScore      float32                `protobuf:"fixed32,3,opt,name=min_score,proto3" json:"min_score,omitempty"`
-	Filter        *structpb.Struct       `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+func (x *VectorStoreServiceInsertTextsResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceInsertTextsResponse) GetSkipped() []*VectorStoreServiceInsertTextsResponseSkipped {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+// VectorStoreServiceStreamInsertTextsRequest is a text of the stream.
+type VectorStoreServiceStreamInsertTextsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Text     string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Metadata *structpb.Struct       `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// id is a stable id of the text replacing the text inserted with it before,
+	// a generated one when empty.
+	Id            string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VectorStoreServiceSearchTextRequest) Reset() {
-	*x = VectorStoreServiceSearchTextRequest{}
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[3]
+func (x *VectorStoreServiceStreamInsertTextsRequest) Reset() {
+	*x = VectorStoreServiceStreamInsertTextsRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VectorStoreServiceSearchTextRequest) String() string {
+func (x *VectorStoreServiceStreamInsertTextsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VectorStoreServiceSearchTextRequest) ProtoMessage() {}
+func (*VectorStoreServiceStreamInsertTextsRequest) ProtoMessage() {}
 
-func (x *VectorStoreServiceSearchTextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[3]
+func (x *VectorStoreServiceStreamInsertTextsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -189,63 +313,176 @@ func (x *VectorStoreServiceSearchTextRequest) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VectorStoreServiceSearchTextRequest.ProtoReflect.Descriptor instead.
-func (*VectorStoreServiceSearchTextRequest) Descriptor() ([]byte, []int) {
-	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use VectorStoreServiceStreamInsertTextsRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceStreamInsertTextsRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *VectorStoreServiceSearchTextRequest) GetText() string {
+func (x *VectorStoreServiceStreamInsertTextsRequest) GetText() string {
 	if x != nil {
 		return x.Text
 	}
 	return ""
 }
 
-func (x *VectorStoreServiceSearchTextRequest) GetTopK() int64 {
+func (x *VectorStoreServiceStreamInsertTextsRequest) GetMetadata() *structpb.Struct {
 	if x != nil {
-		return x.TopK
+		return x.Metadata
 	}
-	return 0
+	return nil
 }
 
-func (x *VectorStoreServiceSearchTextRequest) GetMinScore() float32 {
+func (x *VectorStoreServiceStreamInsertTextsRequest) GetId() string {
 	if x != nil {
-		return x.MinScore
+		return x.Id
+	}
+	return ""
+}
+
+// VectorStoreServiceStreamInsertTextsResponseItem is a failed text of the stream.
+type VectorStoreServiceStreamInsertTextsResponseItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index is the index of the text in the stream.
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// error is why the text failed.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceStreamInsertTextsResponseItem) Reset() {
+	*x = VectorStoreServiceStreamInsertTextsResponseItem{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceStreamInsertTextsResponseItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceStreamInsertTextsResponseItem) ProtoMessage() {}
+
+func (x *VectorStoreServiceStreamInsertTextsResponseItem) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceStreamInsertTextsResponseItem.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceStreamInsertTextsResponseItem) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VectorStoreServiceStreamInsertTextsResponseItem) GetIndex() int64 {
+	if x != nil {
+		return x.Index
 	}
 	return 0
 }
 
-func (x *VectorStoreServiceSearchTextRequest) GetFilter() *structpb.Struct {
+func (x *VectorStoreServiceStreamInsertTextsResponseItem) GetError() string {
 	if x != nil {
-		return x.Filter
+		return x.Error
+	}
+	return ""
+}
+
+type VectorStoreServiceStreamInsertTextsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// failed_items are the failed texts in the order of the stream, the
+	// inserted texts are only counted.
+	FailedItems   []*VectorStoreServiceStreamInsertTextsResponseItem `protobuf:"bytes,1,rep,name=failed_items,proto3" json:"failed_items,omitempty"`
+	Inserted      int64                                              `protobuf:"varint,2,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Failed        int64                                              `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceStreamInsertTextsResponse) Reset() {
+	*x = VectorStoreServiceStreamInsertTextsResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceStreamInsertTextsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceStreamInsertTextsResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceStreamInsertTextsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceStreamInsertTextsResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceStreamInsertTextsResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VectorStoreServiceStreamInsertTextsResponse) GetFailedItems() []*VectorStoreServiceStreamInsertTextsResponseItem {
+	if x != nil {
+		return x.FailedItems
 	}
 	return nil
 }
 
-type VectorStoreServiceSearchTextResponseSimilarText struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
-	Score         float32                `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
-	Metadata      *structpb.Struct       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+func (x *VectorStoreServiceStreamInsertTextsResponse) GetInserted() int64 {
+	if x != nil {
+		return x.Inserted
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceStreamInsertTextsResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type VectorStoreServiceInsertDocumentsRequestDocument struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is a stable id of the document replacing the chunks of the document
+	// inserted with it before, a generated one when empty.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// metadata is copied to the chunks of the document.
+	Metadata      *structpb.Struct `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VectorStoreServiceSearchTextResponseSimilarText) Reset() {
-	*x = VectorStoreServiceSearchTextResponseSimilarText{}
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[4]
+func (x *VectorStoreServiceInsertDocumentsRequestDocument) Reset() {
+	*x = VectorStoreServiceInsertDocumentsRequestDocument{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VectorStoreServiceSearchTextResponseSimilarText) String() string {
+func (x *VectorStoreServiceInsertDocumentsRequestDocument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VectorStoreServiceSearchTextResponseSimilarText) ProtoMessage() {}
+func (*VectorStoreServiceInsertDocumentsRequestDocument) ProtoMessage() {}
 
-func (x *VectorStoreServiceSearchTextResponseSimilarText) ProtoReflect() protoreflect.Message {
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[4]
+func (x *VectorStoreServiceInsertDocumentsRequestDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -256,54 +493,61 @@ func (x *VectorStoreServiceSearchTextResponseSimilarText) ProtoReflect() protore
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VectorStoreServiceSearchTextResponseSimilarText.ProtoReflect.Descriptor instead.
-func (*VectorStoreServiceSearchTextResponseSimilarText) Descriptor() ([]byte, []int) {
-	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use VectorStoreServiceInsertDocumentsRequestDocument.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceInsertDocumentsRequestDocument) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *VectorStoreServiceSearchTextResponseSimilarText) GetText() string {
+func (x *VectorStoreServiceInsertDocumentsRequestDocument) GetId() string {
 	if x != nil {
-		return x.Text
+		return x.Id
 	}
 	return ""
 }
 
-func (x *VectorStoreServiceSearchTextResponseSimilarText) GetScore() float32 {
+func (x *VectorStoreServiceInsertDocumentsRequestDocument) GetText() string {
 	if x != nil {
-		return x.Score
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-func (x *VectorStoreServiceSearchTextResponseSimilarText) GetMetadata() *structpb.Struct {
+func (x *VectorStoreServiceInsertDocumentsRequestDocument) GetMetadata() *structpb.Struct {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-type VectorStoreServiceSearchTextResponse struct {
-	state         protoimpl.MessageState                             `protogen:"open.v1"`
-	SimilarTexts  []*VectorStoreServiceSearchTextResponseSimilarText `protobuf:"bytes,1,rep,name=similar_texts,proto3" json:"similar_texts,omitempty"`
+// VectorStoreServiceInsertDocumentsRequestChunking overrides the chunking
+// config of the collection with the fields it sets.
+type VectorStoreServiceInsertDocumentsRequestChunking struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// chunker is fixed, sentence, recursive or markdown.
+	Chunker string `protobuf:"bytes,1,opt,name=chunker,proto3" json:"chunker,omitempty"`
+	// size is the maximum number of characters of a chunk.
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// overlap is the number of characters a chunk repeats from the previous one, at most.
+	Overlap       *int64 `protobuf:"varint,3,opt,name=overlap,proto3,oneof" json:"overlap,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VectorStoreServiceSearchTextResponse) Reset() {
-	*x = VectorStoreServiceSearchTextResponse{}
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[5]
+func (x *VectorStoreServiceInsertDocumentsRequestChunking) Reset() {
+	*x = VectorStoreServiceInsertDocumentsRequestChunking{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VectorStoreServiceSearchTextResponse) String() string {
+func (x *VectorStoreServiceInsertDocumentsRequestChunking) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VectorStoreServiceSearchTextResponse) ProtoMessage() {}
+func (*VectorStoreServiceInsertDocumentsRequestChunking) ProtoMessage() {}
 
-func (x *VectorStoreServiceSearchTextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[5]
+func (x *VectorStoreServiceInsertDocumentsRequestChunking) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -314,100 +558,1826 @@ func (x *VectorStoreServiceSearchTextResponse) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VectorStoreServiceSearchTextResponse.ProtoReflect.Descriptor instead.
-func (*VectorStoreServiceSearchTextResponse) Descriptor() ([]byte, []int) {
-	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use VectorStoreServiceInsertDocumentsRequestChunking.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceInsertDocumentsRequestChunking) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *VectorStoreServiceSearchTextResponse) GetSimilarTexts() []*VectorStoreServiceSearchTextResponseSimilarText {
+func (x *VectorStoreServiceInsertDocumentsRequestChunking) GetChunker() string {
 	if x != nil {
-		return x.SimilarTexts
+		return x.Chunker
 	}
-	return nil
+	return ""
 }
 
-var File_vectorstore_v1_vectorstore_proto protoreflect.FileDescriptor
+func (x *VectorStoreServiceInsertDocumentsRequestChunking) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
 
-var file_vectorstore_v1_vectorstore_proto_rawDesc = []byte{
-	0x0a, 0x20, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31,
-	0x2f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
-	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x73,
-	0x0a, 0x28, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65,
-	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x33,
-	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x76, 0x0a, 0x24, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54,
-	0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x05, 0x74,
-	0x65, 0x78, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x76, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e,
-	0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x54, 0x65, 0x78, 0x74, 0x52, 0x05, 0x74, 0x65, 0x78, 0x74, 0x73, 0x22, 0x27, 0x0a, 0x25, 0x56,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x9e, 0x01, 0x0a, 0x23, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63,
-	0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x66,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x90, 0x01, 0x0a, 0x2f, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69,
-	0x6d, 0x69, 0x6c, 0x61, 0x72, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63,
-	0x6f, 0x72, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x8d, 0x01, 0x0a, 0x24, 0x56, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x65, 0x0a, 0x0d, 0x73, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x5f, 0x74, 0x65, 0x78,
-	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69,
-	0x6d, 0x69, 0x6c, 0x61, 0x72, 0x54, 0x65, 0x78, 0x74, 0x52, 0x0d, 0x73, 0x69, 0x6d, 0x69, 0x6c,
-	0x61, 0x72, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73, 0x32, 0xcc, 0x02, 0x0a, 0x12, 0x56, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x9b, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x12,
-	0x34, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74,
-	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54,
-	0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
-	0x2f, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73, 0x12, 0x97, 0x01,
-	0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x12, 0x33, 0x2e, 0x76,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x34, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x3a,
-	0x01, 0x2a, 0x22, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x72, 0x69, 0x61, 0x33, 0x70, 0x70, 0x70, 0x2f, 0x72,
-	0x61, 0x67, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f,
-	0x2f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0x3b,
-	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *VectorStoreServiceInsertDocumentsRequestChunking) GetOverlap() int64 {
+	if x != nil && x.Overlap != nil {
+		return *x.Overlap
+	}
+	return 0
+}
+
+type VectorStoreServiceInsertDocumentsRequest struct {
+	state     protoimpl.MessageState                              `protogen:"open.v1"`
+	Documents []*VectorStoreServiceInsertDocumentsRequestDocument `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	Chunking  *VectorStoreServiceInsertDocumentsRequestChunking   `protobuf:"bytes,2,opt,name=chunking,proto3" json:"chunking,omitempty"`
+	// collection is the default collection or a federated collection the
+	// documents are inserted into, the default one when empty.
+	Collection    string `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceInsertDocumentsRequest) Reset() {
+	*x = VectorStoreServiceInsertDocumentsRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceInsertDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceInsertDocumentsRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceInsertDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceInsertDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceInsertDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VectorStoreServiceInsertDocumentsRequest) GetDocuments() []*VectorStoreServiceInsertDocumentsRequestDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceInsertDocumentsRequest) GetChunking() *VectorStoreServiceInsertDocumentsRequestChunking {
+	if x != nil {
+		return x.Chunking
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceInsertDocumentsRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type VectorStoreServiceInsertDocumentsResponseDocument struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// chunk_ids are the ids of the inserted chunks in the order of the document.
+	ChunkIds      []string `protobuf:"bytes,2,rep,name=chunk_ids,proto3" json:"chunk_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceInsertDocumentsResponseDocument) Reset() {
+	*x = VectorStoreServiceInsertDocumentsResponseDocument{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceInsertDocumentsResponseDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceInsertDocumentsResponseDocument) ProtoMessage() {}
+
+func (x *VectorStoreServiceInsertDocumentsResponseDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceInsertDocumentsResponseDocument.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceInsertDocumentsResponseDocument) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VectorStoreServiceInsertDocumentsResponseDocument) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceInsertDocumentsResponseDocument) GetChunkIds() []string {
+	if x != nil {
+		return x.ChunkIds
+	}
+	return nil
+}
+
+type VectorStoreServiceInsertDocumentsResponse struct {
+	state         protoimpl.MessageState                               `protogen:"open.v1"`
+	Documents     []*VectorStoreServiceInsertDocumentsResponseDocument `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceInsertDocumentsResponse) Reset() {
+	*x = VectorStoreServiceInsertDocumentsResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceInsertDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceInsertDocumentsResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceInsertDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceInsertDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceInsertDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VectorStoreServiceInsertDocumentsResponse) GetDocuments() []*VectorStoreServiceInsertDocumentsResponseDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+type VectorStoreServiceUploadDocumentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is a stable id of the document replacing the chunks of the document
+	// uploaded with it before, a generated one when empty.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// mime_type is text/html, text/markdown, text/plain, text/csv or application/jsonl.
+	MimeType string `protobuf:"bytes,2,opt,name=mime_type,proto3" json:"mime_type,omitempty"`
+	Content  []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// metadata is copied to the chunks of the document.
+	Metadata *structpb.Struct                                  `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Chunking *VectorStoreServiceInsertDocumentsRequestChunking `protobuf:"bytes,5,opt,name=chunking,proto3" json:"chunking,omitempty"`
+	// collection is the default collection or a federated collection the
+	// document is inserted into, the default one when empty.
+	Collection    string `protobuf:"bytes,6,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) Reset() {
+	*x = VectorStoreServiceUploadDocumentRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceUploadDocumentRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceUploadDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceUploadDocumentRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceUploadDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) GetChunking() *VectorStoreServiceInsertDocumentsRequestChunking {
+	if x != nil {
+		return x.Chunking
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceUploadDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type VectorStoreServiceUploadDocumentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// chunk_ids are the ids of the inserted chunks in the order of the document.
+	ChunkIds      []string `protobuf:"bytes,2,rep,name=chunk_ids,proto3" json:"chunk_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceUploadDocumentResponse) Reset() {
+	*x = VectorStoreServiceUploadDocumentResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceUploadDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceUploadDocumentResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceUploadDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceUploadDocumentResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceUploadDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VectorStoreServiceUploadDocumentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceUploadDocumentResponse) GetChunkIds() []string {
+	if x != nil {
+		return x.ChunkIds
+	}
+	return nil
+}
+
+type VectorStoreServiceSearchTextRequestMMR struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// lambda trades off the similarity to the query (1) against the diversity of the results (0).
+	Lambda float32 `protobuf:"fixed32,1,opt,name=lambda,proto3" json:"lambda,omitempty"`
+	// fetch_k is the number of the most similar texts the top_k are re-selected from.
+	FetchK        int64 `protobuf:"varint,2,opt,name=fetch_k,proto3" json:"fetch_k,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceSearchTextRequestMMR) Reset() {
+	*x = VectorStoreServiceSearchTextRequestMMR{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceSearchTextRequestMMR) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceSearchTextRequestMMR) ProtoMessage() {}
+
+func (x *VectorStoreServiceSearchTextRequestMMR) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceSearchTextRequestMMR.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceSearchTextRequestMMR) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VectorStoreServiceSearchTextRequestMMR) GetLambda() float32 {
+	if x != nil {
+		return x.Lambda
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceSearchTextRequestMMR) GetFetchK() int64 {
+	if x != nil {
+		return x.FetchK
+	}
+	return 0
+}
+
+type VectorStoreServiceSearchTextRequestFederationCollection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// weight scales the scores of the collection results, 1 when 0.
+	Weight        float32 `protobuf:"fixed32,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederationCollection) Reset() {
+	*x = VectorStoreServiceSearchTextRequestFederationCollection{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederationCollection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceSearchTextRequestFederationCollection) ProtoMessage() {}
+
+func (x *VectorStoreServiceSearchTextRequestFederationCollection) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceSearchTextRequestFederationCollection.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceSearchTextRequestFederationCollection) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederationCollection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederationCollection) GetWeight() float32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type VectorStoreServiceSearchTextRequestFederation struct {
+	state       protoimpl.MessageState                                     `protogen:"open.v1"`
+	Collections []*VectorStoreServiceSearchTextRequestFederationCollection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	// fusion combines the collections results: weighted (the default, min-max normalized scores) or rrf.
+	Fusion        string `protobuf:"bytes,2,opt,name=fusion,proto3" json:"fusion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederation) Reset() {
+	*x = VectorStoreServiceSearchTextRequestFederation{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceSearchTextRequestFederation) ProtoMessage() {}
+
+func (x *VectorStoreServiceSearchTextRequestFederation) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceSearchTextRequestFederation.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceSearchTextRequestFederation) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederation) GetCollections() []*VectorStoreServiceSearchTextRequestFederationCollection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceSearchTextRequestFederation) GetFusion() string {
+	if x != nil {
+		return x.Fusion
+	}
+	return ""
+}
+
+type VectorStoreServiceSearchTextRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Text     string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	TopK     int64                  `protobuf:"varint,2,opt,name=top_k,proto3" json:"top_k,omitempty"`
+	MinScore float32                `protobuf:"fixed32,3,opt,name=min_score,proto3" json:"min_score,omitempty"`
+	// filter restricts the search with must, should and must_not lists of
+	// conditions: nested filters or {"key": ..., <match|in|range|exists>: ...}.
+	Filter *structpb.Struct `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// mmr diversifies the results by maximal marginal relevance when set.
+	Mmr *VectorStoreServiceSearchTextRequestMMR `protobuf:"bytes,5,opt,name=mmr,proto3" json:"mmr,omitempty"`
+	// mode is dense (the default), sparse (bm25 lexical) or hybrid (both, fused).
+	Mode string `protobuf:"bytes,6,opt,name=mode,proto3" json:"mode,omitempty"`
+	// fusion combines the hybrid results: rrf (the default) or dbsf.
+	Fusion string `protobuf:"bytes,7,opt,name=fusion,proto3" json:"fusion,omitempty"`
+	// federation searches its collections instead of the default one when set,
+	// tagging the results with their collection in the reserved _collection
+	// metadata field.
+	Federation    *VectorStoreServiceSearchTextRequestFederation `protobuf:"bytes,8,opt,name=federation,proto3" json:"federation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceSearchTextRequest) Reset() {
+	*x = VectorStoreServiceSearchTextRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceSearchTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceSearchTextRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceSearchTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceSearchTextRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceSearchTextRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetTopK() int64 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetFilter() *structpb.Struct {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetMmr() *VectorStoreServiceSearchTextRequestMMR {
+	if x != nil {
+		return x.Mmr
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetFusion() string {
+	if x != nil {
+		return x.Fusion
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceSearchTextRequest) GetFederation() *VectorStoreServiceSearchTextRequestFederation {
+	if x != nil {
+		return x.Federation
+	}
+	return nil
+}
+
+type VectorStoreServiceSearchTextResponseSimilarText struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Score         float32                `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceSearchTextResponseSimilarText) Reset() {
+	*x = VectorStoreServiceSearchTextResponseSimilarText{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceSearchTextResponseSimilarText) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceSearchTextResponseSimilarText) ProtoMessage() {}
+
+func (x *VectorStoreServiceSearchTextResponseSimilarText) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceSearchTextResponseSimilarText.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceSearchTextResponseSimilarText) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *VectorStoreServiceSearchTextResponseSimilarText) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceSearchTextResponseSimilarText) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceSearchTextResponseSimilarText) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type VectorStoreServiceSearchTextResponse struct {
+	state         protoimpl.MessageState                             `protogen:"open.v1"`
+	SimilarTexts  []*VectorStoreServiceSearchTextResponseSimilarText `protobuf:"bytes,1,rep,name=similar_texts,proto3" json:"similar_texts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceSearchTextResponse) Reset() {
+	*x = VectorStoreServiceSearchTextResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceSearchTextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceSearchTextResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceSearchTextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceSearchTextResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceSearchTextResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VectorStoreServiceSearchTextResponse) GetSimilarTexts() []*VectorStoreServiceSearchTextResponseSimilarText {
+	if x != nil {
+		return x.SimilarTexts
+	}
+	return nil
+}
+
+type VectorStoreServiceLookupTextsRequestRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gte           float64                `protobuf:"fixed64,1,opt,name=gte,proto3" json:"gte,omitempty"`
+	Lte           float64                `protobuf:"fixed64,2,opt,name=lte,proto3" json:"lte,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceLookupTextsRequestRange) Reset() {
+	*x = VectorStoreServiceLookupTextsRequestRange{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceLookupTextsRequestRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceLookupTextsRequestRange) ProtoMessage() {}
+
+func (x *VectorStoreServiceLookupTextsRequestRange) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceLookupTextsRequestRange.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceLookupTextsRequestRange) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *VectorStoreServiceLookupTextsRequestRange) GetGte() float64 {
+	if x != nil {
+		return x.Gte
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceLookupTextsRequestRange) GetLte() float64 {
+	if x != nil {
+		return x.Lte
+	}
+	return 0
+}
+
+type VectorStoreServiceLookupTextsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// match are the metadata values the texts must equal: strings, booleans or numbers.
+	Match *structpb.Struct `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// ranges bound the numeric metadata values of the texts.
+	Ranges        map[string]*VectorStoreServiceLookupTextsRequestRange `protobuf:"bytes,2,rep,name=ranges,proto3" json:"ranges,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Limit         int64                                                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceLookupTextsRequest) Reset() {
+	*x = VectorStoreServiceLookupTextsRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceLookupTextsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceLookupTextsRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceLookupTextsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceLookupTextsRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceLookupTextsRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VectorStoreServiceLookupTextsRequest) GetMatch() *structpb.Struct {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceLookupTextsRequest) GetRanges() map[string]*VectorStoreServiceLookupTextsRequestRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceLookupTextsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type VectorStoreServiceLookupTextsResponseText struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceLookupTextsResponseText) Reset() {
+	*x = VectorStoreServiceLookupTextsResponseText{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceLookupTextsResponseText) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceLookupTextsResponseText) ProtoMessage() {}
+
+func (x *VectorStoreServiceLookupTextsResponseText) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceLookupTextsResponseText.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceLookupTextsResponseText) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VectorStoreServiceLookupTextsResponseText) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceLookupTextsResponseText) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type VectorStoreServiceLookupTextsResponse struct {
+	state         protoimpl.MessageState                       `protogen:"open.v1"`
+	Texts         []*VectorStoreServiceLookupTextsResponseText `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceLookupTextsResponse) Reset() {
+	*x = VectorStoreServiceLookupTextsResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceLookupTextsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceLookupTextsResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceLookupTextsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceLookupTextsResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceLookupTextsResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VectorStoreServiceLookupTextsResponse) GetTexts() []*VectorStoreServiceLookupTextsResponseText {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+type VectorStoreServiceStoredText struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text     string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Metadata *structpb.Struct       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// vector is the embedding of the text when requested.
+	Vector        []float32 `protobuf:"fixed32,4,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceStoredText) Reset() {
+	*x = VectorStoreServiceStoredText{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceStoredText) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceStoredText) ProtoMessage() {}
+
+func (x *VectorStoreServiceStoredText) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceStoredText.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceStoredText) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VectorStoreServiceStoredText) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceStoredText) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceStoredText) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceStoredText) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+type VectorStoreServiceGetTextsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	WithVectors   bool                   `protobuf:"varint,2,opt,name=with_vectors,proto3" json:"with_vectors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceGetTextsRequest) Reset() {
+	*x = VectorStoreServiceGetTextsRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceGetTextsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceGetTextsRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceGetTextsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceGetTextsRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceGetTextsRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *VectorStoreServiceGetTextsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceGetTextsRequest) GetWithVectors() bool {
+	if x != nil {
+		return x.WithVectors
+	}
+	return false
+}
+
+type VectorStoreServiceGetTextsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// texts are the found texts in the order of the request ids.
+	Texts         []*VectorStoreServiceStoredText `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceGetTextsResponse) Reset() {
+	*x = VectorStoreServiceGetTextsResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceGetTextsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceGetTextsResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceGetTextsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceGetTextsResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceGetTextsResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *VectorStoreServiceGetTextsResponse) GetTexts() []*VectorStoreServiceStoredText {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+type VectorStoreServiceListTextsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter is a search filter restricting the listed texts, all of them when empty.
+	Filter *structpb.Struct `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Limit  int64            `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// cursor is the next_cursor of the previous page, the first page when empty.
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	WithVectors   bool   `protobuf:"varint,4,opt,name=with_vectors,proto3" json:"with_vectors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceListTextsRequest) Reset() {
+	*x = VectorStoreServiceListTextsRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceListTextsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceListTextsRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceListTextsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceListTextsRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceListTextsRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *VectorStoreServiceListTextsRequest) GetFilter() *structpb.Struct {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceListTextsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *VectorStoreServiceListTextsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *VectorStoreServiceListTextsRequest) GetWithVectors() bool {
+	if x != nil {
+		return x.WithVectors
+	}
+	return false
+}
+
+type VectorStoreServiceListTextsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// texts are ordered by their ids.
+	Texts []*VectorStoreServiceStoredText `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`
+	// next_cursor lists the next page, empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceListTextsResponse) Reset() {
+	*x = VectorStoreServiceListTextsResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceListTextsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceListTextsResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceListTextsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceListTextsResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceListTextsResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VectorStoreServiceListTextsResponse) GetTexts() []*VectorStoreServiceStoredText {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceListTextsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type VectorStoreServiceDeleteTextsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ids   []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// dry_run counts the texts that would be deleted without deleting them.
+	DryRun        bool `protobuf:"varint,2,opt,name=dry_run,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceDeleteTextsRequest) Reset() {
+	*x = VectorStoreServiceDeleteTextsRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceDeleteTextsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceDeleteTextsRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceDeleteTextsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceDeleteTextsRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceDeleteTextsRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VectorStoreServiceDeleteTextsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceDeleteTextsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type VectorStoreServiceDeleteTextsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// deleted is the number of the deleted texts, or of the texts that would be deleted on a dry run.
+	Deleted       int64 `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceDeleteTextsResponse) Reset() {
+	*x = VectorStoreServiceDeleteTextsResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceDeleteTextsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceDeleteTextsResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceDeleteTextsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceDeleteTextsResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceDeleteTextsResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *VectorStoreServiceDeleteTextsResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type VectorStoreServiceDeleteByFilterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter is a required search filter selecting the texts to delete.
+	Filter *structpb.Struct `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// dry_run counts the texts that would be deleted without deleting them.
+	DryRun        bool `protobuf:"varint,2,opt,name=dry_run,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceDeleteByFilterRequest) Reset() {
+	*x = VectorStoreServiceDeleteByFilterRequest{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceDeleteByFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceDeleteByFilterRequest) ProtoMessage() {}
+
+func (x *VectorStoreServiceDeleteByFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceDeleteByFilterRequest.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceDeleteByFilterRequest) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VectorStoreServiceDeleteByFilterRequest) GetFilter() *structpb.Struct {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *VectorStoreServiceDeleteByFilterRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type VectorStoreServiceDeleteByFilterResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// deleted is the number of the deleted texts, or of the texts that would be deleted on a dry run.
+	Deleted       int64 `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStoreServiceDeleteByFilterResponse) Reset() {
+	*x = VectorStoreServiceDeleteByFilterResponse{}
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStoreServiceDeleteByFilterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStoreServiceDeleteByFilterResponse) ProtoMessage() {}
+
+func (x *VectorStoreServiceDeleteByFilterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vectorstore_v1_vectorstore_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStoreServiceDeleteByFilterResponse.ProtoReflect.Descriptor instead.
+func (*VectorStoreServiceDeleteByFilterResponse) Descriptor() ([]byte, []int) {
+	return file_vectorstore_v1_vectorstore_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *VectorStoreServiceDeleteByFilterResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+var File_vectorstore_v1_vectorstore_proto protoreflect.FileDescriptor
+
+var file_vectorstore_v1_vectorstore_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31,
+	0x2f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x83,
+	0x01, 0x0a, 0x28, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0xde, 0x01, 0x0a, 0x24, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a,
+	0x05, 0x74, 0x65, 0x78, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x54, 0x65, 0x78, 0x74, 0x52, 0x05, 0x74, 0x65, 0x78, 0x74, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x69, 0x64, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x69, 0x64, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x64, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69,
+	0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x64,
+	0x65, 0x64, 0x75, 0x70, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x64, 0x65, 0x64, 0x75, 0x70, 0x5f, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x7e, 0x0a, 0x2c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x6b,
+	0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x22, 0x0a, 0x0c, 0x64,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x25, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64,
+	0x73, 0x12, 0x56, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x22, 0x85, 0x01, 0x0a, 0x2a, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x33, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x5d, 0x0a, 0x2f, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x73,
+	0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x22, 0xc6, 0x01, 0x0a, 0x2b, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x73,
+	0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x63, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x8b, 0x01, 0x0a, 0x30, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x8b, 0x01, 0x0a, 0x30, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x6f, 0x76,
+	0x65, 0x72, 0x6c, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x07, 0x6f,
+	0x76, 0x65, 0x72, 0x6c, 0x61, 0x70, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x76,
+	0x65, 0x72, 0x6c, 0x61, 0x70, 0x22, 0x88, 0x02, 0x0a, 0x28, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x5e, 0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x40, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x5c, 0x0a, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x40, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x61, 0x0a, 0x31, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f,
+	0x69, 0x64, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x29, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5f, 0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x22, 0xa4, 0x02, 0x0a, 0x27, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a, 0x08, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x40, 0x2e,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52,
+	0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x28, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f,
+	0x69, 0x64, 0x73, 0x22, 0x5a, 0x0a, 0x26, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54,
+	0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x4d, 0x52, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x61, 0x6d, 0x62, 0x64, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x6c,
+	0x61, 0x6d, 0x62, 0x64, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x6b, 0x22,
+	0x65, 0x0a, 0x37, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xb2, 0x01, 0x0a, 0x2d, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x65,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x69, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x47, 0x2e,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xf3, 0x02, 0x0a, 0x23,
+	0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6b, 0x12, 0x1c, 0x0a,
+	0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x03,
+	0x6d, 0x6d, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x76, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x4d,
+	0x52, 0x52, 0x03, 0x6d, 0x6d, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x75,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x75, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x5d, 0x0a, 0x0a, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x65, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x90, 0x01, 0x0a, 0x2f, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65,
+	0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61,
+	0x72, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x8d, 0x01, 0x0a, 0x24, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x65, 0x0a,
+	0x0d, 0x73, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65,
+	0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61,
+	0x72, 0x54, 0x65, 0x78, 0x74, 0x52, 0x0d, 0x73, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x5f, 0x74,
+	0x65, 0x78, 0x74, 0x73, 0x22, 0x4f, 0x0a, 0x29, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
+	0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
+	0x67, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x6c, 0x74, 0x65, 0x22, 0xbb, 0x02, 0x0a, 0x24, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x6b,
+	0x75, 0x70, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d,
+	0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x58, 0x0a,
+	0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x40, 0x2e,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0x74, 0x0a,
+	0x0b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x4f,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x74, 0x0a, 0x29, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54,
+	0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x65, 0x78, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x78, 0x0a, 0x25, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x4f, 0x0a, 0x05, 0x74, 0x65, 0x78, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x39, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54, 0x65, 0x78, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x65, 0x78, 0x74, 0x52, 0x05, 0x74, 0x65,
+	0x78, 0x74, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x1c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64,
+	0x54, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x59, 0x0a, 0x21, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x54, 0x65,
+	0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x77, 0x69, 0x74, 0x68, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x22, 0x68, 0x0a, 0x22, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x05, 0x74, 0x65, 0x78, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x54,
+	0x65, 0x78, 0x74, 0x52, 0x05, 0x74, 0x65, 0x78, 0x74, 0x73, 0x22, 0xa7, 0x01, 0x0a, 0x22, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x22, 0x0a, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x23, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x05,
+	0x74, 0x65, 0x78, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x76, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x64, 0x54, 0x65, 0x78, 0x74, 0x52, 0x05, 0x74, 0x65, 0x78, 0x74, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x22, 0x52, 0x0a, 0x24, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x65,
+	0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64,
+	0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x22, 0x41, 0x0a, 0x25, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x74, 0x0a, 0x27, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x22,
+	0x44, 0x0a, 0x28, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x79, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x32, 0xc8, 0x0c, 0x0a, 0x12, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x9b, 0x01, 0x0a,
+	0x0b, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x12, 0x34, 0x2e, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x35, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73, 0x12, 0xb6, 0x01, 0x0a, 0x11, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73,
+	0x12, 0x3a, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74,
+	0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x54, 0x65, 0x78, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x20, 0x3a, 0x01, 0x2a, 0x22, 0x1b, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x5f, 0x74, 0x65, 0x78, 0x74,
+	0x73, 0x28, 0x01, 0x12, 0xab, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x38, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x39, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x22, 0x18, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
+	0x2f, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x83, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x97, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x12, 0x33, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x76, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x3a, 0x01, 0x2a, 0x22, 0x13, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x9b, 0x01, 0x0a, 0x0b, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54, 0x65, 0x78, 0x74,
+	0x73, 0x12, 0x34, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54, 0x65, 0x78, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73, 0x12,
+	0x8f, 0x01, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x12, 0x31, 0x2e, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x47, 0x65, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x32, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x3a, 0x01, 0x2a, 0x22, 0x11,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x65, 0x78, 0x74,
+	0x73, 0x12, 0x93, 0x01, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x12,
+	0x32, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x78, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17,
+	0x3a, 0x01, 0x2a, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x69, 0x73,
+	0x74, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73, 0x12, 0x9b, 0x01, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x54, 0x65, 0x78, 0x74, 0x73, 0x12, 0x34, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x65, 0x78, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22,
+	0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f,
+	0x74, 0x65, 0x78, 0x74, 0x73, 0x12, 0xa8, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x37, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x38, 0x2e, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x79, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x22, 0x18, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x62, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x72, 0x69, 0x61, 0x33, 0x70, 0x70, 0x70, 0x2f, 0x72, 0x61, 0x67, 0x2d, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -422,31 +2392,97 @@ func file_vectorstore_v1_vectorstore_proto_rawDescGZIP() []byte {
 	return file_vectorstore_v1_vectorstore_proto_rawDescData
 }
 
-var file_vectorstore_v1_vectorstore_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_vectorstore_v1_vectorstore_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_vectorstore_v1_vectorstore_proto_goTypes = []any{
-	(*VectorStoreServiceInsertTextsRequestText)(nil),        // 0: vectorstore.v1.VectorStoreServiceInsertTextsRequestText
-	(*VectorStoreServiceInsertTextsRequest)(nil),            // 1: vectorstore.v1.VectorStoreServiceInsertTextsRequest
-	(*VectorStoreServiceInsertTextsResponse)(nil),           // 2: vectorstore.v1.VectorStoreServiceInsertTextsResponse
-	(*VectorStoreServiceSearchTextRequest)(nil),             // 3: vectorstore.v1.VectorStoreServiceSearchTextRequest
-	(*VectorStoreServiceSearchTextResponseSimilarText)(nil), // 4: vectorstore.v1.VectorStoreServiceSearchTextResponseSimilarText
-	(*VectorStoreServiceSearchTextResponse)(nil),            // 5: vectorstore.v1.VectorStoreServiceSearchTextResponse
-	(*structpb.Struct)(nil),                                 // 6: google.protobuf.Struct
+	(*VectorStoreServiceInsertTextsRequestText)(nil),                // 0: vectorstore.v1.VectorStoreServiceInsertTextsRequestText
+	(*VectorStoreServiceInsertTextsRequest)(nil),                    // 1: vectorstore.v1.VectorStoreServiceInsertTextsRequest
+	(*VectorStoreServiceInsertTextsResponseSkipped)(nil),            // 2: vectorstore.v1.VectorStoreServiceInsertTextsResponseSkipped
+	(*VectorStoreServiceInsertTextsResponse)(nil),                   // 3: vectorstore.v1.VectorStoreServiceInsertTextsResponse
+	(*VectorStoreServiceStreamInsertTextsRequest)(nil),              // 4: vectorstore.v1.VectorStoreServiceStreamInsertTextsRequest
+	(*VectorStoreServiceStreamInsertTextsResponseItem)(nil),         // 5: vectorstore.v1.VectorStoreServiceStreamInsertTextsResponseItem
+	(*VectorStoreServiceStreamInsertTextsResponse)(nil),             // 6: vectorstore.v1.VectorStoreServiceStreamInsertTextsResponse
+	(*VectorStoreServiceInsertDocumentsRequestDocument)(nil),        // 7: vectorstore.v1.VectorStoreServiceInsertDocumentsRequestDocument
+	(*VectorStoreServiceInsertDocumentsRequestChunking)(nil),        // 8: vectorstore.v1.VectorStoreServiceInsertDocumentsRequestChunking
+	(*VectorStoreServiceInsertDocumentsRequest)(nil),                // 9: vectorstore.v1.VectorStoreServiceInsertDocumentsRequest
+	(*VectorStoreServiceInsertDocumentsResponseDocument)(nil),       // 10: vectorstore.v1.VectorStoreServiceInsertDocumentsResponseDocument
+	(*VectorStoreServiceInsertDocumentsResponse)(nil),               // 11: vectorstore.v1.VectorStoreServiceInsertDocumentsResponse
+	(*VectorStoreServiceUploadDocumentRequest)(nil),                 // 12: vectorstore.v1.VectorStoreServiceUploadDocumentRequest
+	(*VectorStoreServiceUploadDocumentResponse)(nil),                // 13: vectorstore.v1.VectorStoreServiceUploadDocumentResponse
+	(*VectorStoreServiceSearchTextRequestMMR)(nil),                  // 14: vectorstore.v1.VectorStoreServiceSearchTextRequestMMR
+	(*VectorStoreServiceSearchTextRequestFederationCollection)(nil), // 15: vectorstore.v1.VectorStoreServiceSearchTextRequestFederationCollection
+	(*VectorStoreServiceSearchTextRequestFederation)(nil),           // 16: vectorstore.v1.VectorStoreServiceSearchTextRequestFederation
+	(*VectorStoreServiceSearchTextRequest)(nil),                     // 17: vectorstore.v1.VectorStoreServiceSearchTextRequest
+	(*VectorStoreServiceSearchTextResponseSimilarText)(nil),         // 18: vectorstore.v1.VectorStoreServiceSearchTextResponseSimilarText
+	(*VectorStoreServiceSearchTextResponse)(nil),                    // 19: vectorstore.v1.VectorStoreServiceSearchTextResponse
+	(*VectorStoreServiceLookupTextsRequestRange)(nil),               // 20: vectorstore.v1.VectorStoreServiceLookupTextsRequestRange
+	(*VectorStoreServiceLookupTextsRequest)(nil),                    // 21: vectorstore.v1.VectorStoreServiceLookupTextsRequest
+	(*VectorStoreServiceLookupTextsResponseText)(nil),               // 22: vectorstore.v1.VectorStoreServiceLookupTextsResponseText
+	(*VectorStoreServiceLookupTextsResponse)(nil),                   // 23: vectorstore.v1.VectorStoreServiceLookupTextsResponse
+	(*VectorStoreServiceStoredText)(nil),                            // 24: vectorstore.v1.VectorStoreServiceStoredText
+	(*VectorStoreServiceGetTextsRequest)(nil),                       // 25: vectorstore.v1.VectorStoreServiceGetTextsRequest
+	(*VectorStoreServiceGetTextsResponse)(nil),                      // 26: vectorstore.v1.VectorStoreServiceGetTextsResponse
+	(*VectorStoreServiceListTextsRequest)(nil),                      // 27: vectorstore.v1.VectorStoreServiceListTextsRequest
+	(*VectorStoreServiceListTextsResponse)(nil),                     // 28: vectorstore.v1.VectorStoreServiceListTextsResponse
+	(*VectorStoreServiceDeleteTextsRequest)(nil),                    // 29: vectorstore.v1.VectorStoreServiceDeleteTextsRequest
+	(*VectorStoreServiceDeleteTextsResponse)(nil),                   // 30: vectorstore.v1.VectorStoreServiceDeleteTextsResponse
+	(*VectorStoreServiceDeleteByFilterRequest)(nil),                 // 31: vectorstore.v1.VectorStoreServiceDeleteByFilterRequest
+	(*VectorStoreServiceDeleteByFilterResponse)(nil),                // 32: vectorstore.v1.VectorStoreServiceDeleteByFilterResponse
+	nil,                     // 33: vectorstore.v1.VectorStoreServiceLookupTextsRequest.RangesEntry
+	(*structpb.Struct)(nil), // 34: google.protobuf.Struct
 }
 var file_vectorstore_v1_vectorstore_proto_depIdxs = []int32{
-	6, // 0: vectorstore.v1.VectorStoreServiceInsertTextsRequestText.metadata:type_name -> google.protobuf.Struct
-	0, // 1: vectorstore.v1.VectorStoreServiceInsertTextsRequest.texts:type_name -> vectorstore.v1.VectorStoreServiceInsertTextsRequestText
-	6, // 2: vectorstore.v1.VectorStoreServiceSearchTextRequest.filter:type_name -> google.protobuf.Struct
-	6, // 3: vectorstore.v1.VectorStoreServiceSearchTextResponseSimilarText.metadata:type_name -> google.protobuf.Struct
-	4, // 4: vectorstore.v1.VectorStoreServiceSearchTextResponse.similar_texts:type_name -> vectorstore.v1.VectorStoreServiceSearchTextResponseSimilarText
-	1, // 5: vectorstore.v1.VectorStoreService.InsertTexts:input_type -> vectorstore.v1.VectorStoreServiceInsertTextsRequest
-	3, // 6: vectorstore.v1.VectorStoreService.SearchText:input_type -> vectorstore.v1.VectorStoreServiceSearchTextRequest
-	2, // 7: vectorstore.v1.VectorStoreService.InsertTexts:output_type -> vectorstore.v1.VectorStoreServiceInsertTextsResponse
-	5, // 8: vectorstore.v1.VectorStoreService.SearchText:output_type -> vectorstore.v1.VectorStoreServiceSearchTextResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	34, // 0: vectorstore.v1.VectorStoreServiceInsertTextsRequestText.metadata:type_name -> google.protobuf.Struct
+	0,  // 1: vectorstore.v1.VectorStoreServiceInsertTextsRequest.texts:type_name -> vectorstore.v1.VectorStoreServiceInsertTextsRequestText
+	2,  // 2: vectorstore.v1.VectorStoreServiceInsertTextsResponse.skipped:type_name -> vectorstore.v1.VectorStoreServiceInsertTextsResponseSkipped
+	34, // 3: vectorstore.v1.VectorStoreServiceStreamInsertTextsRequest.metadata:type_name -> google.protobuf.Struct
+	5,  // 4: vectorstore.v1.VectorStoreServiceStreamInsertTextsResponse.failed_items:type_name -> vectorstore.v1.VectorStoreServiceStreamInsertTextsResponseItem
+	34, // 5: vectorstore.v1.VectorStoreServiceInsertDocumentsRequestDocument.metadata:type_name -> google.protobuf.Struct
+	7,  // 6: vectorstore.v1.VectorStoreServiceInsertDocumentsRequest.documents:type_name -> vectorstore.v1.VectorStoreServiceInsertDocumentsRequestDocument
+	8,  // 7: vectorstore.v1.VectorStoreServiceInsertDocumentsRequest.chunking:type_name -> vectorstore.v1.VectorStoreServiceInsertDocumentsRequestChunking
+	10, // 8: vectorstore.v1.VectorStoreServiceInsertDocumentsResponse.documents:type_name -> vectorstore.v1.VectorStoreServiceInsertDocumentsResponseDocument
+	34, // 9: vectorstore.v1.VectorStoreServiceUploadDocumentRequest.metadata:type_name -> google.protobuf.Struct
+	8,  // 10: vectorstore.v1.VectorStoreServiceUploadDocumentRequest.chunking:type_name -> vectorstore.v1.VectorStoreServiceInsertDocumentsRequestChunking
+	15, // 11: vectorstore.v1.VectorStoreServiceSearchTextRequestFederation.collections:type_name -> vectorstore.v1.VectorStoreServiceSearchTextRequestFederationCollection
+	34, // 12: vectorstore.v1.VectorStoreServiceSearchTextRequest.filter:type_name -> google.protobuf.Struct
+	14, // 13: vectorstore.v1.VectorStoreServiceSearchTextRequest.mmr:type_name -> vectorstore.v1.VectorStoreServiceSearchTextRequestMMR
+	16, // 14: vectorstore.v1.VectorStoreServiceSearchTextRequest.federation:type_name -> vectorstore.v1.VectorStoreServiceSearchTextRequestFederation
+	34, // 15: vectorstore.v1.VectorStoreServiceSearchTextResponseSimilarText.metadata:type_name -> google.protobuf.Struct
+	18, // 16: vectorstore.v1.VectorStoreServiceSearchTextResponse.similar_texts:type_name -> vectorstore.v1.VectorStoreServiceSearchTextResponseSimilarText
+	34, // 17: vectorstore.v1.VectorStoreServiceLookupTextsRequest.match:type_name -> google.protobuf.Struct
+	33, // 18: vectorstore.v1.VectorStoreServiceLookupTextsRequest.ranges:type_name -> vectorstore.v1.VectorStoreServiceLookupTextsRequest.RangesEntry
+	34, // 19: vectorstore.v1.VectorStoreServiceLookupTextsResponseText.metadata:type_name -> google.protobuf.Struct
+	22, // 20: vectorstore.v1.VectorStoreServiceLookupTextsResponse.texts:type_name -> vectorstore.v1.VectorStoreServiceLookupTextsResponseText
+	34, // 21: vectorstore.v1.VectorStoreServiceStoredText.metadata:type_name -> google.protobuf.Struct
+	24, // 22: vectorstore.v1.VectorStoreServiceGetTextsResponse.texts:type_name -> vectorstore.v1.VectorStoreServiceStoredText
+	34, // 23: vectorstore.v1.VectorStoreServiceListTextsRequest.filter:type_name -> google.protobuf.Struct
+	24, // 24: vectorstore.v1.VectorStoreServiceListTextsResponse.texts:type_name -> vectorstore.v1.VectorStoreServiceStoredText
+	34, // 25: vectorstore.v1.VectorStoreServiceDeleteByFilterRequest.filter:type_name -> google.protobuf.Struct
+	20, // 26: vectorstore.v1.VectorStoreServiceLookupTextsRequest.RangesEntry.value:type_name -> vectorstore.v1.VectorStoreServiceLookupTextsRequestRange
+	1,  // 27: vectorstore.v1.VectorStoreService.InsertTexts:input_type -> vectorstore.v1.VectorStoreServiceInsertTextsRequest
+	4,  // 28: vectorstore.v1.VectorStoreService.StreamInsertTexts:input_type -> vectorstore.v1.VectorStoreServiceStreamInsertTextsRequest
+	9,  // 29: vectorstore.v1.VectorStoreService.InsertDocuments:input_type -> vectorstore.v1.VectorStoreServiceInsertDocumentsRequest
+	12, // 30: vectorstore.v1.VectorStoreService.UploadDocument:input_type -> vectorstore.v1.VectorStoreServiceUploadDocumentRequest
+	17, // 31: vectorstore.v1.VectorStoreService.SearchText:input_type -> vectorstore.v1.VectorStoreServiceSearchTextRequest
+	21, // 32: vectorstore.v1.VectorStoreService.LookupTexts:input_type -> vectorstore.v1.VectorStoreServiceLookupTextsRequest
+	25, // 33: vectorstore.v1.VectorStoreService.GetTexts:input_type -> vectorstore.v1.VectorStoreServiceGetTextsRequest
+	27, // 34: vectorstore.v1.VectorStoreService.ListTexts:input_type -> vectorstore.v1.VectorStoreServiceListTextsRequest
+	29, // 35: vectorstore.v1.VectorStoreService.DeleteTexts:input_type -> vectorstore.v1.VectorStoreServiceDeleteTextsRequest
+	31, // 36: vectorstore.v1.VectorStoreService.DeleteByFilter:input_type -> vectorstore.v1.VectorStoreServiceDeleteByFilterRequest
+	3,  // 37: vectorstore.v1.VectorStoreService.InsertTexts:output_type -> vectorstore.v1.VectorStoreServiceInsertTextsResponse
+	6,  // 38: vectorstore.v1.VectorStoreService.StreamInsertTexts:output_type -> vectorstore.v1.VectorStoreServiceStreamInsertTextsResponse
+	11, // 39: vectorstore.v1.VectorStoreService.InsertDocuments:output_type -> vectorstore.v1.VectorStoreServiceInsertDocumentsResponse
+	13, // 40: vectorstore.v1.VectorStoreService.UploadDocument:output_type -> vectorstore.v1.VectorStoreServiceUploadDocumentResponse
+	19, // 41: vectorstore.v1.VectorStoreService.SearchText:output_type -> vectorstore.v1.VectorStoreServiceSearchTextResponse
+	23, // 42: vectorstore.v1.VectorStoreService.LookupTexts:output_type -> vectorstore.v1.VectorStoreServiceLookupTextsResponse
+	26, // 43: vectorstore.v1.VectorStoreService.GetTexts:output_type -> vectorstore.v1.VectorStoreServiceGetTextsResponse
+	28, // 44: vectorstore.v1.VectorStoreService.ListTexts:output_type -> vectorstore.v1.VectorStoreServiceListTextsResponse
+	30, // 45: vectorstore.v1.VectorStoreService.DeleteTexts:output_type -> vectorstore.v1.VectorStoreServiceDeleteTextsResponse
+	32, // 46: vectorstore.v1.VectorStoreService.DeleteByFilter:output_type -> vectorstore.v1.VectorStoreServiceDeleteByFilterResponse
+	37, // [37:47] is the sub-list for method output_type
+	27, // [27:37] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_vectorstore_v1_vectorstore_proto_init() }
@@ -454,13 +2490,14 @@ func file_vectorstore_v1_vectorstore_proto_init() {
 	if File_vectorstore_v1_vectorstore_proto != nil {
 		return
 	}
+	file_vectorstore_v1_vectorstore_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_vectorstore_v1_vectorstore_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VectorStoreService_InsertTexts_FullMethodName = "/vectorstore.v1.VectorStoreService/InsertTexts"
-	VectorStoreService_SearchText_FullMethodName  = "/vectorstore.v1.VectorStoreService/SearchText"
+	VectorStoreService_InsertTexts_FullMethodName       = "/vectorstore.v1.VectorStoreService/InsertTexts"
+	VectorStoreService_StreamInsertTexts_FullMethodName = "/vectorstore.v1.VectorStoreService/StreamInsertTexts"
+	VectorStoreService_InsertDocuments_FullMethodName   = "/vectorstore.v1.VectorStoreService/InsertDocuments"
+	VectorStoreService_UploadDocument_FullMethodName    = "/vectorstore.v1.VectorStoreService/UploadDocument"
+	VectorStoreService_SearchText_FullMethodName        = "/vectorstore.v1.VectorStoreService/SearchText"
+	VectorStoreService_LookupTexts_FullMethodName       = "/vectorstore.v1.VectorStoreService/LookupTexts"
+	VectorStoreService_GetTexts_FullMethodName          = "/vectorstore.v1.VectorStoreService/GetTexts"
+	VectorStoreService_ListTexts_FullMethodName         = "/vectorstore.v1.VectorStoreService/ListTexts"
+	VectorStoreService_DeleteTexts_FullMethodName       = "/vectorstore.v1.VectorStoreService/DeleteTexts"
+	VectorStoreService_DeleteByFilter_FullMethodName    = "/vectorstore.v1.VectorStoreService/DeleteByFilter"
 )
 
 // VectorStoreServiceClient is the client API for VectorStoreService service.
@@ -28,7 +36,20 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VectorStoreServiceClient interface {
 	InsertTexts(ctx context.Context, in *VectorStoreServiceInsertTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceInsertTextsResponse, error)
+	// StreamInsertTexts inserts a stream of texts in batches, failing the
+	// invalid texts and the texts of the failed batches on their own. The
+	// gateway takes the stream as newline delimited json.
+	StreamInsertTexts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse], error)
+	InsertDocuments(ctx context.Context, in *VectorStoreServiceInsertDocumentsRequest, opts ...grpc.CallOption) (*VectorStoreServiceInsertDocumentsResponse, error)
+	// UploadDocument is served on the gateway as the multipart form upload
+	// of /api/v1/upload_document.
+	UploadDocument(ctx context.Context, in *VectorStoreServiceUploadDocumentRequest, opts ...grpc.CallOption) (*VectorStoreServiceUploadDocumentResponse, error)
 	SearchText(ctx context.Context, in *VectorStoreServiceSearchTextRequest, opts ...grpc.CallOption) (*VectorStoreServiceSearchTextResponse, error)
+	LookupTexts(ctx context.Context, in *VectorStoreServiceLookupTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceLookupTextsResponse, error)
+	GetTexts(ctx context.Context, in *VectorStoreServiceGetTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceGetTextsResponse, error)
+	ListTexts(ctx context.Context, in *VectorStoreServiceListTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceListTextsResponse, error)
+	DeleteTexts(ctx context.Context, in *VectorStoreServiceDeleteTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceDeleteTextsResponse, error)
+	DeleteByFilter(ctx context.Context, in *VectorStoreServiceDeleteByFilterRequest, opts ...grpc.CallOption) (*VectorStoreServiceDeleteByFilterResponse, error)
 }
 
 type vectorStoreServiceClient struct {
@@ -49,6 +70,39 @@ func (c *vectorStoreServiceClient) InsertTexts(ctx context.Context, in *VectorSt
 	return out, nil
 }
 
+func (c *vectorStoreServiceClient) StreamInsertTexts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VectorStoreService_ServiceDesc.Streams[0], VectorStoreService_StreamInsertTexts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorStoreService_StreamInsertTextsClient = grpc.ClientStreamingClient[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse]
+
+func (c *vectorStoreServiceClient) InsertDocuments(ctx context.Context, in *VectorStoreServiceInsertDocumentsRequest, opts ...grpc.CallOption) (*VectorStoreServiceInsertDocumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorStoreServiceInsertDocumentsResponse)
+	err := c.cc.Invoke(ctx, VectorStoreService_InsertDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorStoreServiceClient) UploadDocument(ctx context.Context, in *VectorStoreServiceUploadDocumentRequest, opts ...grpc.CallOption) (*VectorStoreServiceUploadDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorStoreServiceUploadDocumentResponse)
+	err := c.cc.Invoke(ctx, VectorStoreService_UploadDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vectorStoreServiceClient) SearchText(ctx context.Context, in *VectorStoreServiceSearchTextRequest, opts ...grpc.CallOption) (*VectorStoreServiceSearchTextResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(VectorStoreServiceSearchTextResponse)
@@ -59,12 +113,75 @@ func (c *vectorStoreServiceClient) SearchText(ctx context.Context, in *VectorSto
 	return out, nil
 }
 
+func (c *vectorStoreServiceClient) LookupTexts(ctx context.Context, in *VectorStoreServiceLookupTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceLookupTextsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorStoreServiceLookupTextsResponse)
+	err := c.cc.Invoke(ctx, VectorStoreService_LookupTexts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorStoreServiceClient) GetTexts(ctx context.Context, in *VectorStoreServiceGetTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceGetTextsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorStoreServiceGetTextsResponse)
+	err := c.cc.Invoke(ctx, VectorStoreService_GetTexts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorStoreServiceClient) ListTexts(ctx context.Context, in *VectorStoreServiceListTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceListTextsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorStoreServiceListTextsResponse)
+	err := c.cc.Invoke(ctx, VectorStoreService_ListTexts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorStoreServiceClient) DeleteTexts(ctx context.Context, in *VectorStoreServiceDeleteTextsRequest, opts ...grpc.CallOption) (*VectorStoreServiceDeleteTextsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorStoreServiceDeleteTextsResponse)
+	err := c.cc.Invoke(ctx, VectorStoreService_DeleteTexts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorStoreServiceClient) DeleteByFilter(ctx context.Context, in *VectorStoreServiceDeleteByFilterRequest, opts ...grpc.CallOption) (*VectorStoreServiceDeleteByFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorStoreServiceDeleteByFilterResponse)
+	err := c.cc.Invoke(ctx, VectorStoreService_DeleteByFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VectorStoreServiceServer is the server API for VectorStoreService service.
 // All implementations must embed UnimplementedVectorStoreServiceServer
 // for forward compatibility.
 type VectorStoreServiceServer interface {
 	InsertTexts(context.Context, *VectorStoreServiceInsertTextsRequest) (*VectorStoreServiceInsertTextsResponse, error)
+	// StreamInsertTexts inserts a stream of texts in batches, failing the
+	// invalid texts and the texts of the failed batches on their own. The
+	// gateway takes the stream as newline delimited json.
+	StreamInsertTexts(grpc.ClientStreamingServer[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse]) error
+	InsertDocuments(context.Context, *VectorStoreServiceInsertDocumentsRequest) (*VectorStoreServiceInsertDocumentsResponse, error)
+	// UploadDocument is served on the gateway as the multipart form upload
+	// of /api/v1/upload_document.
+	UploadDocument(context.Context, *VectorStoreServiceUploadDocumentRequest) (*VectorStoreServiceUploadDocumentResponse, error)
 	SearchText(context.Context, *VectorStoreServiceSearchTextRequest) (*VectorStoreServiceSearchTextResponse, error)
+	LookupTexts(context.Context, *VectorStoreServiceLookupTextsRequest) (*VectorStoreServiceLookupTextsResponse, error)
+	GetTexts(context.Context, *VectorStoreServiceGetTextsRequest) (*VectorStoreServiceGetTextsResponse, error)
+	ListTexts(context.Context, *VectorStoreServiceListTextsRequest) (*VectorStoreServiceListTextsResponse, error)
+	DeleteTexts(context.Context, *VectorStoreServiceDeleteTextsRequest) (*VectorStoreServiceDeleteTextsResponse, error)
+	DeleteByFilter(context.Context, *VectorStoreServiceDeleteByFilterRequest) (*VectorStoreServiceDeleteByFilterResponse, error)
 	mustEmbedUnimplementedVectorStoreServiceServer()
 }
 
@@ -78,9 +195,33 @@ type UnimplementedVectorStoreServiceServer struct{}
 func (UnimplementedVectorStoreServiceServer) InsertTexts(context.Context, *VectorStoreServiceInsertTextsRequest) (*VectorStoreServiceInsertTextsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InsertTexts not implemented")
 }
+func (UnimplementedVectorStoreServiceServer) StreamInsertTexts(grpc.ClientStreamingServer[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamInsertTexts not implemented")
+}
+func (UnimplementedVectorStoreServiceServer) InsertDocuments(context.Context, *VectorStoreServiceInsertDocumentsRequest) (*VectorStoreServiceInsertDocumentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertDocuments not implemented")
+}
+func (UnimplementedVectorStoreServiceServer) UploadDocument(context.Context, *VectorStoreServiceUploadDocumentRequest) (*VectorStoreServiceUploadDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadDocument not implemented")
+}
 func (UnimplementedVectorStoreServiceServer) SearchText(context.Context, *VectorStoreServiceSearchTextRequest) (*VectorStoreServiceSearchTextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchText not implemented")
 }
+func (UnimplementedVectorStoreServiceServer) LookupTexts(context.Context, *VectorStoreServiceLookupTextsRequest) (*VectorStoreServiceLookupTextsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupTexts not implemented")
+}
+func (UnimplementedVectorStoreServiceServer) GetTexts(context.Context, *VectorStoreServiceGetTextsRequest) (*VectorStoreServiceGetTextsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTexts not implemented")
+}
+func (UnimplementedVectorStoreServiceServer) ListTexts(context.Context, *VectorStoreServiceListTextsRequest) (*VectorStoreServiceListTextsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTexts not implemented")
+}
+func (UnimplementedVectorStoreServiceServer) DeleteTexts(context.Context, *VectorStoreServiceDeleteTextsRequest) (*VectorStoreServiceDeleteTextsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTexts not implemented")
+}
+func (UnimplementedVectorStoreServiceServer) DeleteByFilter(context.Context, *VectorStoreServiceDeleteByFilterRequest) (*VectorStoreServiceDeleteByFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteByFilter not implemented")
+}
 func (UnimplementedVectorStoreServiceServer) mustEmbedUnimplementedVectorStoreServiceServer() {}
 func (UnimplementedVectorStoreServiceServer) testEmbeddedByValue()                            {}
 
@@ -120,6 +261,49 @@ func _VectorStoreService_InsertTexts_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VectorStoreService_StreamInsertTexts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VectorStoreServiceServer).StreamInsertTexts(&grpc.GenericServerStream[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VectorStoreService_StreamInsertTextsServer = grpc.ClientStreamingServer[VectorStoreServiceStreamInsertTextsRequest, VectorStoreServiceStreamInsertTextsResponse]
+
+func _VectorStoreService_InsertDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorStoreServiceInsertDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorStoreServiceServer).InsertDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorStoreService_InsertDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorStoreServiceServer).InsertDocuments(ctx, req.(*VectorStoreServiceInsertDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorStoreService_UploadDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorStoreServiceUploadDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorStoreServiceServer).UploadDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorStoreService_UploadDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorStoreServiceServer).UploadDocument(ctx, req.(*VectorStoreServiceUploadDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VectorStoreService_SearchText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VectorStoreServiceSearchTextRequest)
 	if err := dec(in); err != nil {
@@ -138,6 +322,96 @@ func _VectorStoreService_SearchText_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VectorStoreService_LookupTexts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorStoreServiceLookupTextsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorStoreServiceServer).LookupTexts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorStoreService_LookupTexts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorStoreServiceServer).LookupTexts(ctx, req.(*VectorStoreServiceLookupTextsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorStoreService_GetTexts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorStoreServiceGetTextsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorStoreServiceServer).GetTexts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorStoreService_GetTexts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorStoreServiceServer).GetTexts(ctx, req.(*VectorStoreServiceGetTextsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorStoreService_ListTexts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorStoreServiceListTextsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorStoreServiceServer).ListTexts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorStoreService_ListTexts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorStoreServiceServer).ListTexts(ctx, req.(*VectorStoreServiceListTextsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorStoreService_DeleteTexts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorStoreServiceDeleteTextsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorStoreServiceServer).DeleteTexts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorStoreService_DeleteTexts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorStoreServiceServer).DeleteTexts(ctx, req.(*VectorStoreServiceDeleteTextsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorStoreService_DeleteByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorStoreServiceDeleteByFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorStoreServiceServer).DeleteByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorStoreService_DeleteByFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorStoreServiceServer).DeleteByFilter(ctx, req.(*VectorStoreServiceDeleteByFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VectorStoreService_ServiceDesc is the grpc.ServiceDesc for VectorStoreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -149,11 +423,45 @@ var VectorStoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InsertTexts",
 			Handler:    _VectorStoreService_InsertTexts_Handler,
 		},
+		{
+			MethodName: "InsertDocuments",
+			Handler:    _VectorStoreService_InsertDocuments_Handler,
+		},
+		{
+			MethodName: "UploadDocument",
+			Handler:    _VectorStoreService_UploadDocument_Handler,
+		},
 		{
 			MethodName: "SearchText",
 			Handler:    _VectorStoreService_SearchText_Handler,
 		},
+		{
+			MethodName: "LookupTexts",
+			Handler:    _VectorStoreService_LookupTexts_Handler,
+		},
+		{
+			MethodName: "GetTexts",
+			Handler:    _VectorStoreService_GetTexts_Handler,
+		},
+		{
+			MethodName: "ListTexts",
+			Handler:    _VectorStoreService_ListTexts_Handler,
+		},
+		{
+			MethodName: "DeleteTexts",
+			Handler:    _VectorStoreService_DeleteTexts_Handler,
+		},
+		{
+			MethodName: "DeleteByFilter",
+			Handler:    _VectorStoreService_DeleteByFilter_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInsertTexts",
+			Handler:       _VectorStoreService_StreamInsertTexts_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "vectorstore/v1/vectorstore.proto",
 }
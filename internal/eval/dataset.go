@@ -0,0 +1,74 @@
+package eval
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	goccy_json "github.com/goccy/go-json"
+)
+
+// Example is a line of a JSONL dataset. At least one of ExpectedSourceIDs
+// and ReferenceAnswer should be set.
+type Example struct {
+	ID                string   `json:"id"`
+	Question          string   `json:"question"`
+	ExpectedSourceIDs []string `json:"expected_source_ids,omitempty"`
+	ReferenceAnswer   string   `json:"reference_answer,omitempty"`
+}
+
+func ReadDatasetFile(path string) ([]*Example, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer file.Close()
+
+	return ReadDataset(file)
+}
+
+func ReadDataset(r io.Reader) ([]*Example, error) {
+	var examples []*Example
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+
+		var example Example
+		if err := goccy_json.Unmarshal(content, &example); err != nil {
+			return nil, fmt.Errorf("failed to decode dataset line %d: %w", line, err)
+		}
+
+		if example.Question == "" {
+			return nil, fmt.Errorf("dataset line %d: question is empty", line)
+		}
+		if example.ID == "" {
+			example.ID = fmt.Sprintf("line-%d", line)
+		}
+
+		examples = append(examples, &example)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+
+	return examples, nil
+}
+
+// WriteDataset writes the examples as JSONL.
+func WriteDataset(w io.Writer, examples []*Example) error {
+	encoder := goccy_json.NewEncoder(w)
+	for _, example := range examples {
+		if err := encoder.Encode(example); err != nil {
+			return fmt.Errorf("failed to encode example %s: %w", example.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/auth"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+)
+
+const (
+	MetricRecallAtK          = "recall_at_k"
+	MetricMRR                = "mrr"
+	MetricNDCGAtK            = "ndcg_at_k"
+	MetricRetrievalRecallAtK = "retrieval_recall_at_k"
+	MetricRetrievalMRR       = "retrieval_mrr"
+	MetricRetrievalNDCGAtK   = "retrieval_ndcg_at_k"
+	MetricRerankLiftMRR      = "rerank_lift_mrr"
+	MetricRerankLiftNDCGAtK  = "rerank_lift_ndcg_at_k"
+	MetricAnswerF1           = "answer_f1"
+)
+
+const (
+	defaultK             = 5
+	defaultSourceIDField = "source_id"
+)
+
+type Config struct {
+	// K is the cutoff of recall@k and nDCG@k.
+	K int
+	// SourceIDField is the metadata field of the retrieved texts holding their source id.
+	SourceIDField string
+}
+
+func (config *Config) apply() {
+	if config.K <= 0 {
+		config.K = defaultK
+	}
+	if config.SourceIDField == "" {
+		config.SourceIDField = defaultSourceIDField
+	}
+}
+
+// Evaluator runs a dataset through the rag use case with explain enabled and
+// scores the traced retrieval, rerank and answer of every example.
+type Evaluator struct {
+	uc     usecase.UseCase
+	config *Config
+	logger *slog.Logger
+}
+
+func NewEvaluator(uc usecase.UseCase, config *Config, logger *slog.Logger) *Evaluator {
+	if config == nil {
+		config = &Config{}
+	}
+	config.apply()
+
+	return &Evaluator{
+		uc:     uc,
+		config: config,
+		logger: logger,
+	}
+}
+
+type ExampleResult struct {
+	ID                 string             `json:"id"`
+	Question           string             `json:"question"`
+	ExpectedSourceIDs  []string           `json:"expected_source_ids,omitempty"`
+	RetrievedSourceIDs []string           `json:"retrieved_source_ids,omitempty"`
+	RankedSourceIDs    []string           `json:"ranked_source_ids,omitempty"`
+	Answer             string             `json:"answer,omitempty"`
+	Metrics            map[string]float64 `json:"metrics,omitempty"`
+	Error              string             `json:"error,omitempty"`
+}
+
+func (e *Evaluator) Run(ctx context.Context, examples []*Example) *Report {
+	// explain needs the admin scope
+	ctx = auth.WithCaller(ctx, &auth.Caller{Scopes: []auth.Scope{auth.ScopeAdmin}})
+
+	results := make([]*ExampleResult, 0, len(examples))
+	for _, example := range examples {
+		result := e.runExample(ctx, example)
+		if result.Error != "" {
+			e.logger.ErrorContext(ctx, "failed to evaluate example", slog.String("id", example.ID), slog.String("error", result.Error))
+		}
+		results = append(results, result)
+	}
+
+	return newReport(e.config.K, results)
+}
+
+func (e *Evaluator) runExample(ctx context.Context, example *Example) *ExampleResult {
+	result := &ExampleResult{
+		ID:                example.ID,
+		Question:          example.Question,
+		ExpectedSourceIDs: example.ExpectedSourceIDs,
+		Metrics:           map[string]float64{},
+	}
+
+	queryResult, err := e.uc.Query(ctx, &domain.QueryInput{
+		Query:   example.Question,
+		Explain: true,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if queryResult.Trace == nil {
+		result.Error = "query returned no trace"
+		return result
+	}
+
+	result.Answer = queryResult.Content
+	result.RetrievedSourceIDs, result.RankedSourceIDs = e.sourceIDs(queryResult.Trace)
+
+	if len(example.ExpectedSourceIDs) > 0 {
+		k := e.config.K
+
+		result.Metrics[MetricRecallAtK] = RecallAtK(result.RankedSourceIDs, example.ExpectedSourceIDs, k)
+		result.Metrics[MetricMRR] = ReciprocalRank(result.RankedSourceIDs, example.ExpectedSourceIDs)
+		result.Metrics[MetricNDCGAtK] = NDCGAtK(result.RankedSourceIDs, example.ExpectedSourceIDs, k)
+		result.Metrics[MetricRetrievalRecallAtK] = RecallAtK(result.RetrievedSourceIDs, example.ExpectedSourceIDs, k)
+		result.Metrics[MetricRetrievalMRR] = ReciprocalRank(result.RetrievedSourceIDs, example.ExpectedSourceIDs)
+		result.Metrics[MetricRetrievalNDCGAtK] = NDCGAtK(result.RetrievedSourceIDs, example.ExpectedSourceIDs, k)
+		result.Metrics[MetricRerankLiftMRR] = result.Metrics[MetricMRR] - result.Metrics[MetricRetrievalMRR]
+		result.Metrics[MetricRerankLiftNDCGAtK] = result.Metrics[MetricNDCGAtK] - result.Metrics[MetricRetrievalNDCGAtK]
+	}
+
+	if example.ReferenceAnswer != "" {
+		result.Metrics[MetricAnswerF1] = AnswerF1(queryResult.Content, example.ReferenceAnswer)
+	}
+
+	return result
+}
+
+// sourceIDs returns the source ids of the retrieved texts in retrieval order
+// and in final order: the reranked texts first, then the rest in retrieval order.
+func (e *Evaluator) sourceIDs(queryTrace *domain.QueryTrace) (retrieved []string, ranked []string) {
+	if queryTrace.Retrieval == nil {
+		return nil, nil
+	}
+
+	for _, r := range queryTrace.Retrieval.Results {
+		retrieved = append(retrieved, e.sourceID(r.Metadata))
+	}
+
+	if queryTrace.Rerank == nil {
+		return retrieved, slices.Clone(retrieved)
+	}
+
+	rerankedIndexes := map[int]bool{}
+	for _, r := range queryTrace.Rerank.Results {
+		if r.Index < 0 || r.Index >= len(retrieved) || rerankedIndexes[r.Index] {
+			continue
+		}
+		rerankedIndexes[r.Index] = true
+		ranked = append(ranked, retrieved[r.Index])
+	}
+	for i, id := range retrieved {
+		if !rerankedIndexes[i] {
+			ranked = append(ranked, id)
+		}
+	}
+
+	return retrieved, ranked
+}
+
+func (e *Evaluator) sourceID(metadata map[string]any) string {
+	value, ok := metadata[e.config.SourceIDField]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,247 @@
+package eval_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/eval"
+	internal_config "github.com/aria3ppp/rag-server/internal/pkg/config"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/uuid"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+type fakeVectorStore struct {
+	results map[string][]*domain.VectorStoreSearchResult
+}
+
+func (f *fakeVectorStore) Search(ctx context.Context, query *domain.VectorStoreSearchInput) ([]*domain.VectorStoreSearchResult, error) {
+	results, ok := f.results[query.Text]
+	if !ok {
+		return nil, errors.New("vectorstore unavailable")
+	}
+	return results, nil
+}
+
+func (f *fakeVectorStore) Lookup(ctx context.Context, input *domain.VectorStoreLookupInput) ([]*domain.VectorStoreLookupResult, error) {
+	return nil, nil
+}
+
+// fakeReranker scores the documents by their position in ranking and returns the top n by score.
+type fakeReranker struct {
+	ranking []string
+}
+
+func (f *fakeReranker) Rerank(ctx context.Context, input *domain.RerankerRerankInput) ([]*domain.RerankerRerankResult, error) {
+	var results []*domain.RerankerRerankResult
+	for index, document := range input.Documents {
+		for rank, ranked := range f.ranking {
+			if ranked == document {
+				results = append(results, &domain.RerankerRerankResult{
+					Index:    index,
+					Document: document,
+					Score:    float32(len(f.ranking) - rank),
+				})
+			}
+		}
+	}
+	slices.SortFunc(results, func(a, b *domain.RerankerRerankResult) int { return int(b.Score - a.Score) })
+	return results[:min(len(results), input.TopN)], nil
+}
+
+// fakeLLM answers with the scripted completion of the last user message.
+type fakeLLM struct {
+	completions map[string]string
+}
+
+func (f *fakeLLM) StreamCompletion(ctx context.Context, chat []*domain.Message, completionHandler func(completionChunk string, err error) (continueRunning bool)) {
+	for _, chunk := range strings.SplitAfter(f.completions[chat[len(chat)-1].Content], " ") {
+		if !completionHandler(chunk, nil) {
+			return
+		}
+	}
+}
+
+type fakeClock struct{}
+
+func (fakeClock) TimeNow() time.Time { return time.UnixMilli(0) }
+
+func newUseCase(t *testing.T, feedbackStore usecase.FeedbackStore) usecase.UseCase {
+	t.Helper()
+
+	text := func(id string) *domain.VectorStoreSearchResult {
+		return &domain.VectorStoreSearchResult{Text: "text " + id, Score: 0.5, Metadata: map[string]any{"doc": id}}
+	}
+
+	return usecase.NewUseCase(
+		&fakeVectorStore{
+			results: map[string][]*domain.VectorStoreSearchResult{
+				"q1": {text("a"), text("b"), text("c")},
+				"q2": {text("x")},
+			},
+		},
+		&fakeReranker{ranking: []string{"text b", "text a", "text c"}},
+		&fakeLLM{
+			completions: map[string]string{
+				"q1": "the answer is b",
+				"q2": "no idea",
+			},
+		},
+		fakeClock{},
+		uuid.NewIDGenerator(),
+		feedbackStore,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		internal_config.NewReloadable(&config.Config{
+			RetrievalConfig: config.RetrievalConfig{TopK: 3, RerankTopN: 1},
+		}),
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+func TestEvaluator_Run(t *testing.T) {
+	t.Parallel()
+
+	dataset := `{"id": "1", "question": "q1", "expected_source_ids": ["b"], "reference_answer": "answer b"}
+
+{"id": "2", "question": "q2", "expected_source_ids": ["y"]}
+{"id": "3", "question": "q3", "reference_answer": "anything"}
+`
+
+	examples, err := eval.ReadDataset(strings.NewReader(dataset))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	evaluator := eval.NewEvaluator(newUseCase(t, nil), &eval.Config{K: 2, SourceIDField: "doc"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	report := evaluator.Run(context.Background(), examples)
+
+	wantResults := []*eval.ExampleResult{
+		{
+			ID:                 "1",
+			Question:           "q1",
+			ExpectedSourceIDs:  []string{"b"},
+			RetrievedSourceIDs: []string{"a", "b", "c"},
+			RankedSourceIDs:    []string{"b", "a", "c"},
+			Answer:             "the answer is b",
+			Metrics: map[string]float64{
+				eval.MetricRecallAtK:          1,
+				eval.MetricMRR:                1,
+				eval.MetricNDCGAtK:            1,
+				eval.MetricRetrievalRecallAtK: 1,
+				eval.MetricRetrievalMRR:       0.5,
+				eval.MetricRetrievalNDCGAtK:   0.6309297535714575,
+				eval.MetricRerankLiftMRR:      0.5,
+				eval.MetricRerankLiftNDCGAtK:  0.36907024642854247,
+				eval.MetricAnswerF1:           2 * 0.5 * 1 / 1.5,
+			},
+		},
+		{
+			ID:                 "2",
+			Question:           "q2",
+			ExpectedSourceIDs:  []string{"y"},
+			RetrievedSourceIDs: []string{"x"},
+			RankedSourceIDs:    []string{"x"},
+			Answer:             "no idea",
+			Metrics: map[string]float64{
+				eval.MetricRecallAtK:          0,
+				eval.MetricMRR:                0,
+				eval.MetricNDCGAtK:            0,
+				eval.MetricRetrievalRecallAtK: 0,
+				eval.MetricRetrievalMRR:       0,
+				eval.MetricRetrievalNDCGAtK:   0,
+				eval.MetricRerankLiftMRR:      0,
+				eval.MetricRerankLiftNDCGAtK:  0,
+			},
+		},
+		{
+			ID:       "3",
+			Question: "q3",
+			Metrics:  map[string]float64{},
+			Error:    "vectorstore unavailable",
+		},
+	}
+
+	if diff := cmp.Diff(wantResults, report.Results, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if diff := cmp.Diff(3, report.Examples); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(1, report.Failed); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(0.5, report.Metrics[eval.MetricMRR]); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(1, report.Counts[eval.MetricAnswerF1]); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// compare with a baseline through the JSON report
+	baselinePath := t.TempDir() + "/baseline.json"
+	baseline := *report
+	baseline.Metrics = map[string]float64{
+		eval.MetricMRR:      0.75,
+		eval.MetricAnswerF1: 0.5,
+	}
+	writeReport(t, baselinePath, &baseline)
+
+	readBaseline, err := eval.ReadReportFile(baselinePath)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	report.Compare(readBaseline, 0.01)
+
+	wantComparison := []*eval.MetricComparison{
+		{Metric: eval.MetricAnswerF1, Baseline: 0.5, Current: 2.0 / 3, Delta: 2.0/3 - 0.5, Regressed: false},
+		{Metric: eval.MetricMRR, Baseline: 0.75, Current: 0.5, Delta: -0.25, Regressed: true},
+	}
+	if diff := cmp.Diff(wantComparison, report.Comparison, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Fatal(diff)
+	}
+	if !report.Regressed() {
+		t.Fatal("expected regression")
+	}
+
+	var markdown bytes.Buffer
+	if err := report.WriteMarkdown(&markdown); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if !strings.Contains(markdown.String(), "| mrr | 2 | 0.5000 | 0.7500 | -0.2500 (regressed) |") {
+		t.Fatalf("unexpected markdown report:\n%s", markdown.String())
+	}
+}
+
+func writeReport(t *testing.T, path string, report *eval.Report) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	if err := report.WriteJSON(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, buffer.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,35 @@
+package eval
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+)
+
+// ExampleFromFeedback turns a well rated answer into an example: the answer
+// is the reference answer and the sources neither flagged by the user nor
+// quarantined are the expected sources. It returns nil when the rating is
+// below minRating.
+func ExampleFromFeedback(record *domain.FeedbackRecord, sourceIDField string, minRating int) *Example {
+	if record.Feedback.Rating < minRating {
+		return nil
+	}
+
+	example := &Example{
+		ID:              record.Response.ID,
+		Question:        record.Response.Query,
+		ReferenceAnswer: record.Response.Answer,
+	}
+
+	for i, source := range record.Response.Sources {
+		if source.Quarantined || slices.Contains(record.Feedback.FlaggedSources, i) {
+			continue
+		}
+		if value, ok := source.Metadata[sourceIDField]; ok && value != nil {
+			example.ExpectedSourceIDs = append(example.ExpectedSourceIDs, fmt.Sprint(value))
+		}
+	}
+
+	return example
+}
@@ -0,0 +1,81 @@
+package eval_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/eval"
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/bbolt"
+
+	"github.com/google/go-cmp/cmp"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestExampleFromFeedback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	store, err := bbolt.NewFeedbackStore(
+		ctx,
+		&config.Config{FeedbackConfig: config.FeedbackConfig{StorePath: filepath.Join(t.TempDir(), "feedback.db")}},
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer store.Close()
+
+	uc := newUseCase(t, store)
+
+	result, err := uc.Query(ctx, &domain.QueryInput{Query: "q1"})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	wantSources := []*domain.Source{{Text: "text b", Score: 3, Metadata: map[string]any{"doc": "b"}}}
+	if diff := cmp.Diff(wantSources, result.Sources); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// the only source is at index 0
+	err = uc.SubmitFeedback(ctx, &domain.SubmitFeedbackInput{ResponseID: result.ResponseID, Rating: 5, FlaggedSources: []int{1}})
+	var validationError *internal_error.ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	err = uc.SubmitFeedback(ctx, &domain.SubmitFeedbackInput{ResponseID: "00000000-0000-0000-0000-000000000000", Rating: 5})
+	var notFoundError *internal_error.NotFoundError
+	if !errors.As(err, &notFoundError) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	if err := uc.SubmitFeedback(ctx, &domain.SubmitFeedbackInput{ResponseID: result.ResponseID, Rating: 5, Comment: "great"}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	var examples []*eval.Example
+	if err := store.ForEachFeedback(ctx, func(record *domain.FeedbackRecord) bool {
+		examples = append(examples, eval.ExampleFromFeedback(record, "doc", 4), eval.ExampleFromFeedback(record, "doc", 6))
+		return true
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	want := []*eval.Example{
+		{ID: result.ResponseID, Question: "q1", ExpectedSourceIDs: []string{"b"}, ReferenceAnswer: "the answer is b"},
+		nil,
+	}
+	if diff := cmp.Diff(want, examples); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,108 @@
+package eval
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+// RecallAtK is the fraction of the expected ids found in the first k ranked ids.
+func RecallAtK(ranked []string, expected []string, k int) float64 {
+	if len(expected) == 0 {
+		return 0
+	}
+
+	top := topK(ranked, k)
+
+	found := 0
+	for _, id := range lo.Uniq(expected) {
+		if lo.Contains(top, id) {
+			found++
+		}
+	}
+
+	return float64(found) / float64(len(lo.Uniq(expected)))
+}
+
+// ReciprocalRank is 1/rank of the first expected id in ranked, or 0 if none is found.
+func ReciprocalRank(ranked []string, expected []string) float64 {
+	for i, id := range ranked {
+		if lo.Contains(expected, id) {
+			return 1 / float64(i+1)
+		}
+	}
+
+	return 0
+}
+
+// NDCGAtK is the normalized discounted cumulative gain of the first k ranked
+// ids with binary relevance. Repeated ids only count once.
+func NDCGAtK(ranked []string, expected []string, k int) float64 {
+	expected = lo.Uniq(expected)
+	if len(expected) == 0 {
+		return 0
+	}
+
+	var (
+		dcg  float64
+		seen = map[string]bool{}
+	)
+	for i, id := range topK(ranked, k) {
+		if lo.Contains(expected, id) && !seen[id] {
+			dcg += 1 / math.Log2(float64(i+2))
+		}
+		seen[id] = true
+	}
+
+	var idcg float64
+	for i := 0; i < min(len(expected), k); i++ {
+		idcg += 1 / math.Log2(float64(i+2))
+	}
+
+	return dcg / idcg
+}
+
+// AnswerF1 is the token level F1 score between answer and reference after
+// lowercasing and dropping punctuation.
+func AnswerF1(answer string, reference string) float64 {
+	answerTokens, referenceTokens := tokenize(answer), tokenize(reference)
+	if len(answerTokens) == 0 || len(referenceTokens) == 0 {
+		if len(answerTokens) == len(referenceTokens) {
+			return 1
+		}
+		return 0
+	}
+
+	referenceCounts := map[string]int{}
+	for _, token := range referenceTokens {
+		referenceCounts[token]++
+	}
+
+	common := 0
+	for _, token := range answerTokens {
+		if referenceCounts[token] > 0 {
+			referenceCounts[token]--
+			common++
+		}
+	}
+	if common == 0 {
+		return 0
+	}
+
+	precision := float64(common) / float64(len(answerTokens))
+	recall := float64(common) / float64(len(referenceTokens))
+
+	return 2 * precision * recall / (precision + recall)
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func topK(ranked []string, k int) []string {
+	return ranked[:min(len(ranked), k)]
+}
@@ -0,0 +1,116 @@
+package eval_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/eval"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		ranked   []string
+		expected []string
+		k        int
+	}
+
+	type want struct {
+		recall float64
+		rr     float64
+		ndcg   float64
+	}
+
+	type testCase struct {
+		name  string
+		input input
+		want  want
+	}
+
+	testCases := []testCase{
+		{
+			name:  "first_hit",
+			input: input{ranked: []string{"a", "b", "c"}, expected: []string{"a"}, k: 3},
+			want:  want{recall: 1, rr: 1, ndcg: 1},
+		},
+		{
+			name:  "second_hit",
+			input: input{ranked: []string{"b", "a", "c"}, expected: []string{"a"}, k: 3},
+			want:  want{recall: 1, rr: 0.5, ndcg: 1 / math.Log2(3)},
+		},
+		{
+			name:  "hit_after_k",
+			input: input{ranked: []string{"b", "c", "a"}, expected: []string{"a"}, k: 2},
+			want:  want{recall: 0, rr: 1.0 / 3, ndcg: 0},
+		},
+		{
+			name:  "partial_recall",
+			input: input{ranked: []string{"a", "x", "y"}, expected: []string{"a", "b"}, k: 3},
+			want:  want{recall: 0.5, rr: 1, ndcg: 1 / (1 + 1/math.Log2(3))},
+		},
+		{
+			name:  "duplicate_ranked_ids",
+			input: input{ranked: []string{"a", "a", "b"}, expected: []string{"a", "b"}, k: 3},
+			want:  want{recall: 1, rr: 1, ndcg: (1 + 1/math.Log2(4)) / (1 + 1/math.Log2(3))},
+		},
+		{
+			name:  "no_hit",
+			input: input{ranked: []string{"x", "y"}, expected: []string{"a"}, k: 2},
+			want:  want{recall: 0, rr: 0, ndcg: 0},
+		},
+		{
+			name:  "no_expected",
+			input: input{ranked: []string{"x"}, expected: nil, k: 1},
+			want:  want{recall: 0, rr: 0, ndcg: 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := want{
+				recall: eval.RecallAtK(tc.input.ranked, tc.input.expected, tc.input.k),
+				rr:     eval.ReciprocalRank(tc.input.ranked, tc.input.expected),
+				ndcg:   eval.NDCGAtK(tc.input.ranked, tc.input.expected, tc.input.k),
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestAnswerF1(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name      string
+		answer    string
+		reference string
+		want      float64
+	}
+
+	testCases := []testCase{
+		{name: "exact", answer: "Paris is the capital.", reference: "paris is the capital", want: 1},
+		{name: "partial", answer: "the capital is Paris", reference: "Paris", want: 2 * 0.25 * 1 / 1.25},
+		{name: "disjoint", answer: "London", reference: "Paris", want: 0},
+		{name: "empty_answer", answer: "", reference: "Paris", want: 0},
+		{name: "both_empty", answer: "", reference: "", want: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.want, eval.AnswerF1(tc.answer, tc.reference), cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
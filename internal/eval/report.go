@@ -0,0 +1,160 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	goccy_json "github.com/goccy/go-json"
+	"github.com/samber/lo"
+)
+
+type Report struct {
+	K        int                `json:"k"`
+	Examples int                `json:"examples"`
+	Failed   int                `json:"failed"`
+	Metrics  map[string]float64 `json:"metrics"`
+	// Counts is the number of examples each metric is averaged over.
+	Counts     map[string]int      `json:"counts"`
+	Comparison []*MetricComparison `json:"comparison,omitempty"`
+	Results    []*ExampleResult    `json:"results"`
+}
+
+type MetricComparison struct {
+	Metric    string  `json:"metric"`
+	Baseline  float64 `json:"baseline"`
+	Current   float64 `json:"current"`
+	Delta     float64 `json:"delta"`
+	Regressed bool    `json:"regressed"`
+}
+
+func newReport(k int, results []*ExampleResult) *Report {
+	report := &Report{
+		K:        k,
+		Examples: len(results),
+		Metrics:  map[string]float64{},
+		Counts:   map[string]int{},
+		Results:  results,
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			report.Failed++
+		}
+		for metric, value := range result.Metrics {
+			report.Metrics[metric] += value
+			report.Counts[metric]++
+		}
+	}
+
+	for metric, count := range report.Counts {
+		report.Metrics[metric] /= float64(count)
+	}
+
+	return report
+}
+
+// Compare fills the comparison of the report metrics with the baseline ones.
+// A metric regresses when it drops more than tolerance below the baseline.
+func (r *Report) Compare(baseline *Report, tolerance float64) {
+	r.Comparison = nil
+
+	for _, metric := range sortedMetrics(r.Metrics) {
+		baselineValue, ok := baseline.Metrics[metric]
+		if !ok {
+			continue
+		}
+
+		delta := r.Metrics[metric] - baselineValue
+		r.Comparison = append(r.Comparison, &MetricComparison{
+			Metric:    metric,
+			Baseline:  baselineValue,
+			Current:   r.Metrics[metric],
+			Delta:     delta,
+			Regressed: delta < -tolerance,
+		})
+	}
+}
+
+func (r *Report) Regressed() bool {
+	return lo.SomeBy(r.Comparison, func(c *MetricComparison) bool { return c.Regressed })
+}
+
+func ReadReportFile(path string) (*Report, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var report Report
+	if err := goccy_json.Unmarshal(content, &report); err != nil {
+		return nil, fmt.Errorf("failed to decode report: %w", err)
+	}
+
+	return &report, nil
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	content, err := goccy_json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", content)
+	return err
+}
+
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Evaluation report\n\n")
+	fmt.Fprintf(&b, "- examples: %d\n- failed: %d\n- k: %d\n\n", r.Examples, r.Failed, r.K)
+
+	fmt.Fprintf(&b, "## Metrics\n\n")
+	if len(r.Comparison) > 0 {
+		fmt.Fprintf(&b, "| metric | examples | value | baseline | delta |\n|---|---|---|---|---|\n")
+		comparisons := lo.SliceToMap(r.Comparison, func(c *MetricComparison) (string, *MetricComparison) { return c.Metric, c })
+		for _, metric := range sortedMetrics(r.Metrics) {
+			comparison, ok := comparisons[metric]
+			if !ok {
+				fmt.Fprintf(&b, "| %s | %d | %.4f | | |\n", metric, r.Counts[metric], r.Metrics[metric])
+				continue
+			}
+			fmt.Fprintf(&b, "| %s | %d | %.4f | %.4f | %+.4f%s |\n", metric, r.Counts[metric], comparison.Current, comparison.Baseline, comparison.Delta, lo.Ternary(comparison.Regressed, " (regressed)", ""))
+		}
+	} else {
+		fmt.Fprintf(&b, "| metric | examples | value |\n|---|---|---|\n")
+		for _, metric := range sortedMetrics(r.Metrics) {
+			fmt.Fprintf(&b, "| %s | %d | %.4f |\n", metric, r.Counts[metric], r.Metrics[metric])
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Examples\n\n| id | %s | error |\n|---|%s---|\n",
+		strings.Join(exampleMetrics, " | "),
+		strings.Repeat("---|", len(exampleMetrics)),
+	)
+	for _, result := range r.Results {
+		values := lo.Map(exampleMetrics, func(metric string, _ int) string {
+			value, ok := result.Metrics[metric]
+			return lo.Ternary(ok, fmt.Sprintf("%.4f", value), "")
+		})
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", escapeMarkdownCell(result.ID), strings.Join(values, " | "), escapeMarkdownCell(result.Error))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var exampleMetrics = []string{MetricRecallAtK, MetricMRR, MetricNDCGAtK, MetricRerankLiftMRR, MetricAnswerF1}
+
+func sortedMetrics(metrics map[string]float64) []string {
+	keys := lo.Keys(metrics)
+	slices.Sort(keys)
+	return keys
+}
+
+func escapeMarkdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type Scope string
+
+const ScopeAdmin Scope = "admin"
+
+// AnonymousCallerID is the id of the callers without a known api key.
+const AnonymousCallerID = "anonymous"
+
+type Caller struct {
+	// ID identifies the api key of the caller without revealing it.
+	ID string
+	// Address is the client address, as forwarded by the trusted proxies, e.g. the gateway, when called through them.
+	Address string
+	// Tenant selects the tenant policies, e.g. the personal data redaction, of
+	// the caller. It is the tenant of its api key, empty for the anonymous callers.
+	Tenant string
+	Scopes []Scope
+}
+
+type callerContextKey struct{}
+
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	caller, ok := ctx.Value(callerContextKey{}).(*Caller)
+	return caller, ok
+}
+
+func HasScope(ctx context.Context, scope Scope) bool {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, callerScope := range caller.Scopes {
+		if callerScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// APIKeysFunc returns the current admin api keys.
+type APIKeysFunc func() (adminAPIKeys []string)
+
+// TenantsFunc returns the current tenants by their api keys.
+type TenantsFunc func() (apiKeyTenants map[string]string)
+
+// TrustedProxiesFunc returns the current CIDRs of the proxies whose
+// x-forwarded-for metadata is trusted.
+type TrustedProxiesFunc func() (trustedProxies []string)
+
+// Authenticator resolves the caller of a request from its
+// `authorization: Bearer <api key>` metadata: the admin api keys grant the
+// admin scope and the tenant api keys select the caller tenant. Requests
+// without a known key are served as anonymous callers without any scope nor
+// tenant.
+type Authenticator struct {
+	adminAPIKeysFn   APIKeysFunc
+	tenantsFn        TenantsFunc
+	trustedProxiesFn TrustedProxiesFunc
+}
+
+func NewAuthenticator(adminAPIKeysFn APIKeysFunc, tenantsFn TenantsFunc, trustedProxiesFn TrustedProxiesFunc) *Authenticator {
+	return &Authenticator{
+		adminAPIKeysFn:   adminAPIKeysFn,
+		tenantsFn:        tenantsFn,
+		trustedProxiesFn: trustedProxiesFn,
+	}
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context) context.Context {
+	caller := &Caller{
+		ID:      AnonymousCallerID,
+		Address: clientAddress(ctx, parsePrefixes(a.trustedProxiesFn())),
+	}
+
+	if apiKey, ok := bearerToken(ctx); ok {
+		for _, adminAPIKey := range a.adminAPIKeysFn() {
+			if isAPIKey(apiKey, adminAPIKey) {
+				caller.ID = apiKeyID(apiKey)
+				caller.Scopes = append(caller.Scopes, ScopeAdmin)
+				break
+			}
+		}
+
+		for tenantAPIKey, tenant := range a.tenantsFn() {
+			if isAPIKey(apiKey, tenantAPIKey) {
+				caller.ID = apiKeyID(apiKey)
+				caller.Tenant = tenant
+				break
+			}
+		}
+	}
+
+	return WithCaller(ctx, caller)
+}
+
+// isAPIKey compares the keys in constant time, never matching an empty key.
+func isAPIKey(apiKey, knownAPIKey string) bool {
+	return knownAPIKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(knownAPIKey)) == 1
+}
+
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(a.Authenticate(ctx), req)
+	}
+}
+
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: stream, ctx: a.Authenticate(stream.Context())})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// apiKeyID is a short fingerprint of the api key, safe to log.
+func apiKeyID(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return "key-" + hex.EncodeToString(sum[:4])
+}
+
+// clientAddress is the peer address or, when the peer is a trusted proxy,
+// the last x-forwarded-for address not of a trusted proxy. The addresses
+// are appended by every proxy, so the ones before it could be forged by the
+// client.
+func clientAddress(ctx context.Context, trustedProxies []netip.Prefix) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	address := p.Addr.String()
+
+	if !isTrusted(address, trustedProxies) {
+		return address
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return address
+	}
+
+	var forwarded []string
+	for _, value := range md.Get("x-forwarded-for") {
+		forwarded = append(forwarded, strings.Split(value, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		forwardedAddress := strings.TrimSpace(forwarded[i])
+		if forwardedAddress == "" {
+			continue
+		}
+		address = forwardedAddress
+		if !isTrusted(forwardedAddress, trustedProxies) {
+			break
+		}
+	}
+
+	return address
+}
+
+// isTrusted reports whether the address, with or without its port, is in one of the trusted proxies.
+func isTrusted(address string, trustedProxies []netip.Prefix) bool {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	ip, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parsePrefixes parses the CIDRs, skipping the invalid ones the config validation rejects.
+func parsePrefixes(cidrs []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr)); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(value, " ")
+		if found && strings.EqualFold(scheme, "bearer") && token != "" {
+			return strings.TrimSpace(token), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,150 @@
+package auth_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/auth"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		ctx context.Context
+	}
+
+	type want struct {
+		admin   bool
+		id      string
+		address string
+		tenant  string
+	}
+
+	type testCase struct {
+		name  string
+		input input
+		want  want
+	}
+
+	withAuthorization := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+	}
+
+	const (
+		adminKeyID = "key-69a52655"
+		acmeKeyID  = "key-afacab35"
+	)
+
+	testCases := []testCase{
+		{
+			name:  "no_metadata",
+			input: input{ctx: context.Background()},
+			want:  want{admin: false, id: auth.AnonymousCallerID},
+		},
+		{
+			name: "forwarded_address_of_trusted_proxies",
+			input: input{ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}}),
+				metadata.Pairs("x-forwarded-for", "10.0.0.1, 10.0.0.2"),
+			)},
+			want: want{admin: false, id: auth.AnonymousCallerID, address: "10.0.0.1"},
+		},
+		{
+			name: "forged_forwarded_address",
+			input: input{ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}}),
+				metadata.Pairs("x-forwarded-for", "10.0.0.1, 192.168.0.1"),
+			)},
+			want: want{admin: false, id: auth.AnonymousCallerID, address: "192.168.0.1"},
+		},
+		{
+			name: "forwarded_address_of_untrusted_peer",
+			input: input{ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(192, 168, 0, 1), Port: 1234}}),
+				metadata.Pairs("x-forwarded-for", "10.0.0.1"),
+			)},
+			want: want{admin: false, id: auth.AnonymousCallerID, address: "192.168.0.1:1234"},
+		},
+		{
+			name:  "peer_address",
+			input: input{ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}})},
+			want:  want{admin: false, id: auth.AnonymousCallerID, address: "127.0.0.1:1234"},
+		},
+		{
+			name:  "tenant_key",
+			input: input{ctx: withAuthorization("Bearer acme-key")},
+			want:  want{admin: false, id: acmeKeyID, tenant: "acme"},
+		},
+		{
+			name:  "tenant_header_ignored",
+			input: input{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant-id", "acme"))},
+			want:  want{admin: false, id: auth.AnonymousCallerID},
+		},
+		{
+			name:  "admin_key",
+			input: input{ctx: withAuthorization("Bearer admin-key")},
+			want:  want{admin: true, id: adminKeyID},
+		},
+		{
+			name:  "admin_key_lowercase_scheme",
+			input: input{ctx: withAuthorization("bearer admin-key")},
+			want:  want{admin: true, id: adminKeyID},
+		},
+		{
+			name:  "unknown_key",
+			input: input{ctx: withAuthorization("Bearer other-key")},
+			want:  want{admin: false, id: auth.AnonymousCallerID},
+		},
+		{
+			name:  "not_bearer",
+			input: input{ctx: withAuthorization("Basic admin-key")},
+			want:  want{admin: false, id: auth.AnonymousCallerID},
+		},
+		{
+			name:  "empty_token",
+			input: input{ctx: withAuthorization("Bearer ")},
+			want:  want{admin: false, id: auth.AnonymousCallerID},
+		},
+	}
+
+	authenticator := auth.NewAuthenticator(
+		func() []string {
+			return []string{"", "admin-key"}
+		},
+		func() map[string]string {
+			return map[string]string{"": "empty", "acme-key": "acme"}
+		},
+		func() []string {
+			return []string{"127.0.0.1/32", "10.0.0.2/32"}
+		},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := authenticator.Authenticate(tc.input.ctx)
+
+			caller, ok := auth.CallerFromContext(ctx)
+			if !ok {
+				t.Fatal("expected a caller in context")
+			}
+
+			got := want{
+				admin:   auth.HasScope(ctx, auth.ScopeAdmin),
+				id:      caller.ID,
+				address: caller.Address,
+				tenant:  caller.Tenant,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/caarlos0/env/v11"
+	validatorPkg "github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+)
+
+var validator = validatorPkg.New(validatorPkg.WithRequiredStructEnabled())
+
+// Load fills cfg from the config file at path (if any) and then from the
+// environment. Environment variables keep precedence over file values and
+// envDefault values are only used when neither of them is set.
+func Load(path string, cfg any) error {
+	fileFields, err := DecodeFile(path, cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := ApplyEnv(cfg, fileFields); err != nil {
+		return err
+	}
+
+	return Validate(cfg)
+}
+
+// FileFields are the paths of the struct fields set by a config file, their
+// Go field names joined by dots, e.g. "RetrievalConfig.MinScore".
+type FileFields map[string]bool
+
+// Sub returns the fields set in the struct field name, relative to it.
+func (fields FileFields) Sub(name string) FileFields {
+	sub := make(FileFields)
+	for path := range fields {
+		if rest, ok := strings.CutPrefix(path, name+"."); ok {
+			sub[rest] = true
+		}
+	}
+	return sub
+}
+
+// DecodeFile decodes the yaml or toml file at path into cfg and returns the
+// fields it set. An empty path is a no-op.
+func DecodeFile(path string, cfg any) (FileFields, error) {
+	fileFields := make(FileFields)
+	if path == "" {
+		return fileFields, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfgType := reflect.TypeOf(cfg)
+	if cfgType.Kind() != reflect.Pointer || cfgType.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config must be a pointer to struct: got %T", cfg)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var document yaml.Node
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, fmt.Errorf("failed to decode yaml config file %s: %w", path, err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to decode yaml config file %s: %w", path, err)
+		}
+
+		if len(document.Content) > 0 {
+			for _, key := range yamlKeys(document.Content[0], nil) {
+				addFileField(fileFields, cfgType.Elem(), "yaml", key)
+			}
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(content), cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode toml config file %s: %w", path, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, 0, len(undecoded))
+			for _, key := range undecoded {
+				keys = append(keys, key.String())
+			}
+			return nil, fmt.Errorf("unknown keys in toml config file %s: %s", path, strings.Join(keys, ", "))
+		}
+
+		for _, key := range metadata.Keys() {
+			addFileField(fileFields, cfgType.Elem(), "toml", key)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q: use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+
+	return fileFields, nil
+}
+
+// yamlKeys returns the key paths of the mappings of node.
+func yamlKeys(node *yaml.Node, prefix []string) [][]string {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var keys [][]string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := append(slices.Clone(prefix), node.Content[i].Value)
+		keys = append(keys, key)
+		keys = append(keys, yamlKeys(node.Content[i+1], key)...)
+	}
+	return keys
+}
+
+// addFileField adds the path of the struct field of structType set by the
+// file key, the fields named by their tag. The keys below a field that isn't
+// a struct, e.g. a map, set that field.
+func addFileField(fileFields FileFields, structType reflect.Type, tag string, key []string) {
+	var path []string
+	for _, name := range key {
+		if structType.Kind() != reflect.Struct {
+			break
+		}
+
+		field, ok := fieldByTag(structType, tag, name)
+		if !ok {
+			return
+		}
+		path = append(path, field.Name)
+		structType = field.Type
+	}
+	fileFields[strings.Join(path, ".")] = true
+}
+
+func fieldByTag(structType reflect.Type, tag string, name string) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tagName, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if tagName == "" {
+			tagName = strings.ToLower(field.Name)
+		}
+		if field.IsExported() && tagName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// ApplyEnv parses the environment into cfg. The fileFields set by a config
+// file are only overridden by explicitly set environment variables, not by
+// their envDefault, even when the file set them to their zero value.
+func ApplyEnv(cfg any, fileFields FileFields) error {
+	environment := env.ToMap(os.Environ())
+
+	value := reflect.ValueOf(cfg)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to struct: got %T", cfg)
+	}
+
+	fileValues := reflect.New(value.Elem().Type()).Elem()
+	fileValues.Set(value.Elem())
+
+	// the file values are passed as environment variables so the required
+	// and not empty variables set by the file are satisfied
+	fileEnvironment := maps.Clone(environment)
+	if err := fileValuesAsEnv(value.Elem(), "", fileFields, fileEnvironment); err != nil {
+		return err
+	}
+
+	if err := env.ParseWithOptions(cfg, env.Options{Environment: fileEnvironment}); err != nil {
+		return err
+	}
+
+	// envDefault replaces the empty file values, so they are set back
+	restoreFileValues(value.Elem(), fileValues, "", fileFields, environment)
+
+	return nil
+}
+
+// Validate checks the `validate` tags of cfg.
+func Validate(cfg any) error {
+	return validator.StructCtx(context.Background(), cfg)
+}
+
+// fieldPath joins the field name to the path of its struct.
+func fieldPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// nestedStruct reports whether the field is a struct of config fields.
+func nestedStruct(field reflect.StructField) bool {
+	return field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{})
+}
+
+func fileValuesAsEnv(value reflect.Value, prefix string, fileFields FileFields, environment map[string]string) error {
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		fieldValue := value.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		path := fieldPath(prefix, field.Name)
+
+		key, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if key == "" {
+			if nestedStruct(field) {
+				if err := fileValuesAsEnv(fieldValue, path, fileFields, environment); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if envValue, exists := environment[key]; (exists && envValue != "") || !fileFields[path] {
+			continue
+		}
+
+		separator := field.Tag.Get("envSeparator")
+		if separator == "" {
+			separator = ","
+		}
+		keyValSeparator := field.Tag.Get("envKeyValSeparator")
+		if keyValSeparator == "" {
+			keyValSeparator = ":"
+		}
+
+		formatted, err := formatEnvValue(fieldValue, separator, keyValSeparator)
+		if err != nil {
+			return fmt.Errorf("failed to format config field %s: %w", field.Name, err)
+		}
+
+		environment[key] = formatted
+	}
+
+	return nil
+}
+
+// restoreFileValues sets the fields set by the file and not by an
+// environment variable back to their file value.
+func restoreFileValues(value reflect.Value, fileValues reflect.Value, prefix string, fileFields FileFields, environment map[string]string) {
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := fieldPath(prefix, field.Name)
+
+		key, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if key == "" {
+			if nestedStruct(field) {
+				restoreFileValues(value.Field(i), fileValues.Field(i), path, fileFields, environment)
+			}
+			continue
+		}
+
+		if envValue, exists := environment[key]; (exists && envValue != "") || !fileFields[path] {
+			continue
+		}
+		value.Field(i).Set(fileValues.Field(i))
+	}
+}
+
+// formatEnvValue formats the value the way env parses it: the slice items
+// joined by the separator and the map entries as key and value joined by the
+// keyValSeparator, sorted by key.
+func formatEnvValue(value reflect.Value, separator string, keyValSeparator string) (string, error) {
+	if duration, ok := value.Interface().(time.Duration); ok {
+		return duration.String(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), nil
+	case reflect.Slice:
+		items := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, err := formatEnvValue(value.Index(i), separator, keyValSeparator)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return strings.Join(items, separator), nil
+	case reflect.Map:
+		entries := make([]string, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key, err := formatEnvValue(iter.Key(), separator, keyValSeparator)
+			if err != nil {
+				return "", err
+			}
+			item, err := formatEnvValue(iter.Value(), separator, keyValSeparator)
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, key+keyValSeparator+item)
+		}
+		slices.Sort(entries)
+		return strings.Join(entries, separator), nil
+	default:
+		return "", fmt.Errorf("unsupported kind %s", value.Kind())
+	}
+}
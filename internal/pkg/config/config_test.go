@@ -0,0 +1,505 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	internal_config "github.com/aria3ppp/rag-server/internal/pkg/config"
+	rag_config "github.com/aria3ppp/rag-server/internal/rag/config"
+	vectorstore_config "github.com/aria3ppp/rag-server/internal/vectorstore/config"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testConfig struct {
+	Server  testServerConfig  `yaml:"server" toml:"server"`
+	Limits  testLimitsConfig  `yaml:"limits" toml:"limits" reload:"true"`
+	Backend testBackendConfig `yaml:"backend" toml:"backend"`
+}
+
+type testServerConfig struct {
+	Port           uint16        `env:"TEST_CONFIG_SERVER_PORT" envDefault:"9001" yaml:"port" toml:"port"`
+	Timeout        time.Duration `env:"TEST_CONFIG_SERVER_TIMEOUT" envDefault:"30s" yaml:"timeout" toml:"timeout"`
+	AllowedOrigins []string      `env:"TEST_CONFIG_SERVER_ALLOWED_ORIGINS" yaml:"allowed_origins" toml:"allowed_origins"`
+}
+
+type testLimitsConfig struct {
+	TopK     int                `env:"TEST_CONFIG_LIMITS_TOP_K" envDefault:"5" yaml:"top_k" toml:"top_k" validate:"min=1"`
+	MinScore float32            `env:"TEST_CONFIG_LIMITS_MIN_SCORE" envDefault:"0.4" yaml:"min_score" toml:"min_score"`
+	Weights  map[string]float32 `env:"TEST_CONFIG_LIMITS_WEIGHTS" yaml:"weights" toml:"weights"`
+}
+
+type testBackendConfig struct {
+	BaseURL string `env:"TEST_CONFIG_BACKEND_BASEURL,notEmpty" yaml:"base_url" toml:"base_url"`
+	APIKey  string `env:"TEST_CONFIG_BACKEND_APIKEY" yaml:"api_key" toml:"api_key" secret:"true"`
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// not parallel: the test cases set environment variables
+func Test_Load(t *testing.T) {
+	type input struct {
+		fileName    string
+		fileContent string
+		env         map[string]string
+	}
+
+	type want struct {
+		config *testConfig
+		err    bool
+	}
+
+	type testCase struct {
+		name  string
+		input input
+		want  want
+	}
+
+	testCases := []testCase{
+		{
+			name: "env_only_uses_defaults",
+			input: input{
+				env: map[string]string{
+					"TEST_CONFIG_BACKEND_BASEURL": "http://backend",
+				},
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9001, Timeout: 30 * time.Second},
+					Limits:  testLimitsConfig{TopK: 5, MinScore: 0.4},
+					Backend: testBackendConfig{BaseURL: "http://backend"},
+				},
+			},
+		},
+		{
+			name: "yaml_file_overrides_defaults",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+server:
+  port: 9100
+  allowed_origins: [a, b]
+limits:
+  top_k: 10
+backend:
+  base_url: http://file
+  api_key: secret
+`,
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9100, Timeout: 30 * time.Second, AllowedOrigins: []string{"a", "b"}},
+					Limits:  testLimitsConfig{TopK: 10, MinScore: 0.4},
+					Backend: testBackendConfig{BaseURL: "http://file", APIKey: "secret"},
+				},
+			},
+		},
+		{
+			name: "yaml_file_map",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+limits:
+  weights: {b: 2, a: 0.5}
+backend:
+  base_url: http://file
+`,
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9001, Timeout: 30 * time.Second},
+					Limits:  testLimitsConfig{TopK: 5, MinScore: 0.4, Weights: map[string]float32{"a": 0.5, "b": 2}},
+					Backend: testBackendConfig{BaseURL: "http://file"},
+				},
+			},
+		},
+		{
+			name: "yaml_file_empty_map",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+limits:
+  weights: {}
+backend:
+  base_url: http://file
+`,
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9001, Timeout: 30 * time.Second},
+					Limits:  testLimitsConfig{TopK: 5, MinScore: 0.4, Weights: map[string]float32{}},
+					Backend: testBackendConfig{BaseURL: "http://file"},
+				},
+			},
+		},
+		{
+			name: "toml_file_overrides_defaults",
+			input: input{
+				fileName: "config.toml",
+				fileContent: `
+[server]
+timeout = "10s"
+
+[limits]
+min_score = 0.7
+
+[backend]
+base_url = "http://file"
+`,
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9001, Timeout: 10 * time.Second},
+					Limits:  testLimitsConfig{TopK: 5, MinScore: 0.7},
+					Backend: testBackendConfig{BaseURL: "http://file"},
+				},
+			},
+		},
+		{
+			name: "yaml_file_zero_values_override_defaults",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+server:
+  timeout: 0s
+limits:
+  min_score: 0
+backend:
+  base_url: http://file
+`,
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9001},
+					Limits:  testLimitsConfig{TopK: 5},
+					Backend: testBackendConfig{BaseURL: "http://file"},
+				},
+			},
+		},
+		{
+			name: "toml_file_zero_values_override_defaults",
+			input: input{
+				fileName: "config.toml",
+				fileContent: `
+[limits]
+min_score = 0.0
+
+[backend]
+base_url = "http://file"
+`,
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9001, Timeout: 30 * time.Second},
+					Limits:  testLimitsConfig{TopK: 5},
+					Backend: testBackendConfig{BaseURL: "http://file"},
+				},
+			},
+		},
+		{
+			name: "env_overrides_file_zero_value",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+limits:
+  min_score: 0
+backend:
+  base_url: http://file
+`,
+				env: map[string]string{
+					"TEST_CONFIG_LIMITS_MIN_SCORE": "0.6",
+				},
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9001, Timeout: 30 * time.Second},
+					Limits:  testLimitsConfig{TopK: 5, MinScore: 0.6},
+					Backend: testBackendConfig{BaseURL: "http://file"},
+				},
+			},
+		},
+		{
+			name: "env_overrides_file",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+server:
+  port: 9100
+limits:
+  top_k: 10
+backend:
+  base_url: http://file
+`,
+				env: map[string]string{
+					"TEST_CONFIG_SERVER_PORT":     "9200",
+					"TEST_CONFIG_BACKEND_BASEURL": "http://env",
+				},
+			},
+			want: want{
+				config: &testConfig{
+					Server:  testServerConfig{Port: 9200, Timeout: 30 * time.Second},
+					Limits:  testLimitsConfig{TopK: 10, MinScore: 0.4},
+					Backend: testBackendConfig{BaseURL: "http://env"},
+				},
+			},
+		},
+		{
+			name: "unknown_yaml_key",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+backend:
+  base_url: http://file
+  unknown: x
+`,
+			},
+			want: want{err: true},
+		},
+		{
+			name: "unknown_toml_key",
+			input: input{
+				fileName: "config.toml",
+				fileContent: `
+[backend]
+base_url = "http://file"
+unknown = "x"
+`,
+			},
+			want: want{err: true},
+		},
+		{
+			name: "unsupported_extension",
+			input: input{
+				fileName:    "config.json",
+				fileContent: `{}`,
+			},
+			want: want{err: true},
+		},
+		{
+			name: "missing_required",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+limits:
+  top_k: 3
+`,
+			},
+			want: want{err: true},
+		},
+		{
+			name: "validation_error",
+			input: input{
+				fileName: "config.yaml",
+				fileContent: `
+limits:
+  top_k: -1
+backend:
+  base_url: http://file
+`,
+			},
+			want: want{err: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.input.env {
+				t.Setenv(key, value)
+			}
+
+			var path string
+			if tc.input.fileName != "" {
+				path = writeFile(t, tc.input.fileName, tc.input.fileContent)
+			}
+
+			var config testConfig
+			err := internal_config.Load(path, &config)
+			if tc.want.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if diff := cmp.Diff(tc.want.config, &config); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+// not parallel: the environment must not override the example configs
+func Test_Load_Examples(t *testing.T) {
+	type testCase struct {
+		name   string
+		path   string
+		config any
+	}
+
+	testCases := []testCase{
+		{
+			name:   "rag",
+			path:   filepath.Join("..", "..", "..", "configs", "rag.example.yaml"),
+			config: &rag_config.Config{},
+		},
+		{
+			name:   "vectorstore",
+			path:   filepath.Join("..", "..", "..", "configs", "vectorstore.example.yaml"),
+			config: &vectorstore_config.Config{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := internal_config.Load(tc.path, tc.config); err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+		})
+	}
+}
+
+func Test_Load_ZeroCollectionWeight(t *testing.T) {
+	// a weight of 0 is rejected instead of read as the default weight of 1
+	t.Setenv("RAG_RETRIEVAL_COLLECTION_WEIGHTS", "tickets:0")
+
+	if err := internal_config.Load(filepath.Join("..", "..", "..", "configs", "rag.example.yaml"), &rag_config.Config{}); err == nil {
+		t.Fatal("want a validation error, got nil")
+	}
+}
+
+func Test_Print(t *testing.T) {
+	t.Parallel()
+
+	config := &testConfig{
+		Server:  testServerConfig{Port: 9001, Timeout: 30 * time.Second, AllowedOrigins: []string{"a"}},
+		Limits:  testLimitsConfig{TopK: 5, MinScore: 0.5},
+		Backend: testBackendConfig{BaseURL: "http://backend", APIKey: "secret"},
+	}
+
+	var buffer bytes.Buffer
+	if err := internal_config.Print(&buffer, config); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	want := `server:
+  port: 9001
+  timeout: 30s
+  allowed_origins: [a]
+limits:
+  top_k: 5
+  min_score: 0.5
+  weights: {}
+backend:
+  base_url: http://backend
+  api_key: '[REDACTED]'
+`
+
+	if diff := cmp.Diff(want, buffer.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_MergeReloadable(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		current *testConfig
+		next    *testConfig
+	}
+
+	type want struct {
+		merged          *testConfig
+		changed         []string
+		restartRequired []string
+	}
+
+	type testCase struct {
+		name  string
+		input input
+		want  want
+	}
+
+	base := testConfig{
+		Server:  testServerConfig{Port: 9001},
+		Limits:  testLimitsConfig{TopK: 5, MinScore: 0.4},
+		Backend: testBackendConfig{BaseURL: "http://backend"},
+	}
+
+	testCases := []testCase{
+		{
+			name: "no_change",
+			input: input{
+				current: &base,
+				next:    &base,
+			},
+			want: want{
+				merged: &base,
+			},
+		},
+		{
+			name: "reloadable_change",
+			input: func() input {
+				next := base
+				next.Limits.TopK = 10
+				return input{current: &base, next: &next}
+			}(),
+			want: func() want {
+				merged := base
+				merged.Limits.TopK = 10
+				return want{merged: &merged, changed: []string{"limits"}}
+			}(),
+		},
+		{
+			name: "restart_required_change",
+			input: func() input {
+				next := base
+				next.Server.Port = 9100
+				next.Backend.BaseURL = "http://other"
+				next.Limits.MinScore = 0.8
+				return input{current: &base, next: &next}
+			}(),
+			want: func() want {
+				merged := base
+				merged.Limits.MinScore = 0.8
+				return want{
+					merged:          &merged,
+					changed:         []string{"limits"},
+					restartRequired: []string{"server.port", "backend.base_url"},
+				}
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, changed, restartRequired := internal_config.MergeReloadable(tc.input.current, tc.input.next)
+
+			if diff := cmp.Diff(tc.want.merged, merged); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(tc.want.restartRequired, restartRequired); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+type Flags struct {
+	Path        string
+	CheckConfig bool
+}
+
+// RegisterFlags registers the -config and -check-config flags on the default
+// flag set. The config path defaults to the value of the pathEnv variable.
+func RegisterFlags(pathEnv string) *Flags {
+	flags := &Flags{}
+
+	flag.StringVar(&flags.Path, "config", os.Getenv(pathEnv), fmt.Sprintf("path to a yaml or toml config file (env %s)", pathEnv))
+	flag.BoolVar(&flags.CheckConfig, "check-config", false, "validate the config, print the effective config with secrets redacted and exit")
+
+	return flags
+}
+
+// CheckToRunCheckConfig loads and validates a T when -check-config is set,
+// prints it and exits.
+func CheckToRunCheckConfig[T any](flags *Flags) {
+	if !flags.CheckConfig {
+		return
+	}
+
+	var cfg T
+	if err := Load(flags.Path, &cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid config: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := Print(os.Stdout, &cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print config: %s\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Print writes cfg to w as yaml using the `yaml` field names. Values of
+// fields tagged with `secret:"true"` are redacted.
+func Print(w io.Writer, cfg any) error {
+	node, err := toYAMLNode(reflect.ValueOf(cfg), false)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+
+	return encoder.Encode(node)
+}
+
+func toYAMLNode(value reflect.Value, secret bool) (*yaml.Node, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+		}
+		value = value.Elem()
+	}
+
+	if secret {
+		if value.IsZero() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ""}, nil
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: redacted}, nil
+	}
+
+	if duration, ok := value.Interface().(time.Duration); ok {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: duration.String()}, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		valueType := value.Type()
+		for i := 0; i < valueType.NumField(); i++ {
+			field := valueType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			fieldNode, err := toYAMLNode(value.Field(i), field.Tag.Get("secret") == "true")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, fieldNode)
+		}
+		return node, nil
+	case reflect.Slice, reflect.Array:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for i := 0; i < value.Len(); i++ {
+			itemNode, err := toYAMLNode(value.Index(i), false)
+			if err != nil {
+				return nil, err
+			}
+			if itemNode.Kind != yaml.ScalarNode {
+				node.Style = 0
+			}
+			node.Content = append(node.Content, itemNode)
+		}
+		return node, nil
+	case reflect.Map:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		iter := value.MapRange()
+		for iter.Next() {
+			itemNode, err := toYAMLNode(iter.Value(), false)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(iter.Key().Interface())}, itemNode)
+		}
+		return node, nil
+	case reflect.String:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value.String()}, nil
+	case reflect.Bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value.Bool())}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(value.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatUint(value.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits())}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", value.Kind())
+	}
+}
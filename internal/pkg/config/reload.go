@@ -0,0 +1,84 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// Reloadable holds a configuration that can be swapped while the servers are running.
+type Reloadable[T any] struct {
+	value atomic.Pointer[T]
+}
+
+func NewReloadable[T any](value *T) *Reloadable[T] {
+	reloadable := &Reloadable[T]{}
+	reloadable.value.Store(value)
+	return reloadable
+}
+
+func (r *Reloadable[T]) Load() *T {
+	return r.value.Load()
+}
+
+func (r *Reloadable[T]) Store(value *T) {
+	r.value.Store(value)
+}
+
+// MergeReloadable returns a copy of current where the fields tagged with
+// `reload:"true"` (and everything nested in them) are taken from next.
+// It reports the yaml paths of the reloaded fields that changed and of the
+// changed fields that need a restart to take effect.
+func MergeReloadable[T any](current *T, next *T) (merged *T, changed []string, restartRequired []string) {
+	merged = new(T)
+	*merged = *current
+
+	mergeReloadable(
+		reflect.ValueOf(merged).Elem(),
+		reflect.ValueOf(next).Elem(),
+		"",
+		&changed,
+		&restartRequired,
+	)
+
+	return merged, changed, restartRequired
+}
+
+func mergeReloadable(merged reflect.Value, next reflect.Value, prefix string, changed *[]string, restartRequired *[]string) {
+	valueType := merged.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		mergedField, nextField := merged.Field(i), next.Field(i)
+
+		if field.Tag.Get("reload") == "true" {
+			if !reflect.DeepEqual(mergedField.Interface(), nextField.Interface()) {
+				*changed = append(*changed, path)
+				mergedField.Set(nextField)
+			}
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Tag.Get("env") == "" {
+			mergeReloadable(mergedField, nextField, path, changed, restartRequired)
+			continue
+		}
+
+		if !reflect.DeepEqual(mergedField.Interface(), nextField.Interface()) {
+			*restartRequired = append(*restartRequired, path)
+		}
+	}
+}
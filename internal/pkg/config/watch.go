@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const defaultWatchInterval = 5 * time.Second
+
+type WatchOpts struct {
+	Interval time.Duration
+}
+
+func (opts *WatchOpts) apply() {
+	if opts.Interval == 0 {
+		opts.Interval = defaultWatchInterval
+	}
+}
+
+// Watch calls onChange on SIGHUP and whenever the content of the file at
+// path changes. It blocks until ctx is done.
+func Watch(ctx context.Context, path string, opts *WatchOpts, onChange func()) {
+	if opts == nil {
+		opts = &WatchOpts{}
+	}
+	opts.apply()
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	lastSum, _ := fileSum(path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			if sum, err := fileSum(path); err == nil {
+				lastSum = sum
+			}
+			onChange()
+		case <-ticker.C:
+			if path == "" {
+				continue
+			}
+			sum, err := fileSum(path)
+			if err != nil || sum == lastSum {
+				continue
+			}
+			lastSum = sum
+			onChange()
+		}
+	}
+}
+
+func fileSum(path string) ([sha256.Size]byte, error) {
+	if path == "" {
+		return [sha256.Size]byte{}, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return sha256.Sum256(content), nil
+}
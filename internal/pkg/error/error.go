@@ -17,3 +17,39 @@ func (e *ValidationError) Error() string {
 func (e *ValidationError) Unwrap() error {
 	return e.internal
 }
+
+type PermissionDeniedError struct {
+	internal error
+}
+
+func NewPermissionDeniedError(internal error) *PermissionDeniedError {
+	return &PermissionDeniedError{internal: internal}
+}
+
+var _ error = (*PermissionDeniedError)(nil)
+
+func (e *PermissionDeniedError) Error() string {
+	return e.internal.Error()
+}
+
+func (e *PermissionDeniedError) Unwrap() error {
+	return e.internal
+}
+
+type NotFoundError struct {
+	internal error
+}
+
+func NewNotFoundError(internal error) *NotFoundError {
+	return &NotFoundError{internal: internal}
+}
+
+var _ error = (*NotFoundError)(nil)
+
+func (e *NotFoundError) Error() string {
+	return e.internal.Error()
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.internal
+}
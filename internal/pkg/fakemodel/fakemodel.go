@@ -0,0 +1,550 @@
+// Package fakemodel serves deterministic stand-ins of the model servers: the
+// OpenAI compatible embeddings and chat completions endpoints and the
+// llama.cpp rerank and tokenize endpoints. It needs no models, so tests and the local
+// compose stack run without downloading any.
+package fakemodel
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	goccy_json "github.com/goccy/go-json"
+)
+
+const (
+	DefaultEmbeddingSize     = 384
+	DefaultCompletion        = "This is a fake completion."
+	DefaultModel             = "fake"
+	completionID             = "chatcmpl-fake"
+	completionFinishedReason = "stop"
+)
+
+// Completion is a scripted answer of the chat completions endpoint.
+type Completion struct {
+	// Match is a case insensitive substring of the last user message. An
+	// empty Match matches every message.
+	Match    string `json:"match" yaml:"match"`
+	Response string `json:"response" yaml:"response"`
+}
+
+type Opts struct {
+	// EmbeddingSize defaults to DefaultEmbeddingSize.
+	EmbeddingSize int
+	// Completions are tried in order; the first match answers.
+	Completions []*Completion
+	// DefaultCompletion answers when no completion matches. It defaults to DefaultCompletion.
+	DefaultCompletion string
+	// Latency delays every response.
+	Latency time.Duration
+	// ChunkLatency delays every streamed completion chunk.
+	ChunkLatency time.Duration
+	// MaxBatchSize fails the embeddings requests of more inputs, like the
+	// llama.cpp slots. 0 doesn't bound them.
+	MaxBatchSize int
+	// MaxBatchTokens fails the embeddings requests of more tokens, as counted
+	// by Tokenize, like the llama.cpp batch size. 0 doesn't bound them.
+	MaxBatchTokens int
+	// Fault is called for every request but the health check; a non zero
+	// status code fails the request with it.
+	Fault func(r *http.Request) (statusCode int)
+	// Logger defaults to discarding.
+	Logger *slog.Logger
+}
+
+func (opts *Opts) apply() {
+	if opts.EmbeddingSize <= 0 {
+		opts.EmbeddingSize = DefaultEmbeddingSize
+	}
+	if opts.DefaultCompletion == "" {
+		opts.DefaultCompletion = DefaultCompletion
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+}
+
+// FailFirst fails the first n requests with statusCode, e.g. to test retries.
+func FailFirst(n int, statusCode int) func(r *http.Request) int {
+	var requests atomic.Int64
+	return func(r *http.Request) int {
+		if requests.Add(1) <= int64(n) {
+			return statusCode
+		}
+		return 0
+	}
+}
+
+// FailRandomly fails the requests with statusCode with the probability rate.
+func FailRandomly(rate float64, statusCode int) func(r *http.Request) int {
+	return func(r *http.Request) int {
+		if rand.Float64() < rate {
+			return statusCode
+		}
+		return 0
+	}
+}
+
+// NewHandler returns the handler of all the endpoints. The paths are served
+// both with and without the /v1 prefix.
+func NewHandler(opts *Opts) http.Handler {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	opts.apply()
+
+	s := &server{opts: opts}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.health)
+	for _, prefix := range []string{"", "/v1"} {
+		mux.HandleFunc("POST "+prefix+"/embeddings", s.wrap(s.embeddings))
+		mux.HandleFunc("POST "+prefix+"/rerank", s.wrap(s.rerank))
+		mux.HandleFunc("POST "+prefix+"/chat/completions", s.wrap(s.chatCompletions))
+		mux.HandleFunc("POST "+prefix+"/tokenize", s.wrap(s.tokenize))
+	}
+
+	return mux
+}
+
+type server struct {
+	opts *Opts
+}
+
+func (s *server) wrap(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.opts.Logger.DebugContext(r.Context(), "fake model request", slog.String("method", r.Method), slog.String("path", r.URL.Path))
+
+		if !sleep(r, s.opts.Latency) {
+			return
+		}
+
+		if s.opts.Fault != nil {
+			if statusCode := s.opts.Fault(r); statusCode != 0 {
+				writeError(w, statusCode, "injected fault")
+				return
+			}
+		}
+
+		handler(w, r)
+	}
+}
+
+func (s *server) health(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"status":"ok"}`)
+}
+
+type embeddingsRequest struct {
+	Model string                `json:"model"`
+	Input goccy_json.RawMessage `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Object string                    `json:"object"`
+	Data   []*embeddingsResponseData `json:"data"`
+	Model  string                    `json:"model"`
+	Usage  *usage                    `json:"usage"`
+}
+
+type embeddingsResponseData struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+type usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+func (s *server) embeddings(w http.ResponseWriter, r *http.Request) {
+	var request embeddingsRequest
+	if err := goccy_json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var inputs []string
+	if err := goccy_json.Unmarshal(request.Input, &inputs); err != nil {
+		var input string
+		if err := goccy_json.Unmarshal(request.Input, &input); err != nil {
+			writeError(w, http.StatusBadRequest, "input must be a string or an array of strings")
+			return
+		}
+		inputs = []string{input}
+	}
+
+	if s.opts.MaxBatchSize > 0 && len(inputs) > s.opts.MaxBatchSize {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("too many inputs: %d, the batch takes %d", len(inputs), s.opts.MaxBatchSize))
+		return
+	}
+	if s.opts.MaxBatchTokens > 0 {
+		var tokens int
+		for _, input := range inputs {
+			tokens += len(Tokenize(input))
+		}
+		if tokens > s.opts.MaxBatchTokens {
+			writeError(w, http.StatusInternalServerError, "input is too large to process. increase the physical batch size")
+			return
+		}
+	}
+
+	response := &embeddingsResponse{
+		Object: "list",
+		Data:   make([]*embeddingsResponseData, len(inputs)),
+		Model:  modelOrDefault(request.Model),
+		Usage:  &usage{},
+	}
+	for i, input := range inputs {
+		response.Data[i] = &embeddingsResponseData{
+			Object:    "embedding",
+			Index:     i,
+			Embedding: Embed(input, s.opts.EmbeddingSize),
+		}
+		response.Usage.PromptTokens += len(Tokenize(input))
+	}
+	response.Usage.TotalTokens = response.Usage.PromptTokens
+
+	writeJSON(w, response)
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	TopN      int      `json:"top_n"`
+	Documents []string `json:"documents"`
+}
+
+type rerankResponse struct {
+	Model   string                  `json:"model"`
+	Results []*rerankResponseResult `json:"results"`
+}
+
+type rerankResponseResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float32 `json:"relevance_score"`
+}
+
+func (s *server) rerank(w http.ResponseWriter, r *http.Request) {
+	var request rerankRequest
+	if err := goccy_json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results := make([]*rerankResponseResult, len(request.Documents))
+	for i, document := range request.Documents {
+		results[i] = &rerankResponseResult{Index: i, RelevanceScore: RerankScore(request.Query, document)}
+	}
+	slices.SortStableFunc(results, func(a, b *rerankResponseResult) int {
+		switch {
+		case a.RelevanceScore > b.RelevanceScore:
+			return -1
+		case a.RelevanceScore < b.RelevanceScore:
+			return 1
+		default:
+			return 0
+		}
+	})
+	if request.TopN > 0 && request.TopN < len(results) {
+		results = results[:request.TopN]
+	}
+
+	writeJSON(w, &rerankResponse{Model: modelOrDefault(request.Model), Results: results})
+}
+
+type tokenizeRequest struct {
+	Content string `json:"content"`
+}
+
+type tokenizeResponse struct {
+	Tokens []int `json:"tokens"`
+}
+
+// tokenize returns a token id per word of the content.
+func (s *server) tokenize(w http.ResponseWriter, r *http.Request) {
+	var request tokenizeRequest
+	if err := goccy_json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tokens := Tokenize(request.Content)
+	response := &tokenizeResponse{Tokens: make([]int, len(tokens))}
+	for i, token := range tokens {
+		response.Tokens[i] = int(hash(token) % 32000)
+	}
+
+	writeJSON(w, response)
+}
+
+type chatCompletionsRequest struct {
+	Model    string         `json:"model"`
+	Messages []*chatMessage `json:"messages"`
+	Stream   bool           `json:"stream"`
+}
+
+type chatMessage struct {
+	Role string `json:"role"`
+	// Content is either a string or an array of content parts.
+	Content goccy_json.RawMessage `json:"content"`
+}
+
+type chatContentPart struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type chatCompletionsResponse struct {
+	ID      string                   `json:"id"`
+	Object  string                   `json:"object"`
+	Created int64                    `json:"created"`
+	Model   string                   `json:"model"`
+	Choices []*chatCompletionsChoice `json:"choices"`
+	Usage   *usage                   `json:"usage,omitempty"`
+}
+
+type chatCompletionsChoice struct {
+	Index        int                     `json:"index"`
+	Message      *chatCompletionsMessage `json:"message,omitempty"`
+	Delta        *chatCompletionsMessage `json:"delta,omitempty"`
+	FinishReason *string                 `json:"finish_reason"`
+}
+
+type chatCompletionsMessage struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content"`
+}
+
+func (s *server) chatCompletions(w http.ResponseWriter, r *http.Request) {
+	var request chatCompletionsRequest
+	if err := goccy_json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var lastUserMessage string
+	var promptTokens int
+	for _, message := range request.Messages {
+		content := message.text()
+		promptTokens += len(Tokenize(content))
+		if message.Role == "user" {
+			lastUserMessage = content
+		}
+	}
+
+	completion := s.complete(lastUserMessage)
+	finishReason := completionFinishedReason
+	model := modelOrDefault(request.Model)
+
+	if !request.Stream {
+		completionTokens := len(Tokenize(completion))
+		writeJSON(w, &chatCompletionsResponse{
+			ID:      completionID,
+			Object:  "chat.completion",
+			Created: time.Now().Unix(),
+			Model:   model,
+			Choices: []*chatCompletionsChoice{{
+				Message:      &chatCompletionsMessage{Role: "assistant", Content: completion},
+				FinishReason: &finishReason,
+			}},
+			Usage: &usage{PromptTokens: promptTokens, CompletionTokens: completionTokens, TotalTokens: promptTokens + completionTokens},
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher, _ := w.(http.Flusher)
+
+	writeChunk := func(delta *chatCompletionsMessage, finishReason *string) {
+		content, _ := goccy_json.Marshal(&chatCompletionsResponse{
+			ID:      completionID,
+			Object:  "chat.completion.chunk",
+			Created: time.Now().Unix(),
+			Model:   model,
+			Choices: []*chatCompletionsChoice{{Delta: delta, FinishReason: finishReason}},
+		})
+		fmt.Fprintf(w, "data: %s\n\n", content)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	for i, chunk := range strings.SplitAfter(completion, " ") {
+		if i > 0 && !sleep(r, s.opts.ChunkLatency) {
+			return
+		}
+		delta := &chatCompletionsMessage{Content: chunk}
+		if i == 0 {
+			delta.Role = "assistant"
+		}
+		writeChunk(delta, nil)
+	}
+	writeChunk(&chatCompletionsMessage{}, &finishReason)
+
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+func (s *server) complete(message string) string {
+	lowerMessage := strings.ToLower(message)
+	for _, completion := range s.opts.Completions {
+		if strings.Contains(lowerMessage, strings.ToLower(completion.Match)) {
+			return completion.Response
+		}
+	}
+	return s.opts.DefaultCompletion
+}
+
+func (m *chatMessage) text() string {
+	var content string
+	if err := goccy_json.Unmarshal(m.Content, &content); err == nil {
+		return content
+	}
+
+	var parts []*chatContentPart
+	if err := goccy_json.Unmarshal(m.Content, &parts); err != nil {
+		return ""
+	}
+
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part.Type == "text" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// Tokenize lowercases text and splits it into its words.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Embed returns a deterministic unit vector of the hashed words of text, so
+// texts sharing words have a higher cosine similarity.
+func Embed(text string, size int) []float32 {
+	vector := make([]float64, size)
+
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		// empty texts still need a unit vector for the cosine distance
+		vector[hash(text)%uint64(size)] = 1
+	}
+	for _, token := range tokens {
+		h := hash(token)
+		sign := 1.0
+		if h&(1<<63) != 0 {
+			sign = -1
+		}
+		vector[h%uint64(size)] += sign
+	}
+
+	var norm float64
+	for _, value := range vector {
+		norm += value * value
+	}
+	norm = math.Sqrt(norm)
+
+	embedding := make([]float32, size)
+	for i, value := range vector {
+		if norm > 0 {
+			embedding[i] = float32(value / norm)
+		}
+	}
+	return embedding
+}
+
+// RerankScore is the share of the distinct query words found in document.
+func RerankScore(query string, document string) float32 {
+	queryTokens := distinct(Tokenize(query))
+	if len(queryTokens) == 0 {
+		return 0
+	}
+
+	documentTokens := make(map[string]struct{})
+	for _, token := range Tokenize(document) {
+		documentTokens[token] = struct{}{}
+	}
+
+	var overlap int
+	for _, token := range queryTokens {
+		if _, ok := documentTokens[token]; ok {
+			overlap++
+		}
+	}
+	return float32(overlap) / float32(len(queryTokens))
+}
+
+func distinct(tokens []string) []string {
+	seen := make(map[string]struct{}, len(tokens))
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token]; !ok {
+			seen[token] = struct{}{}
+			result = append(result, token)
+		}
+	}
+	return result
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func sleep(r *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func modelOrDefault(model string) string {
+	if model == "" {
+		return DefaultModel
+	}
+	return model
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := goccy_json.NewEncoder(w).Encode(value); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	content, _ := goccy_json.Marshal(map[string]any{
+		"error": map[string]any{"code": statusCode, "message": message, "type": "fake_model_error"},
+	})
+	w.Write(content)
+}
@@ -0,0 +1,283 @@
+package fakemodel_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/fakemodel"
+	rag_config "github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/openai"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/reranker"
+	vectorstore_config "github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/embedder"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	tracer = otel_trace_noop.NewTracerProvider().Tracer("")
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+)
+
+func TestEmbedder(t *testing.T) {
+	t.Parallel()
+
+	server := fakemodel.NewTestServer(t, &fakemodel.Opts{EmbeddingSize: 16})
+
+	config := &vectorstore_config.Config{}
+	config.EmbedderConfig.BaseURL = server.URL + "/v1"
+	config.QdrantConfig.VectorSize = 16
+
+	embedder, err := embedder.NewEmbedder(context.Background(), config, tracer, logger, server.Client())
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	texts := []string{"the quick brown fox", "the quick brown fox", "a quick fox", "tax returns are due"}
+	embeddings, err := embedder.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if diff := cmp.Diff(len(texts), len(embeddings)); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(fakemodel.Embed(texts[0], 16), embeddings[0]); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(embeddings[0], embeddings[1]); diff != "" {
+		t.Fatal(diff)
+	}
+	if similar, unrelated := dot(embeddings[0], embeddings[2]), dot(embeddings[0], embeddings[3]); similar <= unrelated {
+		t.Fatalf("expected overlapping texts to be closer: similar=%f, unrelated=%f", similar, unrelated)
+	}
+}
+
+func TestEmbedder_Model(t *testing.T) {
+	t.Parallel()
+
+	model := func(embeddingSize int) string {
+		t.Helper()
+
+		server := fakemodel.NewTestServer(t, &fakemodel.Opts{EmbeddingSize: embeddingSize})
+
+		config := &vectorstore_config.Config{}
+		config.EmbedderConfig.BaseURL = server.URL + "/v1"
+		config.QdrantConfig.VectorSize = embeddingSize
+
+		embedder, err := embedder.NewEmbedder(context.Background(), config, tracer, logger, server.Client())
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		return embedder.Model()
+	}
+
+	if diff := cmp.Diff(model(16), model(16)); diff != "" {
+		t.Fatal(diff)
+	}
+	if model(16) == model(32) {
+		t.Fatal("want the models of different embeddings to differ")
+	}
+}
+
+func TestEmbed_UnitVectors(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{"", "...", "hello", "hello hello world"} {
+		if diff := cmp.Diff(1.0, float64(dot(fakemodel.Embed(text, 8), fakemodel.Embed(text, 8))), cmpopts.EquateApprox(0, 1e-6)); diff != "" {
+			t.Fatalf("text %q: %s", text, diff)
+		}
+	}
+}
+
+func TestReranker(t *testing.T) {
+	t.Parallel()
+
+	server := fakemodel.NewTestServer(t, nil)
+
+	config := &rag_config.Config{}
+	config.RerankerConfig.BaseURL = server.URL + "/v1"
+
+	reranker, err := reranker.NewReranker(context.Background(), config, tracer, logger, server.Client())
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	documents := []string{"nothing relevant", "paris is nice", "the capital of france is paris"}
+	results, err := reranker.Rerank(context.Background(), &domain.RerankerRerankInput{
+		Query:     "What is the capital of France?",
+		TopN:      2,
+		Documents: documents,
+	})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	want := []*domain.RerankerRerankResult{
+		// five and one of the six query words
+		{Index: 2, Document: documents[2], Score: float32(5) / 6},
+		{Index: 1, Document: documents[1], Score: float32(1) / 6},
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestRerankScore(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		query    string
+		document string
+		want     float32
+	}
+
+	testCases := []testCase{
+		{name: "all_words", query: "Capital of France", document: "france: its capital of note", want: 1},
+		{name: "half_words", query: "capital france", document: "the capital", want: 0.5},
+		{name: "repeated_query_words", query: "paris paris london", document: "paris", want: 0.5},
+		{name: "no_words", query: "capital", document: "tax", want: 0},
+		{name: "empty_query", query: "", document: "tax", want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.want, fakemodel.RerankScore(tc.query, tc.document)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestLLM_StreamCompletion(t *testing.T) {
+	t.Parallel()
+
+	server := fakemodel.NewTestServer(t, &fakemodel.Opts{
+		Completions: []*fakemodel.Completion{
+			{Match: "capital of france", Response: "The capital of France is Paris."},
+		},
+		ChunkLatency: time.Millisecond,
+	})
+
+	config := &rag_config.Config{}
+	config.OpenAIConfig.BaseURL = server.URL + "/v1"
+	config.OpenAIConfig.APIKey = "apikey"
+	config.OpenAIConfig.Model = "model"
+
+	llm, err := openai.NewLLM(context.Background(), config, tracer, logger, server.Client())
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	type testCase struct {
+		name    string
+		message string
+		want    []string
+	}
+
+	testCases := []testCase{
+		{
+			name:    "scripted",
+			message: "What is the Capital of France?",
+			want:    []string{"The ", "capital ", "of ", "France ", "is ", "Paris.", ""},
+		},
+		{
+			name:    "default",
+			message: "hello",
+			want:    []string{"This ", "is ", "a ", "fake ", "completion.", ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var chunks []string
+			llm.StreamCompletion(
+				context.Background(),
+				[]*domain.Message{
+					{Role: domain.RoleSystem, Content: "be brief"},
+					{Role: domain.RoleUser, Content: tc.message},
+				},
+				func(chunk string, err error) bool {
+					if err != nil {
+						t.Error(err)
+						return false
+					}
+					chunks = append(chunks, chunk)
+					return true
+				},
+			)
+
+			if diff := cmp.Diff(tc.want, chunks); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestFault(t *testing.T) {
+	t.Parallel()
+
+	server := fakemodel.NewTestServer(t, &fakemodel.Opts{Fault: fakemodel.FailFirst(1, http.StatusServiceUnavailable)})
+
+	config := &rag_config.Config{}
+	config.RerankerConfig.BaseURL = server.URL
+
+	_, err := reranker.NewReranker(context.Background(), config, tracer, logger, server.Client())
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected the injected fault, got %v", err)
+	}
+
+	if _, err := reranker.NewReranker(context.Background(), config, tracer, logger, server.Client()); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// the health check is never failed
+	response, err := server.Client().Get(server.URL + "/health")
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer response.Body.Close()
+	if diff := cmp.Diff(http.StatusOK, response.StatusCode); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	t.Parallel()
+
+	server := fakemodel.NewTestServer(t, &fakemodel.Opts{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/rerank", strings.NewReader(`{"query":"q","documents":["d"]}`))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if _, err := server.Client().Do(request); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
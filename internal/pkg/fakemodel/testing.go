@@ -0,0 +1,17 @@
+package fakemodel
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+// NewTestServer starts a fake model server closed on the test cleanup. Its
+// URL serves the llama.cpp paths and URL+"/v1" the OpenAI ones.
+func NewTestServer(tb testing.TB, opts *Opts) *httptest.Server {
+	tb.Helper()
+
+	server := httptest.NewServer(NewHandler(opts))
+	tb.Cleanup(server.Close)
+
+	return server
+}
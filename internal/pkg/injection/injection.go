@@ -0,0 +1,88 @@
+// Package injection scores texts for prompt injection attempts with pattern rules.
+package injection
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The metadata fields set on the ingested texts flagged as prompt injections.
+const (
+	MetadataFlagged = "injection_flagged"
+	MetadataScore   = "injection_score"
+	MetadataRules   = "injection_rules"
+)
+
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	// Weight is added to the score when the pattern matches.
+	Weight float32
+}
+
+// DefaultRules match the common instruction override, role play, prompt leaking and chat markup injections.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{
+			Name:    "ignore_instructions",
+			Pattern: regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\b.{0,20}\b(previous|prior|above|earlier|all|any|system)\b.{0,20}\b(instructions?|prompts?|rules|directions|context)\b`),
+			Weight:  1,
+		},
+		{
+			Name:    "new_instructions",
+			Pattern: regexp.MustCompile(`(?i)\b(new|updated|real)\s+(instructions?|system prompt)\s*:|\bfrom now on,?\s+(you|ignore|respond|answer)\b`),
+			Weight:  1,
+		},
+		{
+			Name:    "prompt_leak",
+			Pattern: regexp.MustCompile(`(?i)\b(reveal|print|show|repeat|output)\b.{0,20}\b(system prompt|initial prompt|your instructions|hidden instructions)\b`),
+			Weight:  1,
+		},
+		{
+			Name:    "chat_markup",
+			Pattern: regexp.MustCompile(`(?i)<\|?(im_start|im_end|system|endoftext)\|?>|\[/?INST\]|<</?SYS>>|^\s*#{2,}\s*(system|instruction)s?\b`),
+			Weight:  1,
+		},
+		{
+			Name:    "role_play",
+			Pattern: regexp.MustCompile(`(?i)\byou are now\b|\b(act|behave) as (a|an|if)\b|\bpretend (to be|you are)\b|\bjailbreak\b|\bDAN mode\b`),
+			Weight:  0.5,
+		},
+		{
+			Name:    "addressing_the_model",
+			Pattern: regexp.MustCompile(`(?i)\b(dear|attention|note to|hey)\s+(ai|assistant|language model|llm|chatbot)\b|\bas an ai (language )?model\b`),
+			Weight:  0.5,
+		},
+	}
+}
+
+type Scanner struct {
+	rules []*Rule
+}
+
+// NewScanner returns a scanner with the default rules and a rule of weight
+// one for each of the extra patterns.
+func NewScanner(patterns []string) (*Scanner, error) {
+	rules := DefaultRules()
+
+	for i, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile injection pattern %q: %w", pattern, err)
+		}
+		rules = append(rules, &Rule{Name: fmt.Sprintf("pattern_%d", i), Pattern: re, Weight: 1})
+	}
+
+	return &Scanner{rules: rules}, nil
+}
+
+// Scan returns the sum of the matched rules weights capped at one, and the matched rules names.
+func (s *Scanner) Scan(text string) (score float32, rules []string) {
+	for _, rule := range s.rules {
+		if rule.Pattern.MatchString(text) {
+			score += rule.Weight
+			rules = append(rules, rule.Name)
+		}
+	}
+	return min(score, 1), rules
+}
@@ -0,0 +1,345 @@
+// Package pii detects personal data in texts and redacts it by masking,
+// hashing or dropping it.
+package pii
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// The personal data types.
+const (
+	TypeEmail        = "email"
+	TypePhone        = "phone"
+	TypeCard         = "card"
+	TypeIBAN         = "iban"
+	TypeUSSSN        = "us_ssn"
+	TypeIRNationalID = "ir_national_id"
+)
+
+// The redaction strategies.
+const (
+	// StrategyMask replaces the value with its type, e.g. [EMAIL].
+	StrategyMask = "mask"
+	// StrategyHash replaces the value with its type and a keyed hash, e.g.
+	// [EMAIL:1f2e3d4c5b6a], so equal values can still be correlated.
+	StrategyHash = "hash"
+	// StrategyDrop removes the value.
+	StrategyDrop = "drop"
+)
+
+// DefaultTenant is the metrics tenant of the texts redacted with the default policy.
+const DefaultTenant = "default"
+
+// Policy is what is redacted and how.
+type Policy struct {
+	Strategy string `yaml:"strategy" toml:"strategy" validate:"omitempty,oneof=mask hash drop"`
+	// Types are the redacted personal data types, all of them when empty.
+	Types []string `yaml:"types" toml:"types" validate:"dive,oneof=email phone card iban us_ssn ir_national_id"`
+	// Allow are regular expressions matching the whole values that are kept, e.g. a support email address.
+	Allow []string `yaml:"allow" toml:"allow"`
+}
+
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+	// valid filters out the pattern matches failing a checksum or a format check.
+	valid func(value string) bool
+}
+
+// detectors are ordered by priority: a value is redacted as the first type detecting it.
+var detectors = []*detector{
+	{
+		name:    TypeEmail,
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	},
+	{
+		name:    TypeCard,
+		pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		valid:   validCard,
+	},
+	{
+		name:    TypeIBAN,
+		pattern: regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		valid:   validIBAN,
+	},
+	{
+		name:    TypeUSSSN,
+		pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		valid:   validUSSSN,
+	},
+	{
+		name:    TypeIRNationalID,
+		pattern: regexp.MustCompile(`\b\d{3}-?\d{6}-?\d\b`),
+		valid:   validIRNationalID,
+	},
+	{
+		name:    TypePhone,
+		pattern: regexp.MustCompile(`(?:\+|\b)\d(?:[ ().-]{0,2}\d){7,14}\b`),
+		valid:   validPhone,
+	},
+}
+
+type policy struct {
+	tenant    string
+	strategy  string
+	detectors []*detector
+	allow     []*regexp.Regexp
+}
+
+type Redactor struct {
+	defaultPolicy *policy
+	tenants       map[string]*policy
+	hashKey       []byte
+	redactions    metric.Int64Counter
+}
+
+// NewRedactor redacts the texts of the tenants with their policy, falling
+// back to defaultPolicy. The tenant policies override the default policy
+// fields they set. The redactions are counted in the pii.redactions counter
+// of meter.
+func NewRedactor(defaultPolicy Policy, tenants map[string]Policy, hashKey string, meter metric.Meter) (*Redactor, error) {
+	if defaultPolicy.Strategy == "" {
+		defaultPolicy.Strategy = StrategyMask
+	}
+
+	redactions, err := meter.Int64Counter(
+		"pii.redactions",
+		metric.WithDescription("The number of redacted personal data values."),
+		metric.WithUnit("{redaction}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the redactions counter: %w", err)
+	}
+
+	r := &Redactor{
+		tenants:    make(map[string]*policy, len(tenants)),
+		hashKey:    []byte(hashKey),
+		redactions: redactions,
+	}
+
+	r.defaultPolicy, err = newPolicy(DefaultTenant, defaultPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	for tenant, tenantPolicy := range tenants {
+		if tenantPolicy.Strategy == "" {
+			tenantPolicy.Strategy = defaultPolicy.Strategy
+		}
+		if tenantPolicy.Types == nil {
+			tenantPolicy.Types = defaultPolicy.Types
+		}
+		if tenantPolicy.Allow == nil {
+			tenantPolicy.Allow = defaultPolicy.Allow
+		}
+
+		r.tenants[tenant], err = newPolicy(tenant, tenantPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", tenant, err)
+		}
+	}
+
+	return r, nil
+}
+
+func newPolicy(tenant string, p Policy) (*policy, error) {
+	switch p.Strategy {
+	case StrategyMask, StrategyHash, StrategyDrop:
+	default:
+		return nil, fmt.Errorf("unknown pii strategy %q", p.Strategy)
+	}
+
+	compiled := &policy{tenant: tenant, strategy: p.Strategy}
+
+	for _, d := range detectors {
+		if len(p.Types) == 0 || slices.Contains(p.Types, d.name) {
+			compiled.detectors = append(compiled.detectors, d)
+		}
+	}
+	for _, name := range p.Types {
+		if !slices.ContainsFunc(detectors, func(d *detector) bool { return d.name == name }) {
+			return nil, fmt.Errorf("unknown pii type %q", name)
+		}
+	}
+
+	for _, pattern := range p.Allow {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile pii allow pattern %q: %w", pattern, err)
+		}
+		compiled.allow = append(compiled.allow, re)
+	}
+
+	return compiled, nil
+}
+
+type match struct {
+	start, end int
+	name       string
+}
+
+// Redact redacts the personal data in text with the policy of tenant.
+func (r *Redactor) Redact(ctx context.Context, tenant, text string) string {
+	p, ok := r.tenants[tenant]
+	if !ok {
+		p = r.defaultPolicy
+	}
+
+	var matches []match
+	for _, d := range p.detectors {
+		for _, loc := range d.pattern.FindAllStringIndex(text, -1) {
+			value := text[loc[0]:loc[1]]
+			if d.valid != nil && !d.valid(value) {
+				continue
+			}
+			if slices.ContainsFunc(p.allow, func(re *regexp.Regexp) bool { return re.MatchString(value) }) {
+				continue
+			}
+			// a higher priority type already detected an overlapping value
+			if slices.ContainsFunc(matches, func(m match) bool { return loc[0] < m.end && m.start < loc[1] }) {
+				continue
+			}
+			matches = append(matches, match{start: loc[0], end: loc[1], name: d.name})
+		}
+	}
+
+	if len(matches) == 0 {
+		return text
+	}
+
+	slices.SortFunc(matches, func(a, b match) int { return a.start - b.start })
+
+	var (
+		redacted strings.Builder
+		last     int
+		counts   = make(map[string]int64)
+	)
+	for _, m := range matches {
+		redacted.WriteString(text[last:m.start])
+		redacted.WriteString(r.replacement(p.strategy, m.name, text[m.start:m.end]))
+		last = m.end
+		counts[m.name]++
+	}
+	redacted.WriteString(text[last:])
+
+	for name, count := range counts {
+		r.redactions.Add(ctx, count, metric.WithAttributes(
+			attribute.String("type", name),
+			attribute.String("tenant", p.tenant),
+			attribute.String("strategy", p.strategy),
+		))
+	}
+
+	return redacted.String()
+}
+
+func (r *Redactor) replacement(strategy, name, value string) string {
+	switch strategy {
+	case StrategyHash:
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(value))
+		return "[" + strings.ToUpper(name) + ":" + hex.EncodeToString(mac.Sum(nil))[:12] + "]"
+	case StrategyDrop:
+		return ""
+	default:
+		return "[" + strings.ToUpper(name) + "]"
+	}
+}
+
+func digits(value string) []int {
+	var ds []int
+	for _, c := range value {
+		if '0' <= c && c <= '9' {
+			ds = append(ds, int(c-'0'))
+		}
+	}
+	return ds
+}
+
+// validCard checks the length and the Luhn checksum of a card number.
+func validCard(value string) bool {
+	ds := digits(value)
+	if len(ds) < 13 || len(ds) > 19 {
+		return false
+	}
+
+	sum := 0
+	for i := range ds {
+		d := ds[len(ds)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// validIBAN checks the length and the mod 97 checksum of an iban.
+func validIBAN(value string) bool {
+	iban := strings.ReplaceAll(value, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	// the country code and the check digits are moved to the end and the letters are numbered from 10
+	remainder := 0
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case '0' <= c && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case 'A' <= c && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+
+	return remainder == 1
+}
+
+// validUSSSN rejects the never assigned social security number areas, groups and serials.
+func validUSSSN(value string) bool {
+	area, group, serial := value[0:3], value[4:6], value[7:11]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// validIRNationalID checks the check digit of an iranian national id.
+func validIRNationalID(value string) bool {
+	ds := digits(value)
+	if len(ds) != 10 || slices.Max(ds) == slices.Min(ds) {
+		return false
+	}
+
+	sum := 0
+	for i := range 9 {
+		sum += ds[i] * (10 - i)
+	}
+	remainder := sum % 11
+
+	if remainder < 2 {
+		return ds[9] == remainder
+	}
+	return ds[9] == 11-remainder
+}
+
+var isoDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\b`)
+
+// validPhone checks the number of digits of an international phone number and rejects dates.
+func validPhone(value string) bool {
+	n := len(digits(value))
+	return n >= 8 && n <= 15 && !isoDatePattern.MatchString(value)
+}
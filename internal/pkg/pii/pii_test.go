@@ -0,0 +1,173 @@
+package pii_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/pii"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	otel_metric_noop "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		policy pii.Policy
+		text   string
+	}
+
+	type testCase struct {
+		name  string
+		input input
+		want  string
+	}
+
+	testCases := []testCase{
+		{
+			name: "nothing",
+			input: input{
+				text: "Release 2.4.1 shipped on 2024-05-01 10:30 with 1234567 downloads.",
+			},
+			want: "Release 2.4.1 shipped on 2024-05-01 10:30 with 1234567 downloads.",
+		},
+		{
+			name: "mask",
+			input: input{
+				text: "Mail jane.doe@example.com or call +1 (555) 123-4567.",
+			},
+			want: "Mail [EMAIL] or call [PHONE].",
+		},
+		{
+			name: "checksums",
+			input: input{
+				text: "card 4111 1111 1111 1111, iban GB82 WEST 1234 5698 7654 32, ssn 123-45-6789, national id 0012345679",
+			},
+			want: "card [CARD], iban [IBAN], ssn [US_SSN], national id [IR_NATIONAL_ID]",
+		},
+		{
+			name: "invalid_checksums",
+			input: input{
+				text: "order 4111 1111 1111 1112, ssn 000-45-6789, id 0012345678",
+				// phone would match the order and id numbers
+				policy: pii.Policy{Types: []string{pii.TypeCard, pii.TypeUSSSN, pii.TypeIRNationalID}},
+			},
+			want: "order 4111 1111 1111 1112, ssn 000-45-6789, id 0012345678",
+		},
+		{
+			name: "types",
+			input: input{
+				policy: pii.Policy{Types: []string{pii.TypeEmail}},
+				text:   "jane@example.com +1 555 123 4567",
+			},
+			want: "[EMAIL] +1 555 123 4567",
+		},
+		{
+			name: "allow",
+			input: input{
+				policy: pii.Policy{Allow: []string{`support@example\.com`}},
+				text:   "support@example.com, not jane@example.com or support@example.com.evil.io",
+			},
+			want: "support@example.com, not [EMAIL] or [EMAIL]",
+		},
+		{
+			name: "hash",
+			input: input{
+				policy: pii.Policy{Strategy: pii.StrategyHash},
+				text:   "jane@example.com wrote to jane@example.com",
+			},
+			want: "[EMAIL:43c915a5fb2a] wrote to [EMAIL:43c915a5fb2a]",
+		},
+		{
+			name: "drop",
+			input: input{
+				policy: pii.Policy{Strategy: pii.StrategyDrop},
+				text:   "Mail jane@example.com.",
+			},
+			want: "Mail .",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			redactor, err := pii.NewRedactor(tc.input.policy, nil, "key", otel_metric_noop.NewMeterProvider().Meter(""))
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			got := redactor.Redact(context.Background(), "", tc.input.text)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestRedactor_Redact_Tenants(t *testing.T) {
+	t.Parallel()
+
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("")
+
+	redactor, err := pii.NewRedactor(
+		pii.Policy{},
+		map[string]pii.Policy{
+			"acme": {Strategy: pii.StrategyDrop, Types: []string{pii.TypePhone}},
+		},
+		"",
+		meter,
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	const text = "jane@example.com +1 555 123 4567"
+
+	got := []string{
+		redactor.Redact(context.Background(), "acme", text),
+		redactor.Redact(context.Background(), "other", text),
+	}
+	if diff := cmp.Diff([]string{"jane@example.com ", "[EMAIL] [PHONE]"}, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	counts := make(map[string]int64)
+	for _, dataPoint := range metrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64]).DataPoints {
+		tenant, _ := dataPoint.Attributes.Value(attribute.Key("tenant"))
+		typ, _ := dataPoint.Attributes.Value(attribute.Key("type"))
+		counts[tenant.AsString()+"/"+typ.AsString()] += dataPoint.Value
+	}
+	if diff := cmp.Diff(map[string]int64{"acme/phone": 1, "default/email": 1, "default/phone": 1}, counts); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestNewRedactor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	meter := otel_metric_noop.NewMeterProvider().Meter("")
+
+	for name, policy := range map[string]pii.Policy{
+		"strategy": {Strategy: "encrypt"},
+		"type":     {Types: []string{"passport"}},
+		"allow":    {Allow: []string{"("}},
+	} {
+		if _, err := pii.NewRedactor(policy, nil, "", meter); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+		if _, err := pii.NewRedactor(pii.Policy{}, map[string]pii.Policy{"acme": policy}, "", meter); err == nil {
+			t.Fatalf("tenant %s: expected error", name)
+		}
+	}
+}
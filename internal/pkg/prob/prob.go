@@ -18,22 +18,22 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
-func CheckToRunProbe(config server.Config) {
-	var (
-		probeType string
-		mute      bool
-	)
+var (
+	probeType = flag.String("probe", "", "probe type (http or grpc: other values skips the prob and run the server)")
+	mute      = flag.Bool("mute", false, "mute prob output")
+)
 
-	flag.StringVar(&probeType, "probe", "", "probe type (http or grpc: other values skips the prob and run the server)")
-	flag.BoolVar(&mute, "mute", false, "mute prob output")
-	flag.Parse()
+func CheckToRunProbe(config server.Config) {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
 
 	var (
 		probeFunc func(port uint16) (response string, err error)
 		port      uint16
 	)
 
-	switch strings.ToLower(probeType) {
+	switch strings.ToLower(*probeType) {
 	case "http":
 		probeFunc = runHTTPProbe
 		port = config.HTTPPort
@@ -46,13 +46,13 @@ func CheckToRunProbe(config server.Config) {
 
 	response, err := probeFunc(port)
 	if err != nil {
-		if !mute {
+		if !*mute {
 			fmt.Fprintf(os.Stderr, "prob failed at %d: %s\n", time.Now().Unix(), err)
 		}
 		os.Exit(1)
 	}
 
-	if !mute {
+	if !*mute {
 		fmt.Printf("probe successful at %d: %s\n", time.Now().Unix(), strings.TrimRight(response, "\n"))
 	}
 
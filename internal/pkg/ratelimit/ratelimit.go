@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	grpc_codes "google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+)
+
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// LimitFunc returns the current limits. A non-positive requestsPerSecond disables the limiter.
+type LimitFunc func() (requestsPerSecond float64, burst int)
+
+// Limiter is a server wide token bucket whose limits follow limitFn, so
+// reloaded limits take effect on the next request.
+type Limiter struct {
+	mu                sync.Mutex
+	limiter           *rate.Limiter
+	limitFn           LimitFunc
+	requestsPerSecond float64
+	burst             int
+}
+
+func New(limitFn LimitFunc) *Limiter {
+	return &Limiter{
+		limiter: rate.NewLimiter(rate.Inf, 0),
+		limitFn: limitFn,
+	}
+}
+
+func (l *Limiter) Allow() bool {
+	requestsPerSecond, burst := l.limitFn()
+	if requestsPerSecond <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	if requestsPerSecond != l.requestsPerSecond || burst != l.burst {
+		l.requestsPerSecond, l.burst = requestsPerSecond, burst
+		l.limiter.SetLimit(rate.Limit(requestsPerSecond))
+		l.limiter.SetBurst(max(burst, 1))
+	}
+	l.mu.Unlock()
+
+	return l.limiter.Allow()
+}
+
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !strings.HasPrefix(info.FullMethod, healthServicePrefix) && !l.Allow() {
+			return nil, grpc_status.New(grpc_codes.ResourceExhausted, "rate limit exceeded").Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, healthServicePrefix) && !l.Allow() {
+			return grpc_status.New(grpc_codes.ResourceExhausted, "rate limit exceeded").Err()
+		}
+		return handler(srv, stream)
+	}
+}
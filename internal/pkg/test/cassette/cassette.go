@@ -0,0 +1,335 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	goccy_json "github.com/goccy/go-json"
+)
+
+const (
+	ModeEnv    = "CASSETTE_MODE"
+	MatcherEnv = "CASSETTE_MATCHER"
+	CIEnv      = "CI"
+)
+
+type Mode string
+
+const (
+	// ModeOff passes the requests through to the real servers.
+	ModeOff Mode = "off"
+	// ModeRecord passes the requests through and saves the exchanges to the cassette.
+	ModeRecord Mode = "record"
+	// ModeReplay answers the requests from the cassette without any network access.
+	ModeReplay Mode = "replay"
+)
+
+// ModeFromEnv returns the mode named by CASSETTE_MODE. It defaults to
+// ModeReplay on CI (the CI variable is set) and to ModeOff elsewhere.
+func ModeFromEnv() Mode {
+	switch mode := Mode(strings.ToLower(os.Getenv(ModeEnv))); mode {
+	case ModeOff, ModeRecord, ModeReplay:
+		return mode
+	default:
+		if os.Getenv(CIEnv) != "" {
+			return ModeReplay
+		}
+		return ModeOff
+	}
+}
+
+// volatileHeaders are not recorded: they change between runs or no longer
+// hold once the body is buffered.
+var volatileHeaders = []string{"Date", "Content-Length", "Transfer-Encoding", "Keep-Alive", "Connection"}
+
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+	// BodyEncoding is "base64" when the body is not valid utf-8.
+	BodyEncoding string `json:"body_encoding,omitempty"`
+}
+
+type Response struct {
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+type Opts struct {
+	// Mode defaults to ModeFromEnv.
+	Mode Mode
+	// Matcher defaults to MatcherFromEnv.
+	Matcher Matcher
+	// Transport is the real transport used in off and record modes. It defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+func (opts *Opts) apply() {
+	if opts.Mode == "" {
+		opts.Mode = ModeFromEnv()
+	}
+	if opts.Matcher == nil {
+		opts.Matcher = MatcherFromEnv()
+	}
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+}
+
+// Recorder is an http.RoundTripper recording to or replaying from a cassette file.
+type Recorder struct {
+	path string
+	opts *Opts
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+var _ http.RoundTripper = (*Recorder)(nil)
+
+func New(path string, opts *Opts) (*Recorder, error) {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	opts.apply()
+
+	recorder := &Recorder{
+		path:     path,
+		opts:     opts,
+		cassette: &Cassette{},
+	}
+
+	if opts.Mode == ModeReplay {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette (record it with %s=%s): %w", ModeEnv, ModeRecord, err)
+		}
+		if err := goccy_json.Unmarshal(content, recorder.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+		recorder.used = make([]bool, len(recorder.cassette.Interactions))
+	}
+
+	return recorder, nil
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.opts.Mode
+}
+
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	switch r.opts.Mode {
+	case ModeReplay:
+		return r.replay(request)
+	case ModeRecord:
+		return r.record(request)
+	default:
+		return r.opts.Transport.RoundTrip(request)
+	}
+}
+
+// Stop saves the cassette when recording.
+func (r *Recorder) Stop() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	content, err := goccy_json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	return os.WriteFile(r.path, append(content, '\n'), 0o644)
+}
+
+func (r *Recorder) record(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := r.opts.Transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// streamed (e.g. server-sent events) responses are read to the end
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	header := response.Header.Clone()
+	for _, key := range volatileHeaders {
+		header.Del(key)
+	}
+
+	interaction := &Interaction{
+		Request: &Request{
+			Method: request.Method,
+			URL:    request.URL.String(),
+		},
+		Response: &Response{
+			StatusCode: response.StatusCode,
+			Header:     header,
+		},
+	}
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeBody(requestBody)
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(responseBody)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return newResponse(request, interaction.Response, responseBody), nil
+}
+
+// replay answers with the first unused matching interaction. Once all the
+// matching interactions are used the last one is reused, so repeated
+// identical requests need to be recorded only once.
+func (r *Recorder) replay(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	matched := -1
+	for i, interaction := range r.cassette.Interactions {
+		recordedBody, err := decodeBody(interaction.Request.Body, interaction.Request.BodyEncoding)
+		if err != nil {
+			return nil, err
+		}
+		if !r.opts.Matcher.Match(interaction.Request, recordedBody, request, requestBody) {
+			continue
+		}
+
+		matched = i
+		if !r.used[i] {
+			break
+		}
+	}
+
+	if matched == -1 {
+		return nil, fmt.Errorf("cassette %s has no interaction matching %s %s", r.path, request.Method, request.URL.Path)
+	}
+	r.used[matched] = true
+
+	response := r.cassette.Interactions[matched].Response
+
+	responseBody, err := decodeBody(response.Body, response.BodyEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResponse(request, response, responseBody), nil
+}
+
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func newResponse(request *http.Request, response *Response, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        response.Header.Clone(),
+		Body:          io.NopCloser(newEventReader(body)),
+		ContentLength: -1,
+		Request:       request,
+	}
+}
+
+// eventReader returns the body one server-sent event at a time so streamed
+// responses are replayed as a stream.
+type eventReader struct {
+	events [][]byte
+}
+
+func newEventReader(body []byte) *eventReader {
+	return &eventReader{events: bytes.SplitAfter(body, []byte("\n\n"))}
+}
+
+func (e *eventReader) Read(p []byte) (int, error) {
+	for len(e.events) > 0 && len(e.events[0]) == 0 {
+		e.events = e.events[1:]
+	}
+	if len(e.events) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, e.events[0])
+	e.events[0] = e.events[0][n:]
+
+	return n, nil
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body string, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 body: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unknown body encoding %q", encoding)
+	}
+}
@@ -0,0 +1,284 @@
+package cassette_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/test/cassette"
+	test_http "github.com/aria3ppp/rag-server/internal/pkg/test/http"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newUpstream(t *testing.T, calls *atomic.Int64) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(test_http.MockHandlers{
+		"POST /v1/embeddings": func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"echo":%s}`, body)
+		},
+		"POST /v1/chat/completions": func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, chunk := range []string{"hello", " world"} {
+				fmt.Fprintf(w, "data: {\"content\":%q}\n\n", chunk)
+				w.(http.Flusher).Flush()
+			}
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		},
+	})
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func post(t *testing.T, client *http.Client, url string, body string) (int, string) {
+	t.Helper()
+
+	response, err := client.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	return response.StatusCode, string(content)
+}
+
+func TestRecorder_RecordReplay(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	upstream := newUpstream(t, &calls)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	// record
+	recorder, err := cassette.New(path, &cassette.Opts{Mode: cassette.ModeRecord})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	_, embeddings := post(t, recorder.Client(), upstream.URL+"/v1/embeddings", `{"input":["a"],"model":"m"}`)
+	_, completion := post(t, recorder.Client(), upstream.URL+"/v1/chat/completions", `{"stream":true}`)
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	upstream.Close()
+
+	if diff := cmp.Diff(`{"echo":{"input":["a"],"model":"m"}}`, embeddings); diff != "" {
+		t.Fatal(diff)
+	}
+
+	type testCase struct {
+		name       string
+		matcher    cassette.Matcher
+		url        string
+		body       string
+		wantErr    bool
+		wantStatus int
+		wantBody   string
+	}
+
+	testCases := []testCase{
+		{
+			name:       "json_matcher_ignores_key_order_and_host",
+			matcher:    cassette.JSONMatcher(),
+			url:        "http://localhost:1/v1/embeddings",
+			body:       `{ "model": "m", "input": ["a"] }`,
+			wantStatus: http.StatusOK,
+			wantBody:   embeddings,
+		},
+		{
+			name:    "json_matcher_different_value",
+			matcher: cassette.JSONMatcher(),
+			url:     "http://localhost:1/v1/embeddings",
+			body:    `{"input":["b"],"model":"m"}`,
+			wantErr: true,
+		},
+		{
+			name:       "json_matcher_ignored_field",
+			matcher:    cassette.JSONMatcher("model"),
+			url:        "http://localhost:1/v1/embeddings",
+			body:       `{"input":["a"],"model":"other"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   embeddings,
+		},
+		{
+			name:       "body_hash_matcher_same_body",
+			matcher:    cassette.BodyHashMatcher(),
+			url:        "http://localhost:1/v1/embeddings",
+			body:       `{"input":["a"],"model":"m"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   embeddings,
+		},
+		{
+			name:    "body_hash_matcher_reordered_body",
+			matcher: cassette.BodyHashMatcher(),
+			url:     "http://localhost:1/v1/embeddings",
+			body:    `{"model":"m","input":["a"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "different_path",
+			matcher: cassette.JSONMatcher(),
+			url:     "http://localhost:1/v1/rerank",
+			body:    `{"input":["a"],"model":"m"}`,
+			wantErr: true,
+		},
+		{
+			name:       "streamed_response",
+			matcher:    cassette.JSONMatcher(),
+			url:        "http://localhost:1/v1/chat/completions",
+			body:       `{"stream":true}`,
+			wantStatus: http.StatusOK,
+			wantBody:   completion,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			replayer, err := cassette.New(path, &cassette.Opts{Mode: cassette.ModeReplay, Matcher: tc.matcher})
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			response, err := replayer.Client().Post(tc.url, "application/json", strings.NewReader(tc.body))
+			if (err != nil) != tc.wantErr {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+
+			content, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if diff := cmp.Diff(tc.wantStatus, response.StatusCode); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(tc.wantBody, string(content)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	if diff := cmp.Diff(int64(2), calls.Load()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestRecorder_ReplayStreamsEvents(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	upstream := newUpstream(t, &calls)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := cassette.New(path, &cassette.Opts{Mode: cassette.ModeRecord})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	post(t, recorder.Client(), upstream.URL+"/v1/chat/completions", `{}`)
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	replayer, err := cassette.New(path, &cassette.Opts{Mode: cassette.ModeReplay})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	response, err := replayer.Client().Post("http://localhost:1/v1/chat/completions", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer response.Body.Close()
+
+	if diff := cmp.Diff("text/event-stream", response.Header.Get("Content-Type")); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var events []string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			events = append(events, line)
+		}
+	}
+
+	want := []string{`data: {"content":"hello"}`, `data: {"content":" world"}`, `data: [DONE]`}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestNew_ReplayMissingCassette(t *testing.T) {
+	t.Parallel()
+
+	_, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), &cassette.Opts{Mode: cassette.ModeReplay})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	type testCase struct {
+		name string
+		mode string
+		ci   string
+		want cassette.Mode
+	}
+
+	testCases := []testCase{
+		{name: "default", want: cassette.ModeOff},
+		{name: "ci_default", ci: "true", want: cassette.ModeReplay},
+		{name: "ci_off", mode: "off", ci: "true", want: cassette.ModeOff},
+		{name: "record", mode: "RECORD", want: cassette.ModeRecord},
+		{name: "unknown", mode: "unknown", want: cassette.ModeOff},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(cassette.ModeEnv, tc.mode)
+			t.Setenv(cassette.CIEnv, tc.ci)
+
+			if diff := cmp.Diff(tc.want, cassette.ModeFromEnv()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestNewTest_ReplayMissingCassette(t *testing.T) {
+	t.Parallel()
+
+	var skipped bool
+	t.Run("missing", func(t *testing.T) {
+		defer func() { skipped = t.Skipped() }()
+		cassette.NewTest(t, "missing", &cassette.Opts{Mode: cassette.ModeReplay})
+	})
+
+	if !skipped {
+		t.Fatal("want the test replaying a missing cassette skipped")
+	}
+}
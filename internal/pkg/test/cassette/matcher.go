@@ -0,0 +1,92 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+
+	goccy_json "github.com/goccy/go-json"
+)
+
+// Matcher decides whether a recorded request answers a new one. The host
+// and port are never compared: the servers listen on random ports.
+type Matcher interface {
+	Match(recorded *Request, recordedBody []byte, request *http.Request, requestBody []byte) bool
+}
+
+type MatcherFunc func(recorded *Request, recordedBody []byte, request *http.Request, requestBody []byte) bool
+
+func (f MatcherFunc) Match(recorded *Request, recordedBody []byte, request *http.Request, requestBody []byte) bool {
+	return f(recorded, recordedBody, request, requestBody)
+}
+
+// MatcherFromEnv returns the matcher named by CASSETTE_MATCHER: "body_hash"
+// or "json" (the default).
+func MatcherFromEnv() Matcher {
+	switch strings.ToLower(os.Getenv(MatcherEnv)) {
+	case "body_hash":
+		return BodyHashMatcher()
+	default:
+		return JSONMatcher()
+	}
+}
+
+// BodyHashMatcher matches the method, path, query and the sha256 of the body.
+func BodyHashMatcher() Matcher {
+	return MatcherFunc(func(recorded *Request, recordedBody []byte, request *http.Request, requestBody []byte) bool {
+		return matchMethodAndURL(recorded, request) && sha256.Sum256(recordedBody) == sha256.Sum256(requestBody)
+	})
+}
+
+// JSONMatcher matches the method, path, query and the bodies as JSON values,
+// so key order and whitespace do not matter. The ignoredFields top level
+// keys are dropped before comparing. Non JSON bodies are compared as bytes.
+func JSONMatcher(ignoredFields ...string) Matcher {
+	return MatcherFunc(func(recorded *Request, recordedBody []byte, request *http.Request, requestBody []byte) bool {
+		if !matchMethodAndURL(recorded, request) {
+			return false
+		}
+
+		recordedValue, recordedErr := normalizeJSON(recordedBody, ignoredFields)
+		requestValue, requestErr := normalizeJSON(requestBody, ignoredFields)
+		if recordedErr != nil || requestErr != nil {
+			return bytes.Equal(recordedBody, requestBody)
+		}
+
+		return reflect.DeepEqual(recordedValue, requestValue)
+	})
+}
+
+func matchMethodAndURL(recorded *Request, request *http.Request) bool {
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+
+	return recorded.Method == request.Method &&
+		recordedURL.Path == request.URL.Path &&
+		reflect.DeepEqual(recordedURL.Query(), request.URL.Query())
+}
+
+func normalizeJSON(body []byte, ignoredFields []string) (any, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	var value any
+	if err := goccy_json.Unmarshal(body, &value); err != nil {
+		return nil, err
+	}
+
+	if object, ok := value.(map[string]any); ok {
+		for _, field := range ignoredFields {
+			delete(object, field)
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,41 @@
+package cassette
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// NewTest returns a recorder for the testdata/cassettes/<name>.json cassette
+// of the calling test package, saved when the test ends. Replaying a cassette
+// not recorded yet skips the test.
+func NewTest(tb testing.TB, name string, opts *Opts) *Recorder {
+	tb.Helper()
+
+	path := filepath.Join("testdata", "cassettes", name+".json")
+
+	mode := ModeFromEnv()
+	if opts != nil && opts.Mode != "" {
+		mode = opts.Mode
+	}
+	if _, err := os.Stat(path); mode == ModeReplay && errors.Is(err, fs.ErrNotExist) {
+		tb.Skipf("cassette %s is not recorded: record it with %s=%s against the model servers", path, ModeEnv, ModeRecord)
+	}
+
+	recorder, err := New(path, opts)
+	if err != nil {
+		tb.Fatal(cmp.Diff(err, nil))
+	}
+
+	tb.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			tb.Error(cmp.Diff(err, nil))
+		}
+	})
+
+	return recorder
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aria3ppp/rag-server/internal/pkg/test/cassette"
 	test_server "github.com/aria3ppp/rag-server/internal/pkg/test/server"
 	"github.com/google/go-cmp/cmp"
 	"github.com/qdrant/go-client/qdrant"
@@ -93,9 +94,12 @@ func _TestSetupEmbedderServer(t *testing.T) {
 	httpPort, cleanup := test_server.SetupEmbedderServer(t)
 	t.Cleanup(cleanup)
 
+	recorder := cassette.NewTest(t, t.Name(), nil)
+
 	llm, err := openai.New(
 		openai.WithBaseURL(fmt.Sprintf("http://localhost:%d/v1", httpPort)),
 		openai.WithToken("OPENAI_API_KEY"),
+		openai.WithHTTPClient(recorder.Client()),
 	)
 	if err != nil {
 		t.Fatal(cmp.Diff(err, nil))
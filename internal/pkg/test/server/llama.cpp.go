@@ -22,6 +22,14 @@ func SetupEmbedderServer(f Fatalizer) (httpPort int, cleanup func()) {
 	return setupLlamaCppServer(f, "embedder", []string{"-m", "/models/CompendiumLabs/bge-small-en-v1.5-gguf/bge-small-en-v1.5-f32.gguf", "--embedding"})
 }
 
+func SetupRerankerServer(f Fatalizer) (httpPort int, cleanup func()) {
+	return setupLlamaCppServer(f, "reranker", []string{"-m", "/models/BAAI/bge-reranker-base/bge-reranker-base-Q4_K_M.gguf", "--reranking"})
+}
+
+func SetupLLMServer(f Fatalizer) (httpPort int, cleanup func()) {
+	return setupLlamaCppServer(f, "llm", []string{"-m", "/models/hugging-quants/Llama-3.2-1B-Instruct-Q4_K_M-GGUF/llama-3.2-1b-instruct-q4_k_m.gguf", "--pooling", "cls"})
+}
+
 func setupLlamaCppServer(f Fatalizer, modelsType string, command []string) (httpPort int, cleanup func()) {
 	f.Helper()
 
@@ -80,12 +88,13 @@ func setupLlamaCppServer(f Fatalizer, modelsType string, command []string) (http
 	return httpPort, cleanup
 }
 
-func GetEmbedderEmbeddingSize(tb testing.TB, baseURL string) int {
+func GetEmbedderEmbeddingSize(tb testing.TB, baseURL string, httpClient *http.Client) int {
 	tb.Helper()
 
 	llm, err := openai.New(
 		openai.WithBaseURL(baseURL),
 		openai.WithToken("OPENAI_API_KEY"),
+		openai.WithHTTPClient(httpClient),
 	)
 	if err != nil {
 		tb.Fatal(cmp.Diff(err, nil))
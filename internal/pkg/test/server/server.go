@@ -2,6 +2,8 @@ package server
 
 import (
 	"sync"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/test/cassette"
 )
 
 type TestServerFunc func(Fatalizer) (port int, cleanup func())
@@ -37,3 +39,13 @@ func SetupServers(f Fatalizer, testcontainers map[string]TestServerFunc) (ports
 
 	return ports, cleanupFunc
 }
+
+// SetupModelServers sets up the model servers unless the cassettes are
+// replayed: the replayed tests need no servers and get zero ports.
+func SetupModelServers(f Fatalizer, testcontainers map[string]TestServerFunc) (ports map[string]int, cleanupFunc func()) {
+	if cassette.ModeFromEnv() == cassette.ModeReplay {
+		return map[string]int{}, func() {}
+	}
+
+	return SetupServers(f, testcontainers)
+}
@@ -0,0 +1,18 @@
+package tokenizer
+
+import "unicode/utf8"
+
+// EstimateTokens estimates the tokens of text from its characters: a token
+// per four ASCII characters, as the english text tokenizes, and per two other
+// characters, as the non latin scripts tokenize in much shorter tokens.
+func EstimateTokens(text string) int {
+	ascii := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] < utf8.RuneSelf {
+			ascii++
+		}
+	}
+	other := utf8.RuneCountInString(text) - ascii
+
+	return (ascii+3)/4 + (other+1)/2
+}
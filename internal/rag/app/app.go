@@ -12,10 +12,20 @@ import (
 	rag_grpc_server "github.com/aria3ppp/rag-server/internal/rag/app/grpc_server"
 	"github.com/aria3ppp/rag-server/internal/rag/config"
 
+	"github.com/aria3ppp/rag-server/internal/pkg/auth"
+	internal_config "github.com/aria3ppp/rag-server/internal/pkg/config"
+	"github.com/aria3ppp/rag-server/internal/pkg/pii"
+	"github.com/aria3ppp/rag-server/internal/pkg/ratelimit"
 	"github.com/aria3ppp/rag-server/internal/pkg/server"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/audit"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/bbolt"
 	"github.com/aria3ppp/rag-server/internal/rag/infras/clock"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/memory"
 	"github.com/aria3ppp/rag-server/internal/rag/infras/openai"
 	"github.com/aria3ppp/rag-server/internal/rag/infras/reranker"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/screening"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/tokenizer"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/uuid"
 	"github.com/aria3ppp/rag-server/internal/rag/infras/vectorstore"
 	"github.com/aria3ppp/rag-server/internal/rag/usecase"
 	template_app "github.com/aria3ppp/rag-server/pkg/app"
@@ -23,6 +33,7 @@ import (
 
 	grpc_gateway_runtime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -33,13 +44,27 @@ import (
 
 func New(
 	ctx context.Context,
-	config *config.Config,
+	reloadableConfig *internal_config.Reloadable[config.Config],
 	slogHandler slog.Handler,
 	tracer trace.Tracer,
+	meter metric.Meter,
 	httpClient *http.Client,
-) (*template_app.App, error) {
+) (_ *template_app.App, err error) {
 	logger := slog.New(slogHandler)
 
+	// cleanups release the resources in reverse order when the app shuts
+	// down, or right away when it fails to be created
+	var cleanups []func() error
+	defer func() {
+		if err != nil {
+			for i := len(cleanups) - 1; i >= 0; i-- {
+				cleanups[i]()
+			}
+		}
+	}()
+
+	config := reloadableConfig.Load()
+
 	vectorstore, err := vectorstore.NewVectorStore(
 		ctx,
 		config,
@@ -49,8 +74,7 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("failed to vectorstore.NewVectorStore: %w", err)
 	}
-	// TODO: provide a mechanism to pass a list of cleanups to app instance
-	// defer vectorstore.Close()
+	cleanups = append(cleanups, vectorstore.Close)
 
 	reranker, err := reranker.NewReranker(
 		ctx,
@@ -74,14 +98,112 @@ func New(
 		return nil, fmt.Errorf("failed to openai.NewLLM: %w", err)
 	}
 
+	feedbackStore, err := bbolt.NewFeedbackStore(
+		ctx,
+		config,
+		tracer,
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bbolt.NewFeedbackStore: %w", err)
+	}
+	cleanups = append(cleanups, feedbackStore.Close)
+
 	clock := clock.NewClock()
 
+	var auditLog usecase.AuditLog
+	if config.AuditConfig.Enabled {
+		auditWriter, err := audit.NewJSONLWriter(
+			ctx,
+			config,
+			clock,
+			tracer,
+			logger,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to audit.NewJSONLWriter: %w", err)
+		}
+
+		jsonlAuditLog, err := audit.NewAuditLog(
+			ctx,
+			config,
+			auditWriter,
+			tracer,
+			logger,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to audit.NewAuditLog: %w", err)
+		}
+		cleanups = append(cleanups, jsonlAuditLog.Close)
+
+		auditLog = jsonlAuditLog
+	}
+
+	idGenerator := uuid.NewIDGenerator()
+
+	var screener usecase.Screener
+	if config.ScreeningConfig.Enabled {
+		screener, err = screening.NewScreener(
+			ctx,
+			config,
+			llm,
+			tracer,
+			logger,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to screening.NewScreener: %w", err)
+		}
+	}
+
+	var redactor usecase.Redactor
+	if config.PIIConfig.Enabled {
+		redactor, err = pii.NewRedactor(
+			pii.Policy{
+				Strategy: config.PIIConfig.Strategy,
+				Types:    config.PIIConfig.Types,
+				Allow:    config.PIIConfig.Allow,
+			},
+			config.PIIConfig.Tenants,
+			config.PIIConfig.HashKey,
+			meter,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pii.NewRedactor: %w", err)
+		}
+	}
+
+	var historyTokenizer usecase.Tokenizer = tokenizer.NewHeuristicTokenizer()
+	if config.TokenizerConfig.BaseURL != "" {
+		historyTokenizer, err = tokenizer.NewLlamaCPPTokenizer(
+			ctx,
+			config,
+			tracer,
+			logger,
+			httpClient,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to tokenizer.NewLlamaCPPTokenizer: %w", err)
+		}
+	}
+
+	var summaryCache usecase.SummaryCache
+	if config.MemoryConfig.Enabled {
+		summaryCache = memory.NewSummaryCache(config)
+	}
+
 	useCase := usecase.NewUseCase(
 		vectorstore,
 		reranker,
 		llm,
 		clock,
-		config,
+		idGenerator,
+		feedbackStore,
+		auditLog,
+		screener,
+		redactor,
+		historyTokenizer,
+		summaryCache,
+		reloadableConfig,
 		tracer,
 		logger,
 	)
@@ -95,7 +217,27 @@ func New(
 	healthServer := health.NewServer()
 	// healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 
-	grpcServer := grpc.NewServer()
+	rateLimiter := ratelimit.New(func() (float64, int) {
+		rateLimitConfig := reloadableConfig.Load().RateLimitConfig
+		return rateLimitConfig.RequestsPerSecond, rateLimitConfig.Burst
+	})
+
+	authenticator := auth.NewAuthenticator(
+		func() []string {
+			return reloadableConfig.Load().AuthConfig.AdminAPIKeys
+		},
+		func() map[string]string {
+			return reloadableConfig.Load().AuthConfig.APIKeyTenants
+		},
+		func() []string {
+			return reloadableConfig.Load().AuthConfig.TrustedProxies
+		},
+	)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rateLimiter.UnaryServerInterceptor(), authenticator.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(rateLimiter.StreamServerInterceptor(), authenticator.StreamServerInterceptor()),
+	)
 
 	ragv1.RegisterRAGServiceServer(grpcServer, ragGRPCService)
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
@@ -153,5 +295,5 @@ func New(
 		httpServer,
 	)
 
-	return template_app.New(server.Start, logger), nil
+	return template_app.New(server.Start, logger, cleanups...), nil
 }
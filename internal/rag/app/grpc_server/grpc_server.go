@@ -2,9 +2,11 @@ package grpc_server
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	ragv1 "github.com/aria3ppp/rag-server/gen/go/rag/v1"
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
 	"github.com/aria3ppp/rag-server/internal/rag/domain"
 	"github.com/aria3ppp/rag-server/internal/rag/usecase"
 	"github.com/samber/lo"
@@ -12,6 +14,9 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	grpc_codes "google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type ragGRPCServer struct {
@@ -53,18 +58,37 @@ func (grpcServer *ragGRPCServer) Query(ctx context.Context, request *ragv1.RAGSe
 	})
 
 	input := &domain.QueryInput{
-		Query:    request.GetQuery(),
-		Messages: messages,
+		Query:          request.GetQuery(),
+		Messages:       messages,
+		ConversationID: request.GetConversationId(),
+		Explain:        request.GetExplain(),
 	}
 
 	result, err := grpcServer.uc.Query(ctx, input)
 	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase query", slog.String("error", err.Error()))
+		return nil, toGRPCStatusError(err)
+	}
+
+	queryTrace, err := queryTraceToProto(result.Trace)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to convert query trace", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	sources, err := sourcesToProto(result.Sources)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to convert sources", slog.String("error", err.Error()))
 		return nil, err
 	}
 
 	response := &ragv1.RAGServiceQueryResponse{
 		Content:     result.Content,
 		CreatedInMs: result.CreatedInMS,
+		Trace:       queryTrace,
+		ResponseId:  result.ResponseID,
+		Sources:     sources,
+		HistoryTrim: historyTrimToProto(result.HistoryTrim),
 	}
 
 	return response, nil
@@ -90,8 +114,10 @@ func (grpcServer *ragGRPCServer) QueryStream(request *ragv1.RAGServiceQueryStrea
 	})
 
 	input := &domain.QueryStreamInput{
-		Query:    request.GetQuery(),
-		Messages: messages,
+		Query:          request.GetQuery(),
+		Messages:       messages,
+		ConversationID: request.GetConversationId(),
+		Explain:        request.GetExplain(),
 	}
 
 	grpcServer.uc.QueryStream(ctx, input, func(event *domain.QueryStreamResultEvent) (continueRunning bool) {
@@ -102,11 +128,27 @@ func (grpcServer *ragGRPCServer) QueryStream(request *ragv1.RAGServiceQueryStrea
 			responseError = err.Error()
 		}
 
+		queryTrace, traceErr := queryTraceToProto(event.Trace)
+		if traceErr != nil {
+			grpcServer.logger.ErrorContext(ctx, "failed to convert query trace", slog.String("error", traceErr.Error()))
+			return false
+		}
+
+		sources, sourcesErr := sourcesToProto(event.Sources)
+		if sourcesErr != nil {
+			grpcServer.logger.ErrorContext(ctx, "failed to convert sources", slog.String("error", sourcesErr.Error()))
+			return false
+		}
+
 		item := &ragv1.RAGServiceQueryStreamResponse{
 			Content:     event.Content,
 			CreatedAtMs: event.CreatedAtMS,
 			StopReason:  ragv1.StopReason(event.StopReason),
 			Error:       responseError,
+			Trace:       queryTrace,
+			ResponseId:  event.ResponseID,
+			Sources:     sources,
+			HistoryTrim: historyTrimToProto(event.HistoryTrim),
 		}
 
 		if err = stream.Send(item); err != nil {
@@ -118,3 +160,252 @@ func (grpcServer *ragGRPCServer) QueryStream(request *ragv1.RAGServiceQueryStrea
 
 	return nil
 }
+
+func (grpcServer *ragGRPCServer) SubmitFeedback(ctx context.Context, request *ragv1.RAGServiceSubmitFeedbackRequest) (_ *ragv1.RAGServiceSubmitFeedbackResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.SubmitFeedback")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	input := &domain.SubmitFeedbackInput{
+		ResponseID: request.GetResponseId(),
+		Rating:     int(request.GetRating()),
+		Comment:    request.GetComment(),
+		FlaggedSources: lo.Map(request.GetFlaggedSources(), func(index int32, _ int) int {
+			return int(index)
+		}),
+	}
+
+	if err = grpcServer.uc.SubmitFeedback(ctx, input); err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase submit feedback", slog.String("error", err.Error()))
+		return nil, toGRPCStatusError(err)
+	}
+
+	return &ragv1.RAGServiceSubmitFeedbackResponse{}, nil
+}
+
+func (grpcServer *ragGRPCServer) ExportAuditRecords(request *ragv1.RAGServiceExportAuditRecordsRequest, stream grpc.ServerStreamingServer[ragv1.RAGServiceExportAuditRecordsResponse]) (err error) {
+	ctx := stream.Context()
+
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.ExportAuditRecords")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	input := &domain.ExportAuditRecordsInput{
+		FromMS: request.GetFromMs(),
+		ToMS:   request.GetToMs(),
+	}
+
+	var sendErr error
+	err = grpcServer.uc.ExportAuditRecords(ctx, input, func(record *domain.AuditRecord) (continueRunning bool) {
+		var item *ragv1.AuditRecord
+		if item, sendErr = auditRecordToProto(record); sendErr != nil {
+			return false
+		}
+
+		sendErr = stream.Send(&ragv1.RAGServiceExportAuditRecordsResponse{Record: item})
+		return sendErr == nil
+	})
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase export audit records", slog.String("error", err.Error()))
+		return toGRPCStatusError(err)
+	}
+	if sendErr != nil {
+		err = sendErr
+		grpcServer.logger.ErrorContext(ctx, "failed to send audit record", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func toGRPCStatusError(err error) error {
+	switch err.(type) {
+	case *internal_error.ValidationError:
+		return grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+	case *internal_error.PermissionDeniedError:
+		return grpc_status.New(grpc_codes.PermissionDenied, err.Error()).Err()
+	case *internal_error.NotFoundError:
+		return grpc_status.New(grpc_codes.NotFound, err.Error()).Err()
+	default:
+		return err
+	}
+}
+
+func messagesToProto(messages []*domain.Message) []*ragv1.Message {
+	return lo.Map(messages, func(m *domain.Message, _ int) *ragv1.Message {
+		return &ragv1.Message{
+			Role:    ragv1.Role(m.Role),
+			Content: m.Content,
+		}
+	})
+}
+
+func sourcesToProto(sources []*domain.Source) ([]*ragv1.Source, error) {
+	result := make([]*ragv1.Source, 0, len(sources))
+	for _, source := range sources {
+		metadata, err := structpb.NewStruct(source.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to structpb new struct: %w", err)
+		}
+		result = append(result, &ragv1.Source{
+			Text:        source.Text,
+			Score:       source.Score,
+			Metadata:    metadata,
+			Quarantined: source.Quarantined,
+		})
+	}
+	return result, nil
+}
+
+func historyTrimToProto(historyTrim *domain.HistoryTrim) *ragv1.HistoryTrim {
+	if historyTrim == nil {
+		return nil
+	}
+
+	return &ragv1.HistoryTrim{
+		BudgetTokens:    int32(historyTrim.BudgetTokens),
+		HistoryTokens:   int32(historyTrim.HistoryTokens),
+		DroppedMessages: int32(historyTrim.DroppedMessages),
+		Summarized:      historyTrim.Summarized,
+	}
+}
+
+func queryTraceMemoryToProto(memory *domain.QueryTraceMemory) *ragv1.QueryTraceMemory {
+	if memory == nil {
+		return nil
+	}
+
+	return &ragv1.QueryTraceMemory{
+		SummarizedMessages: int32(memory.SummarizedMessages),
+		Summary:            memory.Summary,
+		Cached:             memory.Cached,
+	}
+}
+
+func queryTraceToProto(queryTrace *domain.QueryTrace) (*ragv1.QueryTrace, error) {
+	if queryTrace == nil {
+		return nil, nil
+	}
+
+	result := &ragv1.QueryTrace{
+		SelectedContext: queryTrace.SelectedContext,
+		Messages:        messagesToProto(queryTrace.Messages),
+		History:         historyTrimToProto(queryTrace.History),
+		Memory:          queryTraceMemoryToProto(queryTrace.Memory),
+		Expansion: lo.Map(queryTrace.Expansion, func(expansion *domain.QueryTraceExpansion, _ int) *ragv1.QueryTraceExpansion {
+			return &ragv1.QueryTraceExpansion{
+				Chunks: expansion.Chunks,
+				Tokens: int32(expansion.Tokens),
+			}
+		}),
+		Timings: &ragv1.QueryTraceTimings{
+			RetrievalMs:  queryTrace.Timings.RetrievalMS,
+			RerankMs:     queryTrace.Timings.RerankMS,
+			GenerationMs: queryTrace.Timings.GenerationMS,
+			TotalMs:      queryTrace.Timings.TotalMS,
+		},
+	}
+
+	if queryTrace.Retrieval != nil {
+		documents := make([]*ragv1.QueryTraceRetrievalDocument, 0, len(queryTrace.Retrieval.Results))
+		for _, r := range queryTrace.Retrieval.Results {
+			metadata, err := structpb.NewStruct(r.Metadata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to structpb new struct: %w", err)
+			}
+			documents = append(documents, &ragv1.QueryTraceRetrievalDocument{
+				Text:     r.Text,
+				Score:    r.Score,
+				Metadata: metadata,
+			})
+		}
+
+		result.Retrieval = &ragv1.QueryTraceRetrieval{
+			Query:     queryTrace.Retrieval.Input.Text,
+			TopK:      int32(queryTrace.Retrieval.Input.TopK),
+			MinScore:  queryTrace.Retrieval.Input.MinScore,
+			Documents: documents,
+			Mode:      queryTrace.Retrieval.Input.Mode,
+			Fusion:    queryTrace.Retrieval.Input.Fusion,
+		}
+		if mmr := queryTrace.Retrieval.Input.MMR; mmr != nil {
+			result.Retrieval.MmrFetchK = int32(mmr.FetchK)
+			result.Retrieval.MmrLambda = mmr.Lambda
+		}
+		if federation := queryTrace.Retrieval.Input.Federation; federation != nil {
+			result.Retrieval.CollectionFusion = federation.Fusion
+			for _, collection := range federation.Collections {
+				result.Retrieval.Collections = append(result.Retrieval.Collections, &ragv1.QueryTraceRetrievalCollection{
+					Name:   collection.Name,
+					Weight: collection.Weight,
+				})
+			}
+		}
+	}
+
+	for _, s := range queryTrace.Screening {
+		screening := &ragv1.QueryTraceScreening{Text: s.Text}
+		if s.Screening != nil {
+			screening.Score = s.Screening.Score
+			screening.Rules = s.Screening.Rules
+			screening.ClassifierScore = s.Screening.ClassifierScore
+			screening.Action = ragv1.ScreeningAction(s.Screening.Action)
+		}
+		result.Screening = append(result.Screening, screening)
+	}
+
+	if queryTrace.Rerank != nil {
+		result.Rerank = &ragv1.QueryTraceRerank{
+			TopN: int32(queryTrace.Rerank.Input.TopN),
+			Documents: lo.Map(queryTrace.Rerank.Results, func(r *domain.RerankerRerankResult, _ int) *ragv1.QueryTraceRerankDocument {
+				return &ragv1.QueryTraceRerankDocument{
+					Index: int32(r.Index),
+					Text:  r.Document,
+					Score: r.Score,
+				}
+			}),
+		}
+	}
+
+	return result, nil
+}
+
+func auditRecordToProto(record *domain.AuditRecord) (*ragv1.AuditRecord, error) {
+	result := &ragv1.AuditRecord{
+		ResponseId:    record.ResponseID,
+		CreatedAtMs:   record.CreatedAtMS,
+		CallerId:      record.CallerID,
+		CallerAddress: record.CallerAddress,
+		Query:         record.Query,
+		SourceIds:     record.SourceIDs,
+		Answer:        record.Answer,
+		StopReason:    ragv1.StopReason(record.StopReason),
+		Error:         record.Error,
+		Timings: &ragv1.QueryTraceTimings{
+			RetrievalMs:  record.Timings.RetrievalMS,
+			RerankMs:     record.Timings.RerankMS,
+			GenerationMs: record.Timings.GenerationMS,
+			TotalMs:      record.Timings.TotalMS,
+		},
+	}
+
+	if len(record.Filter) > 0 {
+		filter, err := structpb.NewStruct(record.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to structpb new struct: %w", err)
+		}
+		result.Filter = filter
+	}
+
+	return result, nil
+}
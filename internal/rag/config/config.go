@@ -1,40 +1,209 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/pii"
+)
 
 type Config struct {
-	ServerConfig      ServerConfig
-	OpenAIConfig      OpenAIConfig
-	RerankerConfig    RerankerConfig
-	VectorStoreConfig VectorStoreConfig
+	ServerConfig      ServerConfig      `yaml:"server" toml:"server"`
+	LogConfig         LogConfig         `yaml:"log" toml:"log" reload:"true"`
+	RateLimitConfig   RateLimitConfig   `yaml:"rate_limit" toml:"rate_limit" reload:"true"`
+	AuthConfig        AuthConfig        `yaml:"auth" toml:"auth" reload:"true"`
+	OpenAIConfig      OpenAIConfig      `yaml:"openai" toml:"openai"`
+	RerankerConfig    RerankerConfig    `yaml:"reranker" toml:"reranker"`
+	VectorStoreConfig VectorStoreConfig `yaml:"vectorstore" toml:"vectorstore"`
+	RetrievalConfig   RetrievalConfig   `yaml:"retrieval" toml:"retrieval" reload:"true"`
+	ExpansionConfig   ExpansionConfig   `yaml:"expansion" toml:"expansion" reload:"true"`
+	PromptConfig      PromptConfig      `yaml:"prompt" toml:"prompt" reload:"true"`
+	HistoryConfig     HistoryConfig     `yaml:"history" toml:"history" reload:"true"`
+	TokenizerConfig   TokenizerConfig   `yaml:"tokenizer" toml:"tokenizer"`
+	MemoryConfig      MemoryConfig      `yaml:"memory" toml:"memory"`
+	FeedbackConfig    FeedbackConfig    `yaml:"feedback" toml:"feedback"`
+	AuditConfig       AuditConfig       `yaml:"audit" toml:"audit"`
+	ScreeningConfig   ScreeningConfig   `yaml:"screening" toml:"screening"`
+	PIIConfig         PIIConfig         `yaml:"pii" toml:"pii"`
 }
 
 type ServerConfig struct {
-	GRPCConfig              GRPCConfig
-	GatewayConfig           GatewayConfig
-	GracefulShutdownTimeout time.Duration `env:"RAG_SERVER_GRACEFUL_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	GRPCConfig              GRPCConfig    `yaml:"grpc" toml:"grpc"`
+	GatewayConfig           GatewayConfig `yaml:"gateway" toml:"gateway"`
+	GracefulShutdownTimeout time.Duration `env:"RAG_SERVER_GRACEFUL_SHUTDOWN_TIMEOUT" envDefault:"30s" yaml:"graceful_shutdown_timeout" toml:"graceful_shutdown_timeout"`
 }
 
 type GRPCConfig struct {
-	Port uint16 `env:"RAG_SERVER_GRPC_PORT" envDefault:"9001"`
+	Port uint16 `env:"RAG_SERVER_GRPC_PORT" envDefault:"9001" yaml:"port" toml:"port"`
 }
 
 type GatewayConfig struct {
-	Port           uint16   `env:"RAG_SERVER_GATEWAY_PORT" envDefault:"8000"`
-	AllowedOrigins []string `env:"RAG_SERVER_GATEWAY_ALLOWED_ORIGINS"`
+	Port           uint16   `env:"RAG_SERVER_GATEWAY_PORT" envDefault:"8000" yaml:"port" toml:"port"`
+	AllowedOrigins []string `env:"RAG_SERVER_GATEWAY_ALLOWED_ORIGINS" yaml:"allowed_origins" toml:"allowed_origins"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error. Empty uses the build profile default.
+	Level string `env:"RAG_LOG_LEVEL" yaml:"level" toml:"level" validate:"omitempty,oneof=debug info warn error"`
+}
+
+type RateLimitConfig struct {
+	// RequestsPerSecond limits the requests served by the grpc server (and so the gateway). Zero disables it.
+	RequestsPerSecond float64 `env:"RAG_RATE_LIMIT_REQUESTS_PER_SECOND" yaml:"requests_per_second" toml:"requests_per_second" validate:"min=0"`
+	Burst             int     `env:"RAG_RATE_LIMIT_BURST" envDefault:"1" yaml:"burst" toml:"burst" validate:"min=1"`
+}
+
+type AuthConfig struct {
+	// AdminAPIKeys are the bearer tokens granting the admin scope (e.g. to explain queries).
+	AdminAPIKeys []string `env:"RAG_AUTH_ADMIN_API_KEYS" yaml:"admin_api_keys" toml:"admin_api_keys" secret:"true"`
+	// APIKeyTenants are the tenants, e.g. selecting the pii policies, of the callers by their bearer tokens.
+	APIKeyTenants map[string]string `env:"RAG_AUTH_API_KEY_TENANTS" yaml:"api_key_tenants" toml:"api_key_tenants" secret:"true"`
+	// TrustedProxies are the CIDRs of the proxies whose x-forwarded-for client address is trusted, by default the gateway calling from the loopback.
+	TrustedProxies []string `env:"RAG_AUTH_TRUSTED_PROXIES" envDefault:"127.0.0.1/32,::1/128" yaml:"trusted_proxies" toml:"trusted_proxies" validate:"dive,cidr"`
 }
 
 type OpenAIConfig struct {
-	BaseURL string `env:"OPENAI_BASEURL,notEmpty"`
-	APIKey  string `env:"OPENAI_APIKEY,notEmpty"`
-	Model   string `env:"OPENAI_MODEL,notEmpty"`
+	BaseURL string `env:"OPENAI_BASEURL,notEmpty" yaml:"base_url" toml:"base_url"`
+	APIKey  string `env:"OPENAI_APIKEY,notEmpty" yaml:"api_key" toml:"api_key" secret:"true"`
+	Model   string `env:"OPENAI_MODEL,notEmpty" yaml:"model" toml:"model"`
 }
 
 type RerankerConfig struct {
-	BaseURL string `env:"RERANKER_BASEURL,notEmpty"`
+	BaseURL string `env:"RERANKER_BASEURL,notEmpty" yaml:"base_url" toml:"base_url"`
 }
 
 type VectorStoreConfig struct {
-	Host     string `env:"VECTORSTORE_HOST,notEmpty"`
-	GRPCPort uint16 `env:"VECTORSTORE_SERVER_GRPC_PORT,notEmpty"`
+	Host     string `env:"VECTORSTORE_HOST,notEmpty" yaml:"host" toml:"host"`
+	GRPCPort uint16 `env:"VECTORSTORE_SERVER_GRPC_PORT,notEmpty" yaml:"grpc_port" toml:"grpc_port"`
+}
+
+type RetrievalConfig struct {
+	TopK       int     `env:"RAG_RETRIEVAL_TOP_K" envDefault:"5" yaml:"top_k" toml:"top_k" validate:"min=1,max=100"`
+	MinScore   float32 `env:"RAG_RETRIEVAL_MIN_SCORE" envDefault:"0.4" yaml:"min_score" toml:"min_score"`
+	RerankTopN int     `env:"RAG_RETRIEVAL_RERANK_TOP_N" envDefault:"1" yaml:"rerank_top_n" toml:"rerank_top_n" validate:"min=1,max=100"`
+	// MMRFetchK diversifies the top k by maximal marginal relevance among the MMRFetchK most similar texts when not 0.
+	MMRFetchK int `env:"RAG_RETRIEVAL_MMR_FETCH_K" yaml:"mmr_fetch_k" toml:"mmr_fetch_k" validate:"omitempty,gtefield=TopK,max=1000"`
+	// MMRLambda trades off the similarity to the query (1) against the diversity of the results (0).
+	MMRLambda float32 `env:"RAG_RETRIEVAL_MMR_LAMBDA" envDefault:"0.5" yaml:"mmr_lambda" toml:"mmr_lambda" validate:"min=0,max=1"`
+	// Mode searches the embeddings (dense), the bm25 terms (sparse) or both (hybrid). MinScore only applies to the dense search.
+	Mode string `env:"RAG_RETRIEVAL_MODE" envDefault:"dense" yaml:"mode" toml:"mode" validate:"oneof=dense sparse hybrid"`
+	// Fusion combines the hybrid results by reciprocal rank (rrf) or by distribution based score (dbsf).
+	Fusion string `env:"RAG_RETRIEVAL_FUSION" envDefault:"rrf" yaml:"fusion" toml:"fusion" validate:"oneof=rrf dbsf"`
+	// Collections are the vectorstore collections searched together, only the default collection when empty.
+	Collections []string `env:"RAG_RETRIEVAL_COLLECTIONS" yaml:"collections" toml:"collections" validate:"max=10,unique"`
+	// CollectionWeights scale the scores of the collections results, 1 when missing. A collection
+	// not to search is left out of the collections rather than weighted 0.
+	CollectionWeights map[string]float32 `env:"RAG_RETRIEVAL_COLLECTION_WEIGHTS" yaml:"collection_weights" toml:"collection_weights" validate:"dive,gt=0"`
+	// CollectionFusion combines the collections results by their min-max normalized scores (weighted) or by reciprocal rank (rrf).
+	CollectionFusion string `env:"RAG_RETRIEVAL_COLLECTION_FUSION" envDefault:"weighted" yaml:"collection_fusion" toml:"collection_fusion" validate:"oneof=weighted rrf"`
+}
+
+type ExpansionConfig struct {
+	// Mode expands the selected chunks with their neighbor chunks, with their whole parent document or not at all.
+	Mode string `env:"RAG_EXPANSION_MODE" envDefault:"none" yaml:"mode" toml:"mode" validate:"oneof=none neighbors parent"`
+	// Neighbors is the number of chunks fetched on each side of a selected chunk in the neighbors mode.
+	Neighbors int `env:"RAG_EXPANSION_NEIGHBORS" envDefault:"1" yaml:"neighbors" toml:"neighbors" validate:"min=1"`
+	// MaxDocumentChunks bounds the chunks fetched per document in the parent mode.
+	MaxDocumentChunks int `env:"RAG_EXPANSION_MAX_DOCUMENT_CHUNKS" envDefault:"100" yaml:"max_document_chunks" toml:"max_document_chunks" validate:"min=1,max=1000"`
+	// BudgetTokens bounds the tokens of all the expanded chunks, the selected chunks included.
+	BudgetTokens int `env:"RAG_EXPANSION_BUDGET_TOKENS" envDefault:"1024" yaml:"budget_tokens" toml:"budget_tokens" validate:"min=1"`
+	// DocumentFields are the metadata fields identifying the document of a chunk,
+	// the document id the vectorstore stores with the chunks it inserts by default.
+	DocumentFields []string `env:"RAG_EXPANSION_DOCUMENT_FIELDS" envDefault:"document_id" yaml:"document_fields" toml:"document_fields" validate:"min=1"`
+	// ChunkField is the metadata field numbering the chunks of a document, the
+	// chunk index the vectorstore stores with the chunks it inserts by default.
+	ChunkField string `env:"RAG_EXPANSION_CHUNK_FIELD" envDefault:"chunk_index" yaml:"chunk_field" toml:"chunk_field" validate:"required"`
+}
+
+type PromptConfig struct {
+	// SystemPrompt is prepended to the chat as a system message when not empty.
+	SystemPrompt string `env:"RAG_PROMPT_SYSTEM" yaml:"system" toml:"system"`
+	// ContextSeparator joins the retrieved documents in the context message.
+	ContextSeparator string `env:"RAG_PROMPT_CONTEXT_SEPARATOR" envDefault:"\n\n" yaml:"context_separator" toml:"context_separator"`
+}
+
+type HistoryConfig struct {
+	// ContextWindow is the model context size in tokens the chat must fit in.
+	ContextWindow int `env:"RAG_HISTORY_CONTEXT_WINDOW" envDefault:"4096" yaml:"context_window" toml:"context_window" validate:"min=1"`
+	// AnswerTokens are reserved in the context window for the answer.
+	AnswerTokens int `env:"RAG_HISTORY_ANSWER_TOKENS" envDefault:"512" yaml:"answer_tokens" toml:"answer_tokens" validate:"min=0"`
+	// MessageOverheadTokens are counted for the chat template markup of every message.
+	MessageOverheadTokens int `env:"RAG_HISTORY_MESSAGE_OVERHEAD_TOKENS" envDefault:"4" yaml:"message_overhead_tokens" toml:"message_overhead_tokens" validate:"min=0"`
+	// Overflow is done with the oldest messages not fitting the context window: drop or summarize.
+	Overflow string `env:"RAG_HISTORY_OVERFLOW" envDefault:"drop" yaml:"overflow" toml:"overflow" validate:"oneof=drop summarize"`
+	// SummaryTokens are reserved for the summary of the dropped messages when they are summarized.
+	SummaryTokens int `env:"RAG_HISTORY_SUMMARY_TOKENS" envDefault:"256" yaml:"summary_tokens" toml:"summary_tokens" validate:"min=1"`
+}
+
+type TokenizerConfig struct {
+	// BaseURL is the llama.cpp server counting the tokens with the model
+	// tokenizer. The tokens are estimated from the characters when empty.
+	BaseURL string `env:"TOKENIZER_BASEURL" yaml:"base_url" toml:"base_url"`
+}
+
+type MemoryConfig struct {
+	// Enabled summarizes the oldest messages of the histories longer than the threshold into a system note.
+	Enabled bool `env:"RAG_MEMORY_ENABLED" yaml:"enabled" toml:"enabled"`
+	// ThresholdMessages is the history length from which it is summarized.
+	ThresholdMessages int `env:"RAG_MEMORY_THRESHOLD_MESSAGES" envDefault:"8" yaml:"threshold_messages" toml:"threshold_messages" validate:"min=1"`
+	// RecentMessages are the newest messages kept after the summary note.
+	RecentMessages int `env:"RAG_MEMORY_RECENT_MESSAGES" envDefault:"4" yaml:"recent_messages" toml:"recent_messages" validate:"min=0,ltfield=ThresholdMessages"`
+	// SummaryWords bounds the summary length.
+	SummaryWords int `env:"RAG_MEMORY_SUMMARY_WORDS" envDefault:"150" yaml:"summary_words" toml:"summary_words" validate:"min=1"`
+	// CacheSize is the number of conversations whose summary is cached.
+	CacheSize int `env:"RAG_MEMORY_CACHE_SIZE" envDefault:"1000" yaml:"cache_size" toml:"cache_size" validate:"min=1"`
+	// CondenseQuery asks the llm to rewrite the follow up queries into standalone questions for the retrieval.
+	CondenseQuery bool `env:"RAG_MEMORY_CONDENSE_QUERY" yaml:"condense_query" toml:"condense_query"`
+}
+
+type FeedbackConfig struct {
+	// StorePath is the bbolt file recording the responses and their feedback.
+	StorePath string `env:"RAG_FEEDBACK_STORE_PATH" envDefault:"feedback.db" yaml:"store_path" toml:"store_path" validate:"required"`
+	// ResponseRetention is how long a response can receive feedback (zero keeps them forever). Feedback is kept forever.
+	ResponseRetention time.Duration `env:"RAG_FEEDBACK_RESPONSE_RETENTION" envDefault:"720h" yaml:"response_retention" toml:"response_retention" validate:"min=0"`
+	// BufferSize is the number of responses waiting to be written in the background before they are written by the query itself.
+	BufferSize int `env:"RAG_FEEDBACK_BUFFER_SIZE" envDefault:"1024" yaml:"buffer_size" toml:"buffer_size" validate:"min=1"`
+}
+
+type AuditConfig struct {
+	Enabled bool `env:"RAG_AUDIT_ENABLED" envDefault:"true" yaml:"enabled" toml:"enabled"`
+	// Dir holds the audit-<unix ms>.jsonl files.
+	Dir string `env:"RAG_AUDIT_DIR" envDefault:"audit" yaml:"dir" toml:"dir" validate:"required_if=Enabled true"`
+	// BufferSize is the number of records waiting to be written before new records are dropped.
+	BufferSize int `env:"RAG_AUDIT_BUFFER_SIZE" envDefault:"1024" yaml:"buffer_size" toml:"buffer_size" validate:"min=1"`
+	// MaxFileSizeMB rotates the current file once it grows past it.
+	MaxFileSizeMB int `env:"RAG_AUDIT_MAX_FILE_SIZE_MB" envDefault:"100" yaml:"max_file_size_mb" toml:"max_file_size_mb" validate:"min=1"`
+	// Retention deletes the files whose records are all older than it (zero keeps them forever).
+	Retention time.Duration `env:"RAG_AUDIT_RETENTION" envDefault:"2160h" yaml:"retention" toml:"retention" validate:"min=0"`
+	// RedactFields are the record fields replaced with a redaction mark: query, answer, filter and caller_address.
+	RedactFields []string `env:"RAG_AUDIT_REDACT_FIELDS" yaml:"redact_fields" toml:"redact_fields" validate:"dive,oneof=query answer filter caller_address"`
+	// RedactPatterns are regular expressions whose matches are redacted from the query and the answer.
+	RedactPatterns []string `env:"RAG_AUDIT_REDACT_PATTERNS" yaml:"redact_patterns" toml:"redact_patterns"`
+	// SourceIDField is the source metadata field recorded as the source id.
+	SourceIDField string `env:"RAG_AUDIT_SOURCE_ID_FIELD" envDefault:"source_id" yaml:"source_id_field" toml:"source_id_field"`
+}
+
+type ScreeningConfig struct {
+	// Enabled screens the reranked passages for prompt injections before they are given to the llm.
+	Enabled bool `env:"RAG_SCREENING_ENABLED" envDefault:"true" yaml:"enabled" toml:"enabled"`
+	// Action is done with the passages scoring at least the threshold: wrap, quarantine or drop.
+	Action    string  `env:"RAG_SCREENING_ACTION" envDefault:"wrap" yaml:"action" toml:"action" validate:"oneof=wrap quarantine drop"`
+	Threshold float32 `env:"RAG_SCREENING_THRESHOLD" envDefault:"0.5" yaml:"threshold" toml:"threshold" validate:"gt=0,lte=1"`
+	// Patterns are regular expressions scoring as injections on top of the default rules.
+	Patterns []string `env:"RAG_SCREENING_PATTERNS" yaml:"patterns" toml:"patterns"`
+	// Classifier asks the llm to score the passages not flagged by the rules. It costs a completion per passage.
+	Classifier bool `env:"RAG_SCREENING_CLASSIFIER" yaml:"classifier" toml:"classifier"`
+}
+
+type PIIConfig struct {
+	// Enabled redacts the personal data in the queries and the chat history before the retrieval and the generation.
+	Enabled bool `env:"RAG_PII_ENABLED" yaml:"enabled" toml:"enabled"`
+	// Strategy replaces the personal data with its type (mask), its type and a keyed hash (hash) or nothing (drop).
+	Strategy string `env:"RAG_PII_STRATEGY" envDefault:"mask" yaml:"strategy" toml:"strategy" validate:"oneof=mask hash drop"`
+	// Types are the redacted personal data types, all of them when empty.
+	Types []string `env:"RAG_PII_TYPES" yaml:"types" toml:"types" validate:"dive,oneof=email phone card iban us_ssn ir_national_id"`
+	// Allow are regular expressions matching the whole values that are kept.
+	Allow   []string `env:"RAG_PII_ALLOW" yaml:"allow" toml:"allow"`
+	HashKey string   `env:"RAG_PII_HASH_KEY" yaml:"hash_key" toml:"hash_key" secret:"true"`
+	// Tenants are the policies of the tenants, by the tenant of the caller api key, overriding the fields they set.
+	Tenants map[string]pii.Policy `yaml:"tenants" toml:"tenants" validate:"dive"`
 }
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+
+	validatorPkg "github.com/go-playground/validator/v10"
+)
+
+// AuditRecord is who asked what, what was retrieved and what was answered.
+type AuditRecord struct {
+	ResponseID    string
+	CreatedAtMS   int64
+	CallerID      string
+	CallerAddress string
+	Query         string
+	Filter        map[string]any
+	SourceIDs     []string
+	Answer        string
+	StopReason    StopReason
+	// Error is the message of the error that stopped the query.
+	Error   string
+	Timings QueryTraceTimings
+}
+
+type ExportAuditRecordsInput struct {
+	FromMS int64 `validate:"min=0"`
+	// ToMS is exclusive. Zero exports up to now.
+	ToMS int64 `validate:"min=0"`
+}
+
+func (input *ExportAuditRecordsInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+	if input.ToMS != 0 && input.ToMS <= input.FromMS {
+		return internal_error.NewValidationError(errors.New("to must be after from"))
+	}
+	return nil
+}
@@ -25,6 +25,8 @@ const (
 	StopReasonUnspecified StopReason = iota
 	StopReasonDone
 	StopReasonError
+	// StopReasonCancelled is only recorded in the audit log, when the client cancelled the query.
+	StopReasonCancelled
 )
 
 type Message struct {
@@ -35,6 +37,9 @@ type Message struct {
 type QueryInput struct {
 	Query    string     `validate:"required,min=2,max=2000"`
 	Messages []*Message `validate:"-"`
+	Explain  bool       `validate:"-"`
+	// ConversationID keys the cached summary of the conversation history.
+	ConversationID string `validate:"omitempty,max=128"`
 }
 
 func (input *QueryInput) Validate(ctx context.Context) error {
@@ -48,13 +53,21 @@ func (input *QueryInput) Validate(ctx context.Context) error {
 }
 
 type QueryResult struct {
+	ResponseID  string
 	Content     string
 	CreatedInMS int64
+	Sources     []*Source
+	Trace       *QueryTrace
+	// HistoryTrim is nil when the whole history fits the context window.
+	HistoryTrim *HistoryTrim
 }
 
 type QueryStreamInput struct {
 	Query    string     `validate:"required,min=2,max=2000"`
 	Messages []*Message `validate:"-"`
+	Explain  bool       `validate:"-"`
+	// ConversationID keys the cached summary of the conversation history.
+	ConversationID string `validate:"omitempty,max=128"`
 }
 
 func (input *QueryStreamInput) Validate(ctx context.Context) error {
@@ -68,8 +81,16 @@ func (input *QueryStreamInput) Validate(ctx context.Context) error {
 }
 
 type QueryStreamResultEvent struct {
+	// ResponseID identifies the answer, e.g. to submit feedback on it. It is set on every event.
+	ResponseID  string
 	Content     string
 	CreatedAtMS int64
 	StopReason  StopReason
 	Error       error
+	// Sources is only set on the done event.
+	Sources []*Source
+	// HistoryTrim is only set on the done event, when the history was trimmed.
+	HistoryTrim *HistoryTrim
+	// Trace is only set on the debug event sent after the last event of an explained query.
+	Trace *QueryTrace
 }
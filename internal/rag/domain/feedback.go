@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+
+	validatorPkg "github.com/go-playground/validator/v10"
+)
+
+// Source is a retrieved document given to the llm as context.
+type Source struct {
+	Text     string
+	Score    float32
+	Metadata map[string]any
+	// Quarantined sources were screened as prompt injections and kept out of the context.
+	Quarantined bool
+}
+
+// Response is an answered query as recorded for feedback.
+type Response struct {
+	ID          string
+	Query       string
+	Messages    []*Message
+	Sources     []*Source
+	Answer      string
+	CreatedAtMS int64
+}
+
+type SubmitFeedbackInput struct {
+	ResponseID string `validate:"required,uuid"`
+	// Rating is from 1 (bad) to 5 (good).
+	Rating  int    `validate:"min=1,max=5"`
+	Comment string `validate:"max=4000"`
+	// FlaggedSources are the indexes of the response sources the user flagged as wrong or irrelevant.
+	FlaggedSources []int `validate:"max=100,dive,min=0"`
+}
+
+func (input *SubmitFeedbackInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+type Feedback struct {
+	ResponseID     string
+	Rating         int
+	Comment        string
+	FlaggedSources []int
+	CreatedAtMS    int64
+}
+
+// FeedbackRecord is a feedback with the response it rates.
+type FeedbackRecord struct {
+	Response *Response
+	Feedback *Feedback
+}
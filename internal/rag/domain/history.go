@@ -0,0 +1,14 @@
+package domain
+
+// HistoryTrim reports the oldest chat history messages left out of the chat
+// so it fits the model context window.
+type HistoryTrim struct {
+	// BudgetTokens is what the context window leaves to the history after the
+	// system prompt, the retrieved context, the query and the answer.
+	BudgetTokens int
+	// HistoryTokens is the size of the untrimmed history.
+	HistoryTokens   int
+	DroppedMessages int
+	// Summarized is set when the dropped messages were replaced by a summary note.
+	Summarized bool
+}
@@ -0,0 +1,10 @@
+package domain
+
+// ConversationSummary is the rolling summary of the oldest messages of a conversation.
+type ConversationSummary struct {
+	// Messages is the number of summarized messages, from the first one.
+	Messages int
+	// Digest identifies the summarized messages so an edited history is summarized again.
+	Digest  string
+	Summary string
+}
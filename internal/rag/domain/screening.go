@@ -0,0 +1,26 @@
+package domain
+
+// ScreeningAction is what is done with a passage before it is given to the llm.
+type ScreeningAction int8
+
+const (
+	// ScreeningActionNone passes the passage as is.
+	ScreeningActionNone ScreeningAction = iota
+	// ScreeningActionWrap passes the passage between delimiters marking it as untrusted data.
+	ScreeningActionWrap
+	// ScreeningActionQuarantine keeps the passage out of the context but returns it in the sources.
+	ScreeningActionQuarantine
+	// ScreeningActionDrop removes the passage from the context and the sources.
+	ScreeningActionDrop
+)
+
+// PassageScreening is the prompt injection screening decision of a passage.
+type PassageScreening struct {
+	// Score is from 0 (benign) to 1 (injection).
+	Score float32
+	// Rules are the names of the matched rules.
+	Rules []string
+	// ClassifierScore is the llm classifier score, or -1 when the classifier did not run.
+	ClassifierScore float32
+	Action          ScreeningAction
+}
@@ -0,0 +1,56 @@
+package domain
+
+// QueryTrace describes how a query was answered. It is only collected for explained queries.
+type QueryTrace struct {
+	Retrieval       *QueryTraceRetrieval
+	Rerank          *QueryTraceRerank
+	Expansion       []*QueryTraceExpansion
+	Screening       []*QueryTraceScreening
+	Memory          *QueryTraceMemory
+	History         *HistoryTrim
+	SelectedContext []string
+	Messages        []*Message
+	Timings         QueryTraceTimings
+}
+
+type QueryTraceRetrieval struct {
+	Input   *VectorStoreSearchInput
+	Results []*VectorStoreSearchResult
+}
+
+// QueryTraceRerank is nil when less than two documents were retrieved and reranking was skipped.
+type QueryTraceRerank struct {
+	Input   *RerankerRerankInput
+	Results []*RerankerRerankResult
+}
+
+// QueryTraceExpansion is the context expansion of a reranked passage. It is
+// only collected when the expansion is enabled.
+type QueryTraceExpansion struct {
+	// Chunks are the numbers of the chunks of the passage in document order, empty when its metadata has no document and chunk fields.
+	Chunks []int64
+	// Tokens is the size of the expanded passage.
+	Tokens int
+}
+
+// QueryTraceScreening is the screening of a reranked passage. It is only collected when screening is enabled.
+type QueryTraceScreening struct {
+	Text      string
+	Screening *PassageScreening
+}
+
+// QueryTraceMemory is the summary replacing the oldest history messages. It is
+// only collected when the history is long enough to be summarized.
+type QueryTraceMemory struct {
+	SummarizedMessages int
+	Summary            string
+	// Cached is set when the summary was reused without asking the llm.
+	Cached bool
+}
+
+type QueryTraceTimings struct {
+	RetrievalMS  int64
+	RerankMS     int64
+	GenerationMS int64
+	TotalMS      int64
+}
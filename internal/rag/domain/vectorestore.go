@@ -5,6 +5,30 @@ type VectorStoreSearchInput struct {
 	TopK     int
 	MinScore float32
 	Filter   map[string]any
+	// MMR diversifies the results when not nil.
+	MMR *VectorStoreSearchMMR
+	// Mode is dense, sparse or hybrid, dense when empty.
+	Mode string
+	// Fusion combines the hybrid results, rrf when empty.
+	Fusion string
+	// Federation searches its collections instead of the default one when not nil.
+	Federation *VectorStoreSearchFederation
+}
+
+type VectorStoreSearchFederation struct {
+	Collections []*VectorStoreSearchCollection
+	// Fusion combines the collections results, weighted when empty.
+	Fusion string
+}
+
+type VectorStoreSearchCollection struct {
+	Name   string
+	Weight float32
+}
+
+type VectorStoreSearchMMR struct {
+	Lambda float32
+	FetchK int
 }
 
 type VectorStoreSearchResult struct {
@@ -12,3 +36,21 @@ type VectorStoreSearchResult struct {
 	Score    float32
 	Metadata map[string]any
 }
+
+type VectorStoreLookupRange struct {
+	Gte float64
+	Lte float64
+}
+
+type VectorStoreLookupInput struct {
+	// Match are the metadata values the texts must equal.
+	Match map[string]any
+	// Ranges bound the numeric metadata values of the texts.
+	Ranges map[string]*VectorStoreLookupRange
+	Limit  int
+}
+
+type VectorStoreLookupResult struct {
+	Text     string
+	Metadata map[string]any
+}
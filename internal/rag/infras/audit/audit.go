@@ -0,0 +1,241 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// RedactionMark replaces the redacted values.
+	RedactionMark = "[REDACTED]"
+
+	FieldQuery         = "query"
+	FieldAnswer        = "answer"
+	FieldFilter        = "filter"
+	FieldCallerAddress = "caller_address"
+
+	maxBatchSize = 256
+)
+
+type (
+	// Writer persists the audit records. It is only called by the audit log goroutine.
+	Writer interface {
+		Write(ctx context.Context, records []*domain.AuditRecord) error
+	}
+
+	// Exporter is implemented by the writers able to read their records back.
+	Exporter interface {
+		Export(ctx context.Context, from, to time.Time, fn func(record *domain.AuditRecord) (continueRunning bool)) error
+	}
+)
+
+type auditLog struct {
+	records  chan *domain.AuditRecord
+	writer   Writer
+	redactor *redactor
+	dropped  atomic.Uint64
+	cancel   context.CancelFunc
+	done     chan struct{}
+	tracer   trace.Tracer
+	logger   *slog.Logger
+}
+
+var _ usecase.AuditLog = (*auditLog)(nil)
+
+// NewAuditLog buffers the records and writes them in batches with writer
+// until the log is closed. It outlives ctx so the queries still running
+// while shutting down are recorded.
+func NewAuditLog(
+	ctx context.Context,
+	config *config.Config,
+	writer Writer,
+	tracer trace.Tracer,
+	logger *slog.Logger,
+) (*auditLog, error) {
+	redactor, err := newRedactor(config.AuditConfig.RedactFields, config.AuditConfig.RedactPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	log := &auditLog{
+		records:  make(chan *domain.AuditRecord, config.AuditConfig.BufferSize),
+		writer:   writer,
+		redactor: redactor,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		tracer:   tracer,
+		logger:   logger,
+	}
+
+	go log.run(ctx)
+
+	return log, nil
+}
+
+// Record queues the record, dropping it when the buffer is full.
+func (log *auditLog) Record(ctx context.Context, record *domain.AuditRecord) {
+	select {
+	case log.records <- record:
+	default:
+		log.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of records dropped because the buffer was full.
+func (log *auditLog) Dropped() uint64 {
+	return log.dropped.Load()
+}
+
+// Export exports the written records. The records still in the buffer are not exported.
+func (log *auditLog) Export(ctx context.Context, from, to time.Time, fn func(record *domain.AuditRecord) (continueRunning bool)) (err error) {
+	ctx, span := log.tracer.Start(ctx, "auditLog.Export")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	exporter, ok := log.writer.(Exporter)
+	if !ok {
+		err = fmt.Errorf("audit writer %T does not support export", log.writer)
+		return err
+	}
+
+	return exporter.Export(ctx, from, to, fn)
+}
+
+// Close writes the buffered records and closes the writer if it is an io.Closer.
+func (log *auditLog) Close() error {
+	log.cancel()
+	<-log.done
+
+	if closer, ok := log.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (log *auditLog) run(ctx context.Context) {
+	defer close(log.done)
+
+	var reportedDropped uint64
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write what is left so closing does not lose the buffered records
+			for batch := log.drain(nil); len(batch) > 0; batch = log.drain(nil) {
+				log.write(context.WithoutCancel(ctx), batch)
+			}
+			return
+		case record := <-log.records:
+			log.write(ctx, log.drain([]*domain.AuditRecord{record}))
+		}
+
+		if dropped := log.dropped.Load(); dropped != reportedDropped {
+			log.logger.WarnContext(ctx, "audit log is backed up and dropped records", slog.Uint64("count", dropped-reportedDropped))
+			reportedDropped = dropped
+		}
+	}
+}
+
+// drain appends the buffered records to batch without waiting for more.
+func (log *auditLog) drain(batch []*domain.AuditRecord) []*domain.AuditRecord {
+	for len(batch) < maxBatchSize {
+		select {
+		case record := <-log.records:
+			batch = append(batch, record)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (log *auditLog) write(ctx context.Context, batch []*domain.AuditRecord) {
+	for i, record := range batch {
+		batch[i] = log.redactor.redact(record)
+	}
+
+	if err := log.writer.Write(ctx, batch); err != nil {
+		log.logger.ErrorContext(ctx, "failed to audit writer write", slog.Int("count", len(batch)), slog.String("error", err.Error()))
+	}
+}
+
+type redactor struct {
+	fields   map[string]bool
+	patterns []*regexp.Regexp
+}
+
+func newRedactor(fields []string, patterns []string) (*redactor, error) {
+	r := &redactor{fields: make(map[string]bool, len(fields))}
+
+	for _, field := range fields {
+		switch field {
+		case FieldQuery, FieldAnswer, FieldFilter, FieldCallerAddress:
+			r.fields[field] = true
+		default:
+			return nil, fmt.Errorf("unknown audit redact field %q", field)
+		}
+	}
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile audit redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// redact returns a redacted copy of record.
+func (r *redactor) redact(record *domain.AuditRecord) *domain.AuditRecord {
+	redacted := *record
+
+	redacted.Query = r.redactText(FieldQuery, redacted.Query)
+	redacted.Answer = r.redactText(FieldAnswer, redacted.Answer)
+
+	// the filtered fields are kept and only their values are redacted
+	if r.fields[FieldFilter] && len(redacted.Filter) > 0 {
+		redacted.Filter = make(map[string]any, len(record.Filter))
+		for key := range record.Filter {
+			redacted.Filter[key] = RedactionMark
+		}
+	}
+	if r.fields[FieldCallerAddress] && redacted.CallerAddress != "" {
+		redacted.CallerAddress = RedactionMark
+	}
+
+	return &redacted
+}
+
+func (r *redactor) redactText(field string, text string) string {
+	if text == "" {
+		return text
+	}
+	if r.fields[field] {
+		return RedactionMark
+	}
+	for _, re := range r.patterns {
+		text = re.ReplaceAllLiteralString(text, RedactionMark)
+	}
+	return text
+}
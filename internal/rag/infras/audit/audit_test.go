@@ -0,0 +1,300 @@
+package audit_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/audit"
+
+	"github.com/google/go-cmp/cmp"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	tracer = otel_trace_noop.NewTracerProvider().Tracer("")
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+)
+
+type memoryWriter struct {
+	mu      sync.Mutex
+	records []*domain.AuditRecord
+	// started is signaled and release awaited on every write when not nil.
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *memoryWriter) Write(ctx context.Context, records []*domain.AuditRecord) error {
+	if w.started != nil {
+		w.started <- struct{}{}
+		<-w.release
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.records = append(w.records, records...)
+	return nil
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) TimeNow() time.Time { return c.now }
+
+func TestAuditLog_RecordNeverBlocks(t *testing.T) {
+	t.Parallel()
+
+	writer := &memoryWriter{started: make(chan struct{}), release: make(chan struct{})}
+
+	cfg := &config.Config{}
+	cfg.AuditConfig.BufferSize = 2
+
+	auditLog, err := audit.NewAuditLog(context.Background(), cfg, writer, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// the first record is being written and the writer is stuck
+	auditLog.Record(context.Background(), &domain.AuditRecord{Query: "q0"})
+	<-writer.started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 9 {
+			auditLog.Record(context.Background(), &domain.AuditRecord{Query: "q"})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("record blocked on a backed up audit log")
+	}
+
+	// two records are buffered and the rest are dropped
+	if diff := cmp.Diff(uint64(7), auditLog.Dropped()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	go func() {
+		for range writer.started {
+		}
+	}()
+	close(writer.release)
+
+	if err := auditLog.Close(); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	close(writer.started)
+
+	if diff := cmp.Diff(3, len(writer.records)); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestAuditLog_Redaction(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		fields   []string
+		patterns []string
+	}
+
+	type testCase struct {
+		name  string
+		input input
+		want  *domain.AuditRecord
+	}
+
+	record := &domain.AuditRecord{
+		ResponseID:    "id",
+		CallerID:      "key-1",
+		CallerAddress: "10.0.0.1",
+		Query:         "mail me at jane@example.com",
+		Filter:        map[string]any{"tenant": "acme"},
+		Answer:        "jane@example.com is noted",
+	}
+
+	testCases := []testCase{
+		{
+			name:  "none",
+			input: input{},
+			want:  record,
+		},
+		{
+			name:  "fields",
+			input: input{fields: []string{audit.FieldQuery, audit.FieldFilter, audit.FieldCallerAddress}},
+			want: &domain.AuditRecord{
+				ResponseID:    "id",
+				CallerID:      "key-1",
+				CallerAddress: audit.RedactionMark,
+				Query:         audit.RedactionMark,
+				Filter:        map[string]any{"tenant": audit.RedactionMark},
+				Answer:        "jane@example.com is noted",
+			},
+		},
+		{
+			name:  "patterns",
+			input: input{patterns: []string{`[\w.]+@[\w.]+`}},
+			want: &domain.AuditRecord{
+				ResponseID:    "id",
+				CallerID:      "key-1",
+				CallerAddress: "10.0.0.1",
+				Query:         "mail me at " + audit.RedactionMark,
+				Filter:        map[string]any{"tenant": "acme"},
+				Answer:        audit.RedactionMark + " is noted",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Config{}
+			cfg.AuditConfig.BufferSize = 1
+			cfg.AuditConfig.RedactFields = tc.input.fields
+			cfg.AuditConfig.RedactPatterns = tc.input.patterns
+
+			writer := &memoryWriter{}
+			auditLog, err := audit.NewAuditLog(context.Background(), cfg, writer, tracer, logger)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			auditLog.Record(context.Background(), record)
+			if err := auditLog.Close(); err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if diff := cmp.Diff([]*domain.AuditRecord{tc.want}, writer.records); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestNewAuditLog_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{}
+	cfg.AuditConfig.BufferSize = 1
+	cfg.AuditConfig.RedactPatterns = []string{"("}
+
+	if _, err := audit.NewAuditLog(context.Background(), cfg, &memoryWriter{}, tracer, logger); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	t0 := time.UnixMilli(1_700_000_000_000)
+	clock := &fakeClock{now: t0}
+
+	cfg := &config.Config{}
+	cfg.AuditConfig.Dir = dir
+	cfg.AuditConfig.MaxFileSizeMB = 1
+	cfg.AuditConfig.Retention = 72 * time.Hour
+
+	writer, err := audit.NewJSONLWriter(context.Background(), cfg, clock, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	t.Cleanup(func() { writer.Close() })
+
+	newRecord := func(query string, answer string) *domain.AuditRecord {
+		return &domain.AuditRecord{
+			ResponseID:  query,
+			CreatedAtMS: clock.now.UnixMilli(),
+			CallerID:    "anonymous",
+			Query:       query,
+			Filter:      map[string]any{"tenant": "acme"},
+			SourceIDs:   []string{"s1", ""},
+			Answer:      answer,
+			StopReason:  domain.StopReasonDone,
+			Timings:     domain.QueryTraceTimings{RetrievalMS: 1, TotalMS: 2},
+		}
+	}
+
+	write := func(records ...*domain.AuditRecord) {
+		t.Helper()
+		if err := writer.Write(context.Background(), records); err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+	}
+
+	export := func(from, to time.Time) []*domain.AuditRecord {
+		t.Helper()
+		var records []*domain.AuditRecord
+		if err := writer.Export(context.Background(), from, to, func(record *domain.AuditRecord) bool {
+			records = append(records, record)
+			return true
+		}); err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		return records
+	}
+
+	files := func() int {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		return len(entries)
+	}
+
+	// day 0: two records then a record past the max file size
+	r1, r2 := newRecord("q1", "a1"), newRecord("q2", "a2")
+	write(r1, r2)
+	clock.now = clock.now.Add(time.Hour)
+	r3 := newRecord("q3", strings.Repeat("a", 1<<20))
+	write(r3)
+
+	// rotated by size
+	clock.now = clock.now.Add(time.Hour)
+	r4 := newRecord("q4", "a4")
+	write(r4)
+
+	// rotated by age
+	clock.now = clock.now.Add(25 * time.Hour)
+	r5 := newRecord("q5", "a5")
+	write(r5)
+
+	if diff := cmp.Diff(3, files()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if diff := cmp.Diff([]*domain.AuditRecord{r1, r2, r3, r4, r5}, export(t0, clock.now.Add(time.Millisecond))); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]*domain.AuditRecord{r3, r4}, export(t0.Add(time.Hour), t0.Add(3*time.Hour))); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]*domain.AuditRecord(nil), export(t0.Add(-time.Hour), t0)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// the files created before the retention are deleted on rotation
+	clock.now = t0.Add(100 * time.Hour)
+	r6 := newRecord("q6", "a6")
+	write(r6)
+
+	if diff := cmp.Diff(2, files()); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]*domain.AuditRecord{r5, r6}, export(t0, clock.now.Add(time.Millisecond))); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,303 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+
+	goccy_json "github.com/goccy/go-json"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	filePrefix = "audit-"
+	fileSuffix = ".jsonl"
+
+	// maxFileAge rotates the files daily so the retention applies to low traffic servers too.
+	maxFileAge = 24 * time.Hour
+)
+
+type jsonlWriter struct {
+	dir         string
+	maxFileSize int64
+	retention   time.Duration
+	clock       usecase.Clock
+
+	// mu guards the current file against the concurrent exports.
+	mu        sync.Mutex
+	file      *os.File
+	fileStart time.Time
+	fileSize  int64
+
+	tracer trace.Tracer
+	logger *slog.Logger
+}
+
+var (
+	_ Writer   = (*jsonlWriter)(nil)
+	_ Exporter = (*jsonlWriter)(nil)
+)
+
+// NewJSONLWriter writes the records as json lines to audit-<unix ms>.jsonl
+// files in the audit dir, named after their creation time. The files are
+// rotated daily or once past the max size, and the files with only records
+// older than the retention are deleted on rotation.
+func NewJSONLWriter(
+	ctx context.Context,
+	config *config.Config,
+	clock usecase.Clock,
+	tracer trace.Tracer,
+	logger *slog.Logger,
+) (*jsonlWriter, error) {
+	if err := os.MkdirAll(config.AuditConfig.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit dir %s: %w", config.AuditConfig.Dir, err)
+	}
+
+	return &jsonlWriter{
+		dir:         config.AuditConfig.Dir,
+		maxFileSize: int64(config.AuditConfig.MaxFileSizeMB) << 20,
+		retention:   config.AuditConfig.Retention,
+		clock:       clock,
+		tracer:      tracer,
+		logger:      logger,
+	}, nil
+}
+
+func (w *jsonlWriter) Write(ctx context.Context, records []*domain.AuditRecord) (err error) {
+	ctx, span := w.tracer.Start(ctx, "jsonlWriter.Write")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	var lines []byte
+	for _, record := range records {
+		line, err := goccy_json.Marshal(toRecord(record))
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit record: %w", err)
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err = w.rotateIfNeeded(ctx); err != nil {
+		return err
+	}
+
+	n, err := w.file.Write(lines)
+	w.fileSize += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit file: %w", err)
+	}
+
+	if err = w.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit file: %w", err)
+	}
+
+	return nil
+}
+
+func (w *jsonlWriter) rotateIfNeeded(ctx context.Context) error {
+	now := w.clock.TimeNow()
+
+	if w.file != nil && w.fileSize < w.maxFileSize && now.Sub(w.fileStart) < maxFileAge {
+		return nil
+	}
+
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return fmt.Errorf("failed to close audit file: %w", err)
+		}
+		w.file = nil
+	}
+
+	// a file per millisecond at most so a rotation never appends to the previous file
+	if !now.After(w.fileStart) {
+		now = w.fileStart.Add(time.Millisecond)
+	}
+
+	name := filepath.Join(w.dir, fmt.Sprintf("%s%d%s", filePrefix, now.UnixMilli(), fileSuffix))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create audit file %s: %w", name, err)
+	}
+
+	w.file = file
+	w.fileStart = time.UnixMilli(now.UnixMilli())
+	w.fileSize = 0
+
+	if w.retention > 0 {
+		if err := w.deleteExpired(now.Add(-w.retention)); err != nil {
+			w.logger.ErrorContext(ctx, "failed to delete expired audit files", slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// deleteExpired deletes the files only holding records created before before.
+// The records of a file are created before the next file.
+func (w *jsonlWriter) deleteExpired(before time.Time) error {
+	files, err := w.files()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i+1 < len(files); i++ {
+		if files[i+1].start.After(before) {
+			break
+		}
+		if err := os.Remove(files[i].path); err != nil {
+			return fmt.Errorf("failed to remove audit file %s: %w", files[i].path, err)
+		}
+	}
+
+	return nil
+}
+
+// Export reads the records from the oldest file skipping the files only
+// holding records created before from.
+func (w *jsonlWriter) Export(ctx context.Context, from, to time.Time, fn func(record *domain.AuditRecord) (continueRunning bool)) (err error) {
+	ctx, span := w.tracer.Start(ctx, "jsonlWriter.Export")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	// the current file is only read up to its size so a write in progress is never read half way
+	w.mu.Lock()
+	files, err := w.files()
+	currentFile, currentFileSize := "", int64(0)
+	if w.file != nil {
+		currentFile, currentFileSize = w.file.Name(), w.fileSize
+	}
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for i, file := range files {
+		if i+1 < len(files) && !files[i+1].start.After(from) {
+			continue
+		}
+
+		limit := int64(-1)
+		if file.path == currentFile {
+			limit = currentFileSize
+		}
+
+		continueRunning, err := exportFile(ctx, file.path, limit, from, to, fn)
+		if errors.Is(err, os.ErrNotExist) {
+			// deleted by the retention since listed
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !continueRunning {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func exportFile(ctx context.Context, path string, limit int64, from, to time.Time, fn func(record *domain.AuditRecord) (continueRunning bool)) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open audit file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if limit >= 0 {
+		reader = io.LimitReader(file, limit)
+	}
+
+	decoder := goccy_json.NewDecoder(reader)
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		var r record
+		if err := decoder.Decode(&r); err != nil {
+			if errors.Is(err, io.EOF) {
+				return true, nil
+			}
+			return false, fmt.Errorf("failed to decode audit file %s: %w", path, err)
+		}
+
+		if r.CreatedAtMS < from.UnixMilli() || r.CreatedAtMS >= to.UnixMilli() {
+			continue
+		}
+
+		if !fn(r.toDomain()) {
+			return false, nil
+		}
+	}
+}
+
+type auditFile struct {
+	path  string
+	start time.Time
+}
+
+// files returns the audit files sorted by their creation time.
+func (w *jsonlWriter) files() ([]*auditFile, error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit dir %s: %w", w.dir, err)
+	}
+
+	var files []*auditFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		ms, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, &auditFile{path: filepath.Join(w.dir, name), start: time.UnixMilli(ms)})
+	}
+
+	slices.SortFunc(files, func(a, b *auditFile) int { return a.start.Compare(b.start) })
+
+	return files, nil
+}
+
+func (w *jsonlWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
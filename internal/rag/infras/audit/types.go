@@ -0,0 +1,81 @@
+package audit
+
+import "github.com/aria3ppp/rag-server/internal/rag/domain"
+
+type record struct {
+	ResponseID  string         `json:"response_id"`
+	CreatedAtMS int64          `json:"created_at_ms"`
+	Caller      callerRecord   `json:"caller"`
+	Query       string         `json:"query"`
+	Filter      map[string]any `json:"filter,omitempty"`
+	SourceIDs   []string       `json:"source_ids"`
+	Answer      string         `json:"answer"`
+	StopReason  string         `json:"stop_reason"`
+	Error       string         `json:"error,omitempty"`
+	Timings     timingsRecord  `json:"timings"`
+}
+
+type callerRecord struct {
+	ID      string `json:"id"`
+	Address string `json:"address,omitempty"`
+}
+
+type timingsRecord struct {
+	RetrievalMS  int64 `json:"retrieval_ms"`
+	RerankMS     int64 `json:"rerank_ms"`
+	GenerationMS int64 `json:"generation_ms"`
+	TotalMS      int64 `json:"total_ms"`
+}
+
+var stopReasons = map[domain.StopReason]string{
+	domain.StopReasonUnspecified: "unspecified",
+	domain.StopReasonDone:        "done",
+	domain.StopReasonError:       "error",
+	domain.StopReasonCancelled:   "cancelled",
+}
+
+func toRecord(auditRecord *domain.AuditRecord) *record {
+	return &record{
+		ResponseID:  auditRecord.ResponseID,
+		CreatedAtMS: auditRecord.CreatedAtMS,
+		Caller:      callerRecord{ID: auditRecord.CallerID, Address: auditRecord.CallerAddress},
+		Query:       auditRecord.Query,
+		Filter:      auditRecord.Filter,
+		SourceIDs:   auditRecord.SourceIDs,
+		Answer:      auditRecord.Answer,
+		StopReason:  stopReasons[auditRecord.StopReason],
+		Error:       auditRecord.Error,
+		Timings: timingsRecord{
+			RetrievalMS:  auditRecord.Timings.RetrievalMS,
+			RerankMS:     auditRecord.Timings.RerankMS,
+			GenerationMS: auditRecord.Timings.GenerationMS,
+			TotalMS:      auditRecord.Timings.TotalMS,
+		},
+	}
+}
+
+func (r *record) toDomain() *domain.AuditRecord {
+	auditRecord := &domain.AuditRecord{
+		ResponseID:    r.ResponseID,
+		CreatedAtMS:   r.CreatedAtMS,
+		CallerID:      r.Caller.ID,
+		CallerAddress: r.Caller.Address,
+		Query:         r.Query,
+		Filter:        r.Filter,
+		SourceIDs:     r.SourceIDs,
+		Answer:        r.Answer,
+		Error:         r.Error,
+		Timings: domain.QueryTraceTimings{
+			RetrievalMS:  r.Timings.RetrievalMS,
+			RerankMS:     r.Timings.RerankMS,
+			GenerationMS: r.Timings.GenerationMS,
+			TotalMS:      r.Timings.TotalMS,
+		},
+	}
+	for stopReason, name := range stopReasons {
+		if name == r.StopReason {
+			auditRecord.StopReason = stopReason
+		}
+	}
+	return auditRecord
+}
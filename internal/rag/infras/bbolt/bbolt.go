@@ -0,0 +1,398 @@
+package bbolt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+
+	goccy_json "github.com/goccy/go-json"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	responsesBucket = []byte("responses")
+	feedbackBucket  = []byte("feedback")
+)
+
+const (
+	// openTimeout bounds waiting for the file lock held by another process.
+	openTimeout   = time.Second
+	pruneInterval = time.Hour
+
+	maxBatchSize = 256
+)
+
+// ErrClosed is returned by the responses saved after the store is closed.
+var ErrClosed = errors.New("feedback store is closed")
+
+type feedbackStore struct {
+	db     *bbolt.DB
+	config *config.FeedbackConfig
+
+	// responses are the saved responses waiting to be written by the writer goroutine.
+	responses chan *domain.Response
+	// flushes asks the writer goroutine to write the waiting responses and close the channel.
+	flushes chan chan struct{}
+	// pending are the saved responses not written yet, so they can be read back.
+	mu      sync.Mutex
+	pending map[string]*domain.Response
+	cancel  context.CancelFunc
+	done    chan struct{}
+	// closeMu makes closing wait for the responses being saved, and closed
+	// fails the responses saved after, so none is lost.
+	closeMu sync.RWMutex
+	closed  bool
+
+	tracer trace.Tracer
+	logger *slog.Logger
+}
+
+var _ usecase.FeedbackStore = (*feedbackStore)(nil)
+
+// NewFeedbackStore opens the bbolt file, writes the saved responses in
+// batches until the store is closed and, with a response retention, prunes
+// the expired responses every hour until ctx is done.
+func NewFeedbackStore(
+	ctx context.Context,
+	config *config.Config,
+	tracer trace.Tracer,
+	logger *slog.Logger,
+) (*feedbackStore, error) {
+	db, err := bbolt.Open(config.FeedbackConfig.StorePath, 0o600, &bbolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open feedback store %s: %w", config.FeedbackConfig.StorePath, err)
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{responsesBucket, feedbackBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create feedback store buckets: %w", err)
+	}
+
+	// the writer outlives ctx so the responses saved while shutting down are written on close
+	writerCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	store := &feedbackStore{
+		db:        db,
+		config:    &config.FeedbackConfig,
+		responses: make(chan *domain.Response, config.FeedbackConfig.BufferSize),
+		flushes:   make(chan chan struct{}),
+		pending:   make(map[string]*domain.Response),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		tracer:    tracer,
+		logger:    logger,
+	}
+
+	go store.run(writerCtx)
+
+	if store.config.ResponseRetention > 0 {
+		go store.pruneEvery(ctx, pruneInterval)
+	}
+
+	return store, nil
+}
+
+// Close writes the waiting responses and closes the bbolt file. The
+// responses saved after fail with ErrClosed.
+func (store *feedbackStore) Close() error {
+	store.closeMu.Lock()
+	store.closed = true
+	store.closeMu.Unlock()
+
+	store.cancel()
+	<-store.done
+
+	return store.db.Close()
+}
+
+// SaveResponse queues the response to be written in the background, so
+// saving does not wait for the file sync. It is written right away when the
+// buffer is full.
+func (store *feedbackStore) SaveResponse(ctx context.Context, response *domain.Response) (err error) {
+	ctx, span := store.tracer.Start(ctx, "feedbackStore.SaveResponse")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	store.closeMu.RLock()
+	defer store.closeMu.RUnlock()
+	if store.closed {
+		return ErrClosed
+	}
+
+	store.mu.Lock()
+	store.pending[response.ID] = response
+	store.mu.Unlock()
+
+	select {
+	case store.responses <- response:
+		return nil
+	default:
+		return store.write(ctx, []*domain.Response{response})
+	}
+}
+
+func (store *feedbackStore) GetResponse(ctx context.Context, id string) (_ *domain.Response, err error) {
+	ctx, span := store.tracer.Start(ctx, "feedbackStore.GetResponse")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	store.mu.Lock()
+	response, ok := store.pending[id]
+	store.mu.Unlock()
+	if ok {
+		return response, nil
+	}
+
+	var record responseRecord
+	err = store.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(responsesBucket).Get([]byte(id))
+		if value == nil {
+			return internal_error.NewNotFoundError(fmt.Errorf("response %s not found", id))
+		}
+		return goccy_json.Unmarshal(value, &record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record.toDomain(), nil
+}
+
+// SaveFeedback saves the feedback with a copy of its response, so it
+// outlives the response retention. A new feedback on the same response
+// replaces the previous one.
+func (store *feedbackStore) SaveFeedback(ctx context.Context, record *domain.FeedbackRecord) (err error) {
+	ctx, span := store.tracer.Start(ctx, "feedbackStore.SaveFeedback")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	value, err := goccy_json.Marshal(toFeedbackRecord(record))
+	if err != nil {
+		return fmt.Errorf("failed to marshal feedback: %w", err)
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(feedbackBucket).Put([]byte(record.Response.ID), value)
+	})
+}
+
+var errStopIteration = errors.New("stop iteration")
+
+// ForEachFeedback calls fn with every feedback until fn returns false.
+func (store *feedbackStore) ForEachFeedback(ctx context.Context, fn func(record *domain.FeedbackRecord) (continueRunning bool)) error {
+	err := store.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(feedbackBucket).ForEach(func(key, value []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			var record feedbackRecord
+			if err := goccy_json.Unmarshal(value, &record); err != nil {
+				return fmt.Errorf("failed to unmarshal feedback %s: %w", key, err)
+			}
+
+			if !fn(record.toDomain()) {
+				return errStopIteration
+			}
+			return nil
+		})
+	})
+	if errors.Is(err, errStopIteration) {
+		return nil
+	}
+	return err
+}
+
+// Prune deletes the responses created before before and returns their count.
+func (store *feedbackStore) Prune(ctx context.Context, before time.Time) (pruned int, err error) {
+	ctx, span := store.tracer.Start(ctx, "feedbackStore.Prune")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	// the waiting responses are written first so the expired ones among them are pruned too
+	if err = store.flush(ctx); err != nil {
+		return 0, err
+	}
+
+	err = store.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(responsesBucket)
+
+		// deleting while iterating skips keys so the keys are collected first
+		var expired [][]byte
+		if err := bucket.ForEach(func(key, value []byte) error {
+			var record responseRecord
+			if err := goccy_json.Unmarshal(value, &record); err != nil {
+				return fmt.Errorf("failed to unmarshal response %s: %w", key, err)
+			}
+			if record.CreatedAtMS < before.UnixMilli() {
+				expired = append(expired, key)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		pruned = len(expired)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return pruned, nil
+}
+
+func (store *feedbackStore) pruneEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			pruned, err := store.Prune(ctx, now.Add(-store.config.ResponseRetention))
+			if err != nil {
+				store.logger.ErrorContext(ctx, "failed to prune feedback store responses", slog.String("error", err.Error()))
+				continue
+			}
+			store.logger.DebugContext(ctx, "pruned feedback store responses", slog.Int("count", pruned))
+		}
+	}
+}
+
+func (store *feedbackStore) run(ctx context.Context) {
+	defer close(store.done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write what is left so closing does not lose the saved responses
+			for batch := store.drain(nil); len(batch) > 0; batch = store.drain(nil) {
+				store.writeBatch(context.WithoutCancel(ctx), batch)
+			}
+			return
+		case flushed := <-store.flushes:
+			for batch := store.drain(nil); len(batch) > 0; batch = store.drain(nil) {
+				store.writeBatch(ctx, batch)
+			}
+			close(flushed)
+		case response := <-store.responses:
+			store.writeBatch(ctx, store.drain([]*domain.Response{response}))
+		}
+	}
+}
+
+// flush waits until the responses saved before it are written.
+func (store *feedbackStore) flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+
+	select {
+	case store.flushes <- flushed:
+	case <-store.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drain appends the waiting responses to batch without waiting for more.
+func (store *feedbackStore) drain(batch []*domain.Response) []*domain.Response {
+	for len(batch) < maxBatchSize {
+		select {
+		case response := <-store.responses:
+			batch = append(batch, response)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (store *feedbackStore) writeBatch(ctx context.Context, batch []*domain.Response) {
+	if err := store.write(ctx, batch); err != nil {
+		store.logger.ErrorContext(ctx, "failed to feedback store write responses", slog.Int("count", len(batch)), slog.String("error", err.Error()))
+	}
+}
+
+// write writes the responses in a single transaction and removes them from the pending ones.
+func (store *feedbackStore) write(ctx context.Context, responses []*domain.Response) error {
+	defer func() {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		for _, response := range responses {
+			// a response saved again while being written stays pending
+			if store.pending[response.ID] == response {
+				delete(store.pending, response.ID)
+			}
+		}
+	}()
+
+	values := make([][]byte, len(responses))
+	for i, response := range responses {
+		value, err := goccy_json.Marshal(toResponseRecord(response))
+		if err != nil {
+			return fmt.Errorf("failed to marshal response %s: %w", response.ID, err)
+		}
+		values[i] = value
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(responsesBucket)
+		for i, response := range responses {
+			if err := bucket.Put([]byte(response.ID), values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,208 @@
+package bbolt_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/bbolt"
+
+	"github.com/google/go-cmp/cmp"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestFeedbackStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	store, err := bbolt.NewFeedbackStore(
+		ctx,
+		&config.Config{FeedbackConfig: config.FeedbackConfig{StorePath: filepath.Join(t.TempDir(), "feedback.db"), BufferSize: 16}},
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer store.Close()
+
+	old := &domain.Response{
+		ID:          "old",
+		Query:       "q1",
+		Messages:    []*domain.Message{{Role: domain.RoleUser, Content: "hi"}},
+		Sources:     []*domain.Source{{Text: "t1", Score: 0.5, Metadata: map[string]any{"source_id": "a"}}},
+		Answer:      "a1",
+		CreatedAtMS: 1000,
+	}
+	recent := &domain.Response{
+		ID:          "recent",
+		Query:       "q2",
+		Messages:    []*domain.Message{},
+		Sources:     []*domain.Source{},
+		Answer:      "a2",
+		CreatedAtMS: 3000,
+	}
+
+	for _, response := range []*domain.Response{old, recent} {
+		if err := store.SaveResponse(ctx, response); err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+	}
+
+	got, err := store.GetResponse(ctx, "old")
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(old, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	record := &domain.FeedbackRecord{
+		Response: old,
+		Feedback: &domain.Feedback{ResponseID: "old", Rating: 2, Comment: "meh", FlaggedSources: []int{0}, CreatedAtMS: 2000},
+	}
+	if err := store.SaveFeedback(ctx, record); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// the feedback outlives its pruned response
+	pruned, err := store.Prune(ctx, time.UnixMilli(2000))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(1, pruned); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var notFoundError *internal_error.NotFoundError
+	if _, err := store.GetResponse(ctx, "old"); !errors.As(err, &notFoundError) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := store.GetResponse(ctx, "recent"); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	var records []*domain.FeedbackRecord
+	if err := store.ForEachFeedback(ctx, func(record *domain.FeedbackRecord) bool {
+		records = append(records, record)
+		return true
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff([]*domain.FeedbackRecord{record}, records); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestFeedbackStore_Close(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	config := &config.Config{FeedbackConfig: config.FeedbackConfig{StorePath: filepath.Join(t.TempDir(), "feedback.db"), BufferSize: 1024}}
+	tracer := otel_trace_noop.NewTracerProvider().Tracer("")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	store, err := bbolt.NewFeedbackStore(ctx, config, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	responses := make([]*domain.Response, 100)
+	for i := range responses {
+		responses[i] = &domain.Response{ID: fmt.Sprint(i), Query: "q", Messages: []*domain.Message{}, Sources: []*domain.Source{}, Answer: "a", CreatedAtMS: int64(i)}
+		if err := store.SaveResponse(ctx, responses[i]); err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+	}
+
+	// closing writes the responses still waiting
+	if err := store.Close(); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// the responses are read back after a restart
+	store, err = bbolt.NewFeedbackStore(ctx, config, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer store.Close()
+
+	for _, response := range responses {
+		got, err := store.GetResponse(ctx, response.ID)
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		if diff := cmp.Diff(response, got); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+}
+
+func TestFeedbackStore_SaveResponseClosed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	config := &config.Config{FeedbackConfig: config.FeedbackConfig{StorePath: filepath.Join(t.TempDir(), "feedback.db"), BufferSize: 4}}
+	tracer := otel_trace_noop.NewTracerProvider().Tracer("")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	store, err := bbolt.NewFeedbackStore(ctx, config, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// the responses are saved while the store is closing
+	var (
+		wg    sync.WaitGroup
+		saved = make([]bool, 100)
+		errs  = make([]error, len(saved))
+	)
+	for i := range saved {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = store.SaveResponse(ctx, &domain.Response{ID: fmt.Sprint(i), Query: "q", Messages: []*domain.Message{}, Sources: []*domain.Source{}, Answer: "a", CreatedAtMS: int64(i)})
+			saved[i] = errs[i] == nil
+		}()
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	wg.Wait()
+
+	if err := store.SaveResponse(ctx, &domain.Response{ID: "closed"}); !errors.Is(err, bbolt.ErrClosed) {
+		t.Fatalf("want %v, got %v", bbolt.ErrClosed, err)
+	}
+
+	// every saved response is written and the others failed as closed
+	store, err = bbolt.NewFeedbackStore(ctx, config, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer store.Close()
+
+	for i := range saved {
+		if !saved[i] {
+			if !errors.Is(errs[i], bbolt.ErrClosed) {
+				t.Fatalf("response %d: want %v, got %v", i, bbolt.ErrClosed, errs[i])
+			}
+			continue
+		}
+		if _, err := store.GetResponse(ctx, fmt.Sprint(i)); err != nil {
+			t.Fatalf("response %d: %v", i, err)
+		}
+	}
+}
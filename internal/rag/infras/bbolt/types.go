@@ -0,0 +1,91 @@
+package bbolt
+
+import "github.com/aria3ppp/rag-server/internal/rag/domain"
+
+type responseRecord struct {
+	ID          string           `json:"id"`
+	Query       string           `json:"query"`
+	Messages    []*messageRecord `json:"messages,omitempty"`
+	Sources     []*sourceRecord  `json:"sources"`
+	Answer      string           `json:"answer"`
+	CreatedAtMS int64            `json:"created_at_ms"`
+}
+
+type messageRecord struct {
+	Role    domain.Role `json:"role"`
+	Content string      `json:"content"`
+}
+
+type sourceRecord struct {
+	Text        string         `json:"text"`
+	Score       float32        `json:"score"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	Quarantined bool           `json:"quarantined,omitempty"`
+}
+
+type feedbackRecord struct {
+	Response       *responseRecord `json:"response"`
+	Rating         int             `json:"rating"`
+	Comment        string          `json:"comment,omitempty"`
+	FlaggedSources []int           `json:"flagged_sources,omitempty"`
+	CreatedAtMS    int64           `json:"created_at_ms"`
+}
+
+func toResponseRecord(response *domain.Response) *responseRecord {
+	record := &responseRecord{
+		ID:          response.ID,
+		Query:       response.Query,
+		Messages:    make([]*messageRecord, len(response.Messages)),
+		Sources:     make([]*sourceRecord, len(response.Sources)),
+		Answer:      response.Answer,
+		CreatedAtMS: response.CreatedAtMS,
+	}
+	for i, message := range response.Messages {
+		record.Messages[i] = &messageRecord{Role: message.Role, Content: message.Content}
+	}
+	for i, source := range response.Sources {
+		record.Sources[i] = &sourceRecord{Text: source.Text, Score: source.Score, Metadata: source.Metadata, Quarantined: source.Quarantined}
+	}
+	return record
+}
+
+func (record *responseRecord) toDomain() *domain.Response {
+	response := &domain.Response{
+		ID:          record.ID,
+		Query:       record.Query,
+		Messages:    make([]*domain.Message, len(record.Messages)),
+		Sources:     make([]*domain.Source, len(record.Sources)),
+		Answer:      record.Answer,
+		CreatedAtMS: record.CreatedAtMS,
+	}
+	for i, message := range record.Messages {
+		response.Messages[i] = &domain.Message{Role: message.Role, Content: message.Content}
+	}
+	for i, source := range record.Sources {
+		response.Sources[i] = &domain.Source{Text: source.Text, Score: source.Score, Metadata: source.Metadata, Quarantined: source.Quarantined}
+	}
+	return response
+}
+
+func toFeedbackRecord(record *domain.FeedbackRecord) *feedbackRecord {
+	return &feedbackRecord{
+		Response:       toResponseRecord(record.Response),
+		Rating:         record.Feedback.Rating,
+		Comment:        record.Feedback.Comment,
+		FlaggedSources: record.Feedback.FlaggedSources,
+		CreatedAtMS:    record.Feedback.CreatedAtMS,
+	}
+}
+
+func (record *feedbackRecord) toDomain() *domain.FeedbackRecord {
+	return &domain.FeedbackRecord{
+		Response: record.Response.toDomain(),
+		Feedback: &domain.Feedback{
+			ResponseID:     record.Response.ID,
+			Rating:         record.Rating,
+			Comment:        record.Comment,
+			FlaggedSources: record.FlaggedSources,
+			CreatedAtMS:    record.CreatedAtMS,
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+)
+
+type entry struct {
+	conversationID string
+	summary        *domain.ConversationSummary
+}
+
+type summaryCache struct {
+	mu sync.Mutex
+	// entries is ordered from the most to the least recently used.
+	entries        *list.List
+	byConversation map[string]*list.Element
+	size           int
+}
+
+var _ usecase.SummaryCache = (*summaryCache)(nil)
+
+// NewSummaryCache keeps the summaries of the most recently used conversations
+// in memory, evicting the least recently used beyond the cache size.
+func NewSummaryCache(config *config.Config) *summaryCache {
+	return &summaryCache{
+		entries:        list.New(),
+		byConversation: make(map[string]*list.Element),
+		size:           config.MemoryConfig.CacheSize,
+	}
+}
+
+func (c *summaryCache) Get(ctx context.Context, conversationID string) (*domain.ConversationSummary, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.byConversation[conversationID]
+	if !ok {
+		return nil, false
+	}
+	c.entries.MoveToFront(element)
+
+	return element.Value.(*entry).summary, true
+}
+
+func (c *summaryCache) Put(ctx context.Context, conversationID string, summary *domain.ConversationSummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.byConversation[conversationID]; ok {
+		element.Value.(*entry).summary = summary
+		c.entries.MoveToFront(element)
+		return
+	}
+
+	c.byConversation[conversationID] = c.entries.PushFront(&entry{conversationID: conversationID, summary: summary})
+
+	for c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.byConversation, oldest.Value.(*entry).conversationID)
+	}
+}
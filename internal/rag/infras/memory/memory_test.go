@@ -0,0 +1,39 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/memory"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummaryCache_Eviction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cfg := &config.Config{}
+	cfg.MemoryConfig.CacheSize = 2
+
+	cache := memory.NewSummaryCache(cfg)
+
+	cache.Put(ctx, "a", &domain.ConversationSummary{Summary: "a"})
+	cache.Put(ctx, "b", &domain.ConversationSummary{Summary: "b"})
+	// reading a makes b the least recently used
+	cache.Get(ctx, "a")
+	cache.Put(ctx, "c", &domain.ConversationSummary{Summary: "c"})
+
+	got := make(map[string]string)
+	for _, conversationID := range []string{"a", "b", "c"} {
+		if summary, ok := cache.Get(ctx, conversationID); ok {
+			got[conversationID] = summary.Summary
+		}
+	}
+	if diff := cmp.Diff(map[string]string{"a": "a", "c": "c"}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,142 @@
+package openai_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/test/cassette"
+	test_server "github.com/aria3ppp/rag-server/internal/pkg/test/server"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/openai"
+
+	"github.com/google/go-cmp/cmp"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func newConfig() *config.Config {
+	config := &config.Config{}
+	config.OpenAIConfig.BaseURL = fmt.Sprintf("http://localhost:%d/v1", llmPort)
+	config.OpenAIConfig.APIKey = "OPENAI_API_KEY"
+	config.OpenAIConfig.Model = "llm"
+	return config
+}
+
+func TestNewLLM(t *testing.T) {
+	t.Parallel()
+
+	recorder := cassette.NewTest(t, t.Name(), nil)
+
+	llm, err := openai.NewLLM(
+		context.Background(),
+		newConfig(),
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+		recorder.Client(),
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if llm == nil {
+		t.Fatal("want a llm, got nil")
+	}
+}
+
+func Test_LLM_StreamCompletion(t *testing.T) {
+	t.Parallel()
+
+	chat := []*domain.Message{
+		{Role: domain.RoleSystem, Content: "You are a helpful assistant. Answer in one sentence."},
+		{Role: domain.RoleUser, Content: "What color is the sky on a clear day?"},
+	}
+
+	type testCase struct {
+		name string
+		// stopAfter stops the stream after as many chunks, 0 reads it to the end
+		stopAfter int
+		// wantChunks is the exact number of chunks, 0 wants more than one
+		wantChunks int
+	}
+
+	testCases := []testCase{
+		{
+			name:       "stop",
+			stopAfter:  1,
+			wantChunks: 1,
+		},
+		{
+			name: "ok",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			recorder := cassette.NewTest(t, t.Name(), nil)
+
+			llm, err := openai.NewLLM(
+				ctx,
+				newConfig(),
+				otel_trace_noop.NewTracerProvider().Tracer(""),
+				slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+				recorder.Client(),
+			)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			var chunks []string
+			llm.StreamCompletion(ctx, chat, func(completionChunk string, err error) bool {
+				if err != nil {
+					t.Error(cmp.Diff(err, nil))
+					return false
+				}
+				chunks = append(chunks, completionChunk)
+				return tt.stopAfter == 0 || len(chunks) < tt.stopAfter
+			})
+
+			if tt.wantChunks > 0 {
+				if diff := cmp.Diff(tt.wantChunks, len(chunks)); diff != "" {
+					t.Fatal(diff)
+				}
+				return
+			}
+
+			if len(chunks) < 2 {
+				t.Fatalf("want a streamed completion, got %d chunks", len(chunks))
+			}
+			if strings.TrimSpace(strings.Join(chunks, "")) == "" {
+				t.Fatal("want a completion, got an empty one")
+			}
+		})
+	}
+}
+
+var llmPort int
+
+func TestMain(m *testing.M) {
+	const llmTestcontainer = "llm_testcontainer"
+
+	ports, cleanup := test_server.SetupModelServers(
+		test_server.NewFatalizer(runtime.FuncForPC(func() uintptr { pc, _, _, _ := runtime.Caller(1); return pc }()).Name()),
+		map[string]test_server.TestServerFunc{
+			llmTestcontainer: test_server.SetupLLMServer,
+		},
+	)
+
+	llmPort = ports[llmTestcontainer]
+
+	exitCode := m.Run()
+	cleanup()
+
+	os.Exit(exitCode)
+}
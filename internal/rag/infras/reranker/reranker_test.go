@@ -0,0 +1,172 @@
+package reranker_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/test/cassette"
+	test_server "github.com/aria3ppp/rag-server/internal/pkg/test/server"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/reranker"
+
+	"github.com/google/go-cmp/cmp"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestNewReranker(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		baseURLPath string
+		wantErr     bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "not_found",
+			baseURLPath: "/not_found",
+			wantErr:     true,
+		},
+		{
+			name:        "ok",
+			baseURLPath: "/v1",
+			wantErr:     false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := cassette.NewTest(t, t.Name(), nil)
+
+			config := &config.Config{}
+			config.RerankerConfig.BaseURL = fmt.Sprintf("http://localhost:%d%s", rerankerPort, tt.baseURLPath)
+
+			rr, err := reranker.NewReranker(
+				context.Background(),
+				config,
+				otel_trace_noop.NewTracerProvider().Tracer(""),
+				slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+				recorder.Client(),
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if (rr == nil) != tt.wantErr {
+				t.Fatal(cmp.Diff(rr == nil, tt.wantErr))
+			}
+		})
+	}
+}
+
+func Test_Reranker_Rerank(t *testing.T) {
+	t.Parallel()
+
+	documents := []string{
+		"A graceful white swan glides across the shimmering lake.",
+		"The quick brown fox jumps over the lazy dog.",
+		"Beneath the golden sun, a playful orange tabby cat pounces on falling leaves.",
+	}
+
+	type testCase struct {
+		name  string
+		input *domain.RerankerRerankInput
+		// wantTopIndex is the index of the best ranked document
+		wantTopIndex int
+	}
+
+	testCases := []testCase{
+		{
+			name: "top_1",
+			input: &domain.RerankerRerankInput{
+				Query:     "which animal jumps over the dog?",
+				TopN:      1,
+				Documents: documents,
+			},
+			wantTopIndex: 1,
+		},
+		{
+			name: "top_3",
+			input: &domain.RerankerRerankInput{
+				Query:     "what does the cat pounce on?",
+				TopN:      3,
+				Documents: documents,
+			},
+			wantTopIndex: 2,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			recorder := cassette.NewTest(t, t.Name(), nil)
+
+			config := &config.Config{}
+			config.RerankerConfig.BaseURL = fmt.Sprintf("http://localhost:%d/v1", rerankerPort)
+
+			rr, err := reranker.NewReranker(
+				ctx,
+				config,
+				otel_trace_noop.NewTracerProvider().Tracer(""),
+				slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+				recorder.Client(),
+			)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			results, err := rr.Rerank(ctx, tt.input)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if diff := cmp.Diff(tt.input.TopN, len(results)); diff != "" {
+				t.Fatal(diff)
+			}
+
+			if diff := cmp.Diff(tt.wantTopIndex, results[0].Index); diff != "" {
+				t.Fatal(diff)
+			}
+
+			for i, result := range results {
+				if diff := cmp.Diff(tt.input.Documents[result.Index], result.Document); diff != "" {
+					t.Fatalf("result %d: %s", i, diff)
+				}
+				if i > 0 && result.Score > results[i-1].Score {
+					t.Fatalf("result %d: score %f above the previous score %f", i, result.Score, results[i-1].Score)
+				}
+			}
+		})
+	}
+}
+
+var rerankerPort int
+
+func TestMain(m *testing.M) {
+	const rerankerTestcontainer = "reranker_testcontainer"
+
+	ports, cleanup := test_server.SetupModelServers(
+		test_server.NewFatalizer(runtime.FuncForPC(func() uintptr { pc, _, _, _ := runtime.Caller(1); return pc }()).Name()),
+		map[string]test_server.TestServerFunc{
+			rerankerTestcontainer: test_server.SetupRerankerServer,
+		},
+	)
+
+	rerankerPort = ports[rerankerTestcontainer]
+
+	exitCode := m.Run()
+	cleanup()
+
+	os.Exit(exitCode)
+}
@@ -0,0 +1,129 @@
+package tokenizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	internal_tokenizer "github.com/aria3ppp/rag-server/internal/pkg/tokenizer"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/usecase"
+
+	goccy_json "github.com/goccy/go-json"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type heuristicTokenizer struct{}
+
+var _ usecase.Tokenizer = heuristicTokenizer{}
+
+// NewHeuristicTokenizer estimates the tokens from the characters: a token
+// per four ASCII characters, as the english text tokenizes, and per two other
+// characters, as the non latin scripts tokenize in much shorter tokens.
+func NewHeuristicTokenizer() heuristicTokenizer {
+	return heuristicTokenizer{}
+}
+
+func (heuristicTokenizer) CountTokens(ctx context.Context, text string) (int, error) {
+	return internal_tokenizer.EstimateTokens(text), nil
+}
+
+type llamaCPPTokenizer struct {
+	httpClient *http.Client
+	config     *config.TokenizerConfig
+	tracer     trace.Tracer
+	logger     *slog.Logger
+}
+
+var _ usecase.Tokenizer = (*llamaCPPTokenizer)(nil)
+
+// NewLlamaCPPTokenizer counts the tokens with the /tokenize endpoint of the
+// llama.cpp server, falling back to the heuristic estimate when it fails.
+func NewLlamaCPPTokenizer(
+	ctx context.Context,
+	config *config.Config,
+	tracer trace.Tracer,
+	logger *slog.Logger,
+	httpClient *http.Client,
+) (*llamaCPPTokenizer, error) {
+	t := &llamaCPPTokenizer{
+		httpClient: httpClient,
+		config:     &config.TokenizerConfig,
+		tracer:     tracer,
+		logger:     logger,
+	}
+
+	// fail early when the server does not tokenize rather than estimating every count
+	if _, err := t.tokenize(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *llamaCPPTokenizer) CountTokens(ctx context.Context, text string) (_ int, err error) {
+	ctx, span := t.tracer.Start(ctx, "llamaCPPTokenizer.CountTokens")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	tokens, tokenizeErr := t.tokenize(ctx, text)
+	if tokenizeErr != nil {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+		t.logger.WarnContext(ctx, "failed to tokenize, estimating the tokens", slog.String("error", tokenizeErr.Error()))
+		return internal_tokenizer.EstimateTokens(text), nil
+	}
+
+	return len(tokens), nil
+}
+
+func (t *llamaCPPTokenizer) tokenize(ctx context.Context, text string) ([]int, error) {
+	reqBodyBytes, err := json.Marshal(&tokenizeRequest{Content: text})
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/tokenize", t.config.BaseURL),
+		bytes.NewReader(reqBodyBytes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := t.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	respBodyBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tokenizer got status code %d: %s", httpResponse.StatusCode, respBodyBytes)
+	}
+
+	var response tokenizeResponse
+	if err = goccy_json.Unmarshal(respBodyBytes, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Tokens, nil
+}
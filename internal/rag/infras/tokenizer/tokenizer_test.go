@@ -0,0 +1,107 @@
+package tokenizer_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/fakemodel"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/infras/tokenizer"
+
+	"github.com/google/go-cmp/cmp"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestHeuristicTokenizer_CountTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]int{
+		"":                 0,
+		"hello":            2,
+		"hello world!":     3,
+		"سلام دنیا":        5,
+		"price: ۱۲۰ تومان": 6,
+	}
+
+	for text, want := range testCases {
+		got, err := tokenizer.NewHeuristicTokenizer().CountTokens(context.Background(), text)
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("text %q: %s", text, diff)
+		}
+	}
+}
+
+func TestLlamaCPPTokenizer_CountTokens(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	server := fakemodel.NewTestServer(t, &fakemodel.Opts{
+		Fault: func(r *http.Request) int {
+			if failing.Load() {
+				return http.StatusServiceUnavailable
+			}
+			return 0
+		},
+	})
+
+	cfg := &config.Config{}
+	cfg.TokenizerConfig.BaseURL = server.URL
+
+	llamaCPPTokenizer, err := tokenizer.NewLlamaCPPTokenizer(
+		context.Background(),
+		cfg,
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		server.Client(),
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	const text = "the quick brown fox jumps over the lazy dog"
+
+	got, err := llamaCPPTokenizer.CountTokens(context.Background(), text)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	// a token per word
+	if diff := cmp.Diff(9, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// falls back to the heuristic estimate
+	failing.Store(true)
+	got, err = llamaCPPTokenizer.CountTokens(context.Background(), text)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(11, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestNewLlamaCPPTokenizer_Unavailable(t *testing.T) {
+	t.Parallel()
+
+	server := fakemodel.NewTestServer(t, &fakemodel.Opts{Fault: fakemodel.FailFirst(1, http.StatusNotFound)})
+
+	cfg := &config.Config{}
+	cfg.TokenizerConfig.BaseURL = server.URL
+
+	if _, err := tokenizer.NewLlamaCPPTokenizer(
+		context.Background(),
+		cfg,
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		server.Client(),
+	); err == nil {
+		t.Fatal("expected error")
+	}
+}
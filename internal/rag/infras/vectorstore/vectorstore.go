@@ -69,6 +69,26 @@ func (vs *vectorstore) Search(ctx context.Context, query *domain.VectorStoreSear
 		TopK:     int64(query.TopK),
 		MinScore: query.MinScore,
 		Filter:   filter,
+		Mode:     query.Mode,
+		Fusion:   query.Fusion,
+	}
+	if query.MMR != nil {
+		request.Mmr = &vectorstore_v1.VectorStoreServiceSearchTextRequestMMR{
+			Lambda: query.MMR.Lambda,
+			FetchK: int64(query.MMR.FetchK),
+		}
+	}
+	if query.Federation != nil {
+		request.Federation = &vectorstore_v1.VectorStoreServiceSearchTextRequestFederation{
+			Collections: make([]*vectorstore_v1.VectorStoreServiceSearchTextRequestFederationCollection, len(query.Federation.Collections)),
+			Fusion:      query.Federation.Fusion,
+		}
+		for i, collection := range query.Federation.Collections {
+			request.Federation.Collections[i] = &vectorstore_v1.VectorStoreServiceSearchTextRequestFederationCollection{
+				Name:   collection.Name,
+				Weight: collection.Weight,
+			}
+		}
 	}
 
 	response, err := vectorstore_v1.NewVectorStoreServiceClient(vs.client).SearchText(ctx, request)
@@ -87,6 +107,38 @@ func (vs *vectorstore) Search(ctx context.Context, query *domain.VectorStoreSear
 	return result, nil
 }
 
+func (vs *vectorstore) Lookup(ctx context.Context, input *domain.VectorStoreLookupInput) ([]*domain.VectorStoreLookupResult, error) {
+	match, err := structpb.NewStruct(input.Match)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &vectorstore_v1.VectorStoreServiceLookupTextsRequest{
+		Match: match,
+		Ranges: lo.MapValues(input.Ranges, func(r *domain.VectorStoreLookupRange, _ string) *vectorstore_v1.VectorStoreServiceLookupTextsRequestRange {
+			return &vectorstore_v1.VectorStoreServiceLookupTextsRequestRange{
+				Gte: r.Gte,
+				Lte: r.Lte,
+			}
+		}),
+		Limit: int64(input.Limit),
+	}
+
+	response, err := vectorstore_v1.NewVectorStoreServiceClient(vs.client).LookupTexts(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := lo.Map(response.GetTexts(), func(text *vectorstore_v1.VectorStoreServiceLookupTextsResponseText, _ int) *domain.VectorStoreLookupResult {
+		return &domain.VectorStoreLookupResult{
+			Text:     text.GetText(),
+			Metadata: text.GetMetadata().AsMap(),
+		}
+	})
+
+	return result, nil
+}
+
 func (vs *vectorstore) Close() error {
 	return vs.client.Close()
 }
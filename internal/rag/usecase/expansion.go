@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+
+	"github.com/samber/lo"
+)
+
+const (
+	expansionModeNeighbors = "neighbors"
+	expansionModeParent    = "parent"
+
+	expansionChunkSeparator = "\n"
+)
+
+// expand merges the sources with their neighbor chunks, or the chunks of
+// their whole document, fetched from the vectorstore. The chunks are added
+// outward from the selected chunk, in rank order of the sources, while all the
+// passages fit the token budget and each passage stays contiguous. A chunk is
+// only merged once. The sources without the document and chunk fields, or
+// whose lookup fails, are kept as is.
+func (uc *usecase) expand(ctx context.Context, expansionConfig config.ExpansionConfig, sources []*domain.Source) ([]*domain.Source, []*domain.QueryTraceExpansion, error) {
+	if (expansionConfig.Mode != expansionModeNeighbors && expansionConfig.Mode != expansionModeParent) || len(sources) == 0 {
+		return sources, nil, nil
+	}
+
+	type chunk struct {
+		document string
+		number   int64
+	}
+
+	type located struct {
+		match  map[string]any
+		chunk  chunk
+		tokens int
+	}
+
+	// the selected chunks are taken first so no passage merges another one
+	merged := make(map[chunk]bool, len(sources))
+	locations := make([]*located, len(sources))
+	used := 0
+	for i, source := range sources {
+		tokens, err := uc.countTokens(ctx, source.Text)
+		if err != nil {
+			return nil, nil, err
+		}
+		used += tokens
+
+		match, document, ok := documentOf(expansionConfig.DocumentFields, source.Metadata)
+		if !ok {
+			continue
+		}
+		number, ok := chunkNumber(source.Metadata[expansionConfig.ChunkField])
+		if !ok {
+			continue
+		}
+
+		locations[i] = &located{match: match, chunk: chunk{document: document, number: number}, tokens: tokens}
+		merged[locations[i].chunk] = true
+	}
+
+	expanded := make([]*domain.Source, len(sources))
+	expansions := make([]*domain.QueryTraceExpansion, len(sources))
+	for i, source := range sources {
+		expanded[i] = source
+
+		location := locations[i]
+		if location == nil {
+			expansions[i] = &domain.QueryTraceExpansion{}
+			continue
+		}
+		expansions[i] = &domain.QueryTraceExpansion{Chunks: []int64{location.chunk.number}, Tokens: location.tokens}
+
+		lookupInput := &domain.VectorStoreLookupInput{
+			Match: location.match,
+			Limit: expansionConfig.MaxDocumentChunks,
+		}
+		if expansionConfig.Mode == expansionModeNeighbors {
+			lookupInput.Ranges = map[string]*domain.VectorStoreLookupRange{
+				expansionConfig.ChunkField: {
+					Gte: float64(location.chunk.number - int64(expansionConfig.Neighbors)),
+					Lte: float64(location.chunk.number + int64(expansionConfig.Neighbors)),
+				},
+			}
+			lookupInput.Limit = 2*expansionConfig.Neighbors + 1
+		}
+
+		lookupResults, lookupErr := uc.vectorStore.Lookup(ctx, lookupInput)
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		// the passage is still relevant without its context so a failing lookup does not fail the query
+		if lookupErr != nil {
+			uc.logger.WarnContext(ctx, "failed to vector store lookup, keeping the passage unexpanded", slog.String("error", lookupErr.Error()))
+			continue
+		}
+
+		texts := make(map[int64]string, len(lookupResults))
+		for _, lookupResult := range lookupResults {
+			if number, ok := chunkNumber(lookupResult.Metadata[expansionConfig.ChunkField]); ok {
+				texts[number] = lookupResult.Text
+			}
+		}
+
+		var (
+			before, after             []string
+			first, last               = location.chunk.number, location.chunk.number
+			beforeClosed, afterClosed bool
+		)
+		grow := func(number int64) (string, bool, error) {
+			text, ok := texts[number]
+			if !ok || merged[chunk{document: location.chunk.document, number: number}] {
+				return "", false, nil
+			}
+			tokens, err := uc.countTokens(ctx, text)
+			if err != nil {
+				return "", false, err
+			}
+			if used+tokens > expansionConfig.BudgetTokens {
+				return "", false, nil
+			}
+			used += tokens
+			expansions[i].Tokens += tokens
+			merged[chunk{document: location.chunk.document, number: number}] = true
+			return text, true, nil
+		}
+		for !beforeClosed || !afterClosed {
+			if !beforeClosed {
+				text, ok, err := grow(first - 1)
+				if err != nil {
+					return nil, nil, err
+				}
+				if beforeClosed = !ok; ok {
+					first--
+					before = append(before, text)
+				}
+			}
+			if !afterClosed {
+				text, ok, err := grow(last + 1)
+				if err != nil {
+					return nil, nil, err
+				}
+				if afterClosed = !ok; ok {
+					last++
+					after = append(after, text)
+				}
+			}
+		}
+
+		if first == last {
+			continue
+		}
+
+		slices.Reverse(before)
+		expansions[i].Chunks = lo.RangeFrom(first, int(last-first+1))
+		expanded[i] = &domain.Source{
+			Text:        strings.Join(slices.Concat(before, []string{source.Text}, after), expansionChunkSeparator),
+			Score:       source.Score,
+			Metadata:    source.Metadata,
+			Quarantined: source.Quarantined,
+		}
+	}
+
+	return expanded, expansions, nil
+}
+
+// countTokens counts the tokens of text, or nothing without a tokenizer so the budget does not apply.
+func (uc *usecase) countTokens(ctx context.Context, text string) (int, error) {
+	if uc.tokenizer == nil {
+		return 0, nil
+	}
+
+	tokens, err := uc.tokenizer.CountTokens(ctx, text)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to tokenizer count tokens", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return tokens, nil
+}
+
+// documentOf returns the metadata match of the document of a chunk and a key
+// identifying it, or false when a document field is missing or not a scalar.
+func documentOf(documentFields []string, metadata map[string]any) (map[string]any, string, bool) {
+	match := make(map[string]any, len(documentFields))
+	var key strings.Builder
+	for _, field := range documentFields {
+		switch value := metadata[field].(type) {
+		case string, bool, float64:
+			match[field] = value
+			fmt.Fprintf(&key, "%q=%v;", field, value)
+		default:
+			return nil, "", false
+		}
+	}
+	return match, key.String(), true
+}
+
+// chunkNumber returns the integer chunk number of value, as decoded from json or protobuf.
+func chunkNumber(value any) (int64, bool) {
+	switch value := value.(type) {
+	case float64:
+		if value != math.Trunc(value) {
+			return 0, false
+		}
+		return int64(value), true
+	case int64:
+		return value, true
+	case int:
+		return int64(value), true
+	default:
+		return 0, false
+	}
+}
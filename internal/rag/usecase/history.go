@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+)
+
+const (
+	historyOverflowSummarize = "summarize"
+
+	historySummaryPrompt = `Summarize the conversation below between a user and an assistant in at most %d words.
+Keep the facts, names, numbers, decisions and open questions the rest of the conversation may refer to. Reply with only the summary.`
+
+	historySummaryNote = "Summary of the earlier conversation:\n"
+)
+
+var transcriptRoles = map[domain.Role]string{
+	domain.RoleSystem:    "system",
+	domain.RoleAssistant: "assistant",
+	domain.RoleUser:      "user",
+}
+
+// fitContext drops the lowest scored documents until the other messages, the
+// context and the answer fit the context window, as trimming the history can't
+// make room for a context exceeding it alone. The documents of the quarantined
+// sources are not in the context. The kept sources and documents stay in their
+// order. It fails when the other messages alone exceed the context window.
+func (uc *usecase) fitContext(ctx context.Context, config *config.Config, sources []*domain.Source, documents []string, others []*domain.Message) ([]*domain.Source, []string, error) {
+	if uc.tokenizer == nil || len(documents) == 0 {
+		return sources, documents, nil
+	}
+
+	historyConfig := config.HistoryConfig
+
+	// the context message has a single overhead and a separator between the documents
+	budget := historyConfig.ContextWindow - historyConfig.AnswerTokens - historyConfig.MessageOverheadTokens
+	for _, message := range others {
+		tokens, err := uc.countMessageTokens(ctx, historyConfig, message.Content)
+		if err != nil {
+			return nil, nil, err
+		}
+		budget -= tokens
+	}
+	if budget < 0 {
+		err := internal_error.NewValidationError(fmt.Errorf("query exceeds the context window of %d tokens by %d tokens", historyConfig.ContextWindow, -budget))
+		uc.logger.ErrorContext(ctx, "failed to fit context", slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	separatorTokens, err := uc.countTokens(ctx, config.PromptConfig.ContextSeparator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contextTokens := 0
+	documentTokens := make([]int, len(documents))
+	for i, document := range documents {
+		tokens, err := uc.countTokens(ctx, document)
+		if err != nil {
+			return nil, nil, err
+		}
+		documentTokens[i] = tokens + separatorTokens
+		contextTokens += documentTokens[i]
+	}
+
+	if contextTokens <= budget {
+		return sources, documents, nil
+	}
+
+	// the documents are those of the sources not quarantined, in their order
+	documentSources := make([]*domain.Source, 0, len(documents))
+	for _, source := range sources {
+		if !source.Quarantined {
+			documentSources = append(documentSources, source)
+		}
+	}
+
+	// drop the lowest scored documents first, the later ones on equal scores
+	byScore := make([]int, len(documents))
+	for i := range byScore {
+		byScore[i] = i
+	}
+	slices.SortFunc(byScore, func(a, b int) int {
+		return cmp.Or(cmp.Compare(documentSources[a].Score, documentSources[b].Score), cmp.Compare(b, a))
+	})
+
+	dropped := make(map[*domain.Source]bool)
+	for _, index := range byScore {
+		if contextTokens <= budget {
+			break
+		}
+		dropped[documentSources[index]] = true
+		contextTokens -= documentTokens[index]
+	}
+
+	keptSources := make([]*domain.Source, 0, len(sources)-len(dropped))
+	keptDocuments := make([]string, 0, len(documents)-len(dropped))
+	for i, source := range documentSources {
+		if !dropped[source] {
+			keptDocuments = append(keptDocuments, documents[i])
+		}
+	}
+	for _, source := range sources {
+		if !dropped[source] {
+			keptSources = append(keptSources, source)
+		}
+	}
+
+	uc.logger.WarnContext(
+		ctx,
+		"dropped the lowest scored passages exceeding the context window",
+		slog.Int("budget_tokens", budget),
+		slog.Int("dropped_passages", len(dropped)),
+	)
+
+	return keptSources, keptDocuments, nil
+}
+
+// fitHistory returns the newest history messages fitting the context window
+// left by the other chat messages and the answer. The older messages are
+// dropped or, with the summarize overflow, replaced by a summary note. The
+// trim is nil when the whole history fits.
+func (uc *usecase) fitHistory(ctx context.Context, historyConfig config.HistoryConfig, history []*domain.Message, others []*domain.Message) ([]*domain.Message, *domain.HistoryTrim, error) {
+	if uc.tokenizer == nil || len(history) == 0 {
+		return history, nil, nil
+	}
+
+	count := func(content string) (int, error) {
+		return uc.countMessageTokens(ctx, historyConfig, content)
+	}
+
+	budget := historyConfig.ContextWindow - historyConfig.AnswerTokens
+	for _, message := range others {
+		tokens, err := count(message.Content)
+		if err != nil {
+			return nil, nil, err
+		}
+		budget -= tokens
+	}
+
+	historyTokens := 0
+	messageTokens := make([]int, len(history))
+	for i, message := range history {
+		tokens, err := count(message.Content)
+		if err != nil {
+			return nil, nil, err
+		}
+		messageTokens[i] = tokens
+		historyTokens += tokens
+	}
+
+	if historyTokens <= budget {
+		return history, nil, nil
+	}
+
+	summarize := historyConfig.Overflow == historyOverflowSummarize
+
+	available := budget
+	if summarize {
+		available -= historyConfig.SummaryTokens + historyConfig.MessageOverheadTokens
+	}
+
+	// keep the newest messages fitting the budget
+	start, kept := len(history), 0
+	for start > 0 && kept+messageTokens[start-1] <= available {
+		start--
+		kept += messageTokens[start]
+	}
+
+	trim := &domain.HistoryTrim{
+		BudgetTokens:    budget,
+		HistoryTokens:   historyTokens,
+		DroppedMessages: start,
+	}
+	fitted := history[start:]
+
+	if summarize && start > 0 {
+		if note, ok := uc.summarizeHistory(ctx, historyConfig, history[:start]); ok {
+			fitted = append([]*domain.Message{note}, fitted...)
+			trim.Summarized = true
+		}
+	}
+
+	uc.logger.InfoContext(
+		ctx,
+		"trimmed chat history to the context window",
+		slog.Int("budget_tokens", trim.BudgetTokens),
+		slog.Int("history_tokens", trim.HistoryTokens),
+		slog.Int("dropped_messages", trim.DroppedMessages),
+		slog.Bool("summarized", trim.Summarized),
+	)
+
+	return fitted, trim, nil
+}
+
+// countMessageTokens counts the tokens of a chat message with content.
+func (uc *usecase) countMessageTokens(ctx context.Context, historyConfig config.HistoryConfig, content string) (int, error) {
+	tokens, err := uc.countTokens(ctx, content)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tokens: %w", err)
+	}
+	return tokens + historyConfig.MessageOverheadTokens, nil
+}
+
+// summarizeHistory asks the llm to summarize messages into a system note. The
+// messages are only dropped when the summary fails or does not fit.
+func (uc *usecase) summarizeHistory(ctx context.Context, historyConfig config.HistoryConfig, messages []*domain.Message) (*domain.Message, bool) {
+	// about three words per four tokens
+	summary, err := uc.summarize(ctx, "", messages, historyConfig.SummaryTokens*3/4)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to summarize chat history, dropping it", slog.String("error", err.Error()))
+		return nil, false
+	}
+
+	note := historySummaryNote + summary
+
+	tokens, err := uc.tokenizer.CountTokens(ctx, note)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to count chat history summary tokens, dropping it", slog.String("error", err.Error()))
+		return nil, false
+	}
+	if tokens > historyConfig.SummaryTokens {
+		uc.logger.WarnContext(ctx, "chat history summary is too long, dropping it", slog.Int("tokens", tokens))
+		return nil, false
+	}
+
+	return &domain.Message{Role: domain.RoleSystem, Content: note}, true
+}
+
+// summarize asks the llm to summarize messages in at most words, continuing
+// previousSummary of the messages before them when not empty.
+func (uc *usecase) summarize(ctx context.Context, previousSummary string, messages []*domain.Message, words int) (string, error) {
+	var conversation strings.Builder
+	if previousSummary != "" {
+		conversation.WriteString(historySummaryNote + previousSummary + "\n\n")
+	}
+	conversation.WriteString(transcript(messages))
+
+	return uc.complete(ctx, []*domain.Message{
+		{Role: domain.RoleSystem, Content: fmt.Sprintf(historySummaryPrompt, words)},
+		{Role: domain.RoleUser, Content: conversation.String()},
+	})
+}
+
+// complete returns the whole trimmed completion of chat.
+func (uc *usecase) complete(ctx context.Context, chat []*domain.Message) (string, error) {
+	var (
+		completion strings.Builder
+		err        error
+	)
+	uc.llm.StreamCompletion(ctx, chat, func(completionChunk string, handlerErr error) (continueRunning bool) {
+		if handlerErr != nil {
+			err = handlerErr
+			return false
+		}
+		completion.WriteString(completionChunk)
+		return true
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to llm stream completion: %w", err)
+	}
+
+	return strings.TrimSpace(completion.String()), nil
+}
+
+func transcript(messages []*domain.Message) string {
+	var transcript strings.Builder
+	for _, message := range messages {
+		fmt.Fprintf(&transcript, "%s: %s\n", transcriptRoles[message.Role], message.Content)
+	}
+	return transcript.String()
+}
@@ -18,14 +18,55 @@ type (
 
 	VectorStore interface {
 		Search(ctx context.Context, query *domain.VectorStoreSearchInput) ([]*domain.VectorStoreSearchResult, error)
+		// Lookup returns the texts whose metadata match input, in no particular order.
+		Lookup(ctx context.Context, input *domain.VectorStoreLookupInput) ([]*domain.VectorStoreLookupResult, error)
 	}
 
 	Clock interface {
 		TimeNow() time.Time
 	}
 
+	IDGenerator interface {
+		NewID() (string, error)
+	}
+
+	FeedbackStore interface {
+		SaveResponse(ctx context.Context, response *domain.Response) error
+		// GetResponse returns a NotFoundError when no response has the id.
+		GetResponse(ctx context.Context, id string) (*domain.Response, error)
+		SaveFeedback(ctx context.Context, record *domain.FeedbackRecord) error
+	}
+
+	AuditLog interface {
+		// Record must not block nor fail the query so the records are dropped when the log is backed up.
+		Record(ctx context.Context, record *domain.AuditRecord)
+		// Export calls fn with the records created in [from, to) until fn returns false.
+		Export(ctx context.Context, from, to time.Time, fn func(record *domain.AuditRecord) (continueRunning bool)) error
+	}
+
+	Screener interface {
+		// Screen returns the screening of each source, in the sources order.
+		Screen(ctx context.Context, sources []*domain.Source) ([]*domain.PassageScreening, error)
+	}
+
+	Redactor interface {
+		// Redact returns text without the personal data, redacted with the policy of tenant.
+		Redact(ctx context.Context, tenant, text string) string
+	}
+
+	Tokenizer interface {
+		CountTokens(ctx context.Context, text string) (int, error)
+	}
+
+	SummaryCache interface {
+		Get(ctx context.Context, conversationID string) (*domain.ConversationSummary, bool)
+		Put(ctx context.Context, conversationID string, summary *domain.ConversationSummary)
+	}
+
 	UseCase interface {
 		QueryStream(ctx context.Context, input *domain.QueryStreamInput, handler func(event *domain.QueryStreamResultEvent) (continueRunning bool))
 		Query(ctx context.Context, input *domain.QueryInput) (*domain.QueryResult, error)
+		SubmitFeedback(ctx context.Context, input *domain.SubmitFeedbackInput) error
+		ExportAuditRecords(ctx context.Context, input *domain.ExportAuditRecordsInput, handler func(record *domain.AuditRecord) (continueRunning bool)) error
 	}
 )
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/aria3ppp/rag-server/internal/rag/config"
+	"github.com/aria3ppp/rag-server/internal/rag/domain"
+)
+
+const condenseQueryPrompt = `Rewrite the follow up question of the user as a standalone question, resolving its references to the conversation below. Reply with only the question.`
+
+// remember replaces the oldest messages of the histories longer than the
+// threshold with a summary note, keeping the recent messages. The summary of a
+// conversation is cached and rolled forward with the messages aging out of the
+// recent ones, so each message is summarized once. The history is returned as
+// is when summarizing fails.
+func (uc *usecase) remember(ctx context.Context, memoryConfig config.MemoryConfig, conversationID string, history []*domain.Message) ([]*domain.Message, *domain.QueryTraceMemory) {
+	if !memoryConfig.Enabled || len(history) <= memoryConfig.ThresholdMessages {
+		return history, nil
+	}
+
+	older, recent := history[:len(history)-memoryConfig.RecentMessages], history[len(history)-memoryConfig.RecentMessages:]
+
+	// the cached summary is only continued when the client sent the same older messages
+	var cached *domain.ConversationSummary
+	if conversationID != "" && uc.summaryCache != nil {
+		if summary, ok := uc.summaryCache.Get(ctx, conversationID); ok && summary.Messages <= len(older) && summary.Digest == digestMessages(older[:summary.Messages]) {
+			cached = summary
+		}
+	}
+
+	memoryTrace := &domain.QueryTraceMemory{SummarizedMessages: len(older)}
+
+	if cached != nil && cached.Messages == len(older) {
+		memoryTrace.Summary = cached.Summary
+		memoryTrace.Cached = true
+	} else {
+		previousSummary, from := "", 0
+		if cached != nil {
+			previousSummary, from = cached.Summary, cached.Messages
+		}
+
+		summary, err := uc.summarize(ctx, previousSummary, older[from:], memoryConfig.SummaryWords)
+		if err != nil {
+			uc.logger.WarnContext(ctx, "failed to summarize conversation, keeping the whole history", slog.String("error", err.Error()))
+			return history, nil
+		}
+		memoryTrace.Summary = summary
+
+		if conversationID != "" && uc.summaryCache != nil {
+			uc.summaryCache.Put(ctx, conversationID, &domain.ConversationSummary{
+				Messages: len(older),
+				Digest:   digestMessages(older),
+				Summary:  summary,
+			})
+		}
+	}
+
+	remembered := make([]*domain.Message, 0, 1+len(recent))
+	remembered = append(remembered, &domain.Message{Role: domain.RoleSystem, Content: historySummaryNote + memoryTrace.Summary})
+	remembered = append(remembered, recent...)
+
+	return remembered, memoryTrace
+}
+
+// condenseQuery asks the llm to rewrite query into a standalone question
+// using history, or returns query when it fails.
+func (uc *usecase) condenseQuery(ctx context.Context, history []*domain.Message, query string) string {
+	condensed, err := uc.complete(ctx, []*domain.Message{
+		{Role: domain.RoleSystem, Content: condenseQueryPrompt},
+		{Role: domain.RoleUser, Content: transcript(history) + "\nFollow up question: " + query},
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to condense query, retrieving with it as is", slog.String("error", err.Error()))
+		return query
+	}
+	if condensed == "" {
+		return query
+	}
+
+	return condensed
+}
+
+func digestMessages(messages []*domain.Message) string {
+	hash := sha256.New()
+	for _, message := range messages {
+		hash.Write([]byte{byte(message.Role)})
+		hash.Write([]byte(message.Content))
+		// separate the messages so moving text across them changes the digest
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
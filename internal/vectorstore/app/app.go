@@ -9,10 +9,19 @@ import (
 
 	vectorstorev1 "github.com/aria3ppp/rag-server/gen/go/vectorstore/v1"
 	vectorstore_openapiv2 "github.com/aria3ppp/rag-server/gen/openapiv2/vectorstore"
+	internal_config "github.com/aria3ppp/rag-server/internal/pkg/config"
+	"github.com/aria3ppp/rag-server/internal/pkg/injection"
+	"github.com/aria3ppp/rag-server/internal/pkg/pii"
+	"github.com/aria3ppp/rag-server/internal/pkg/ratelimit"
 	"github.com/aria3ppp/rag-server/internal/pkg/server"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/app/gateway"
 	vectorstore_grpc_server "github.com/aria3ppp/rag-server/internal/vectorstore/app/grpc_server"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/bm25"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/chunker"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/embedcache"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/embedder"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/parser"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/qdrant"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/uuid"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/usecase"
@@ -21,6 +30,7 @@ import (
 
 	grpc_gateway_runtime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -31,14 +41,28 @@ import (
 
 func New(
 	ctx context.Context,
-	config *config.Config,
+	reloadableConfig *internal_config.Reloadable[config.Config],
 	slogHandler slog.Handler,
 	tracer trace.Tracer,
+	meter metric.Meter,
 	httpClient *http.Client,
-) (*template_app.App, error) {
+) (_ *template_app.App, err error) {
 	logger := slog.New(slogHandler)
 
-	embedder, err := embedder.NewEmbedder(
+	// cleanups release the resources in reverse order when the app shuts
+	// down, or right away when it fails to be created
+	var cleanups []func() error
+	defer func() {
+		if err != nil {
+			for i := len(cleanups) - 1; i >= 0; i-- {
+				cleanups[i]()
+			}
+		}
+	}()
+
+	config := reloadableConfig.Load()
+
+	defaultEmbedder, err := embedder.NewEmbedder(
 		ctx,
 		config,
 		tracer,
@@ -49,8 +73,44 @@ func New(
 		return nil, fmt.Errorf("failed to embedder.NewEmbedder: %w", err)
 	}
 
+	// the embedders of the collections are wrapped in the embedding cache when it is enabled
+	cacheEmbedder := func(collection string, uncached usecase.Embedder, model string) (usecase.Embedder, error) {
+		return uncached, nil
+	}
+	if config.EmbeddingCacheConfig.Size > 0 {
+		embeddingCache, err := embedcache.NewCache(
+			config,
+			meter,
+			tracer,
+			logger,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embedcache.NewCache: %w", err)
+		}
+		cleanups = append(cleanups, embeddingCache.Close)
+
+		cacheEmbedder = func(collection string, uncached usecase.Embedder, model string) (usecase.Embedder, error) {
+			cachedEmbedder, err := embeddingCache.Embedder(collection, uncached, model)
+			if err != nil {
+				return nil, err
+			}
+			return cachedEmbedder, nil
+		}
+	}
+
+	useCaseEmbedder, err := cacheEmbedder(config.QdrantConfig.CollectionName, defaultEmbedder, defaultEmbedder.Model())
+	if err != nil {
+		return nil, fmt.Errorf("failed to cache the embedder: %w", err)
+	}
+
 	idGenerator := uuid.NewIDGenerator()
 
+	sparseEncoder := bm25.NewSparseEncoder(config)
+
+	documentChunker := chunker.NewChunker()
+
+	documentParser := parser.NewParser()
+
 	vectorRepo, err := qdrant.NewVectorRepo(
 		ctx,
 		config,
@@ -61,10 +121,88 @@ func New(
 		return nil, fmt.Errorf("failed to qdrant.NewVectorRepo: %w", err)
 	}
 
+	// the federated collections reuse the infras with their own embedder and collection
+	collections := make(map[string]*usecase.Collection, len(config.FederationConfig.Collections))
+	for name, collectionConfig := range config.FederationConfig.Collections {
+		federatedConfig := *config
+		federatedConfig.EmbedderConfig.BaseURL = collectionConfig.EmbedderBaseURL
+		federatedConfig.QdrantConfig.CollectionName = name
+		federatedConfig.QdrantConfig.VectorSize = collectionConfig.VectorSize
+		federatedConfig.ChunkingConfig = config.ChunkingConfig.Override(collectionConfig.Chunking)
+		if err := internal_config.Validate(&federatedConfig.ChunkingConfig); err != nil {
+			return nil, fmt.Errorf("failed to validate the chunking of collection %q: %w", name, err)
+		}
+
+		collectionEmbedder, err := embedder.NewEmbedder(
+			ctx,
+			&federatedConfig,
+			tracer,
+			logger,
+			httpClient,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embedder.NewEmbedder of collection %q: %w", name, err)
+		}
+
+		cachedCollectionEmbedder, err := cacheEmbedder(name, collectionEmbedder, collectionEmbedder.Model())
+		if err != nil {
+			return nil, fmt.Errorf("failed to cache the embedder of collection %q: %w", name, err)
+		}
+
+		collectionVectorRepo, err := qdrant.NewVectorRepo(
+			ctx,
+			&federatedConfig,
+			tracer,
+			logger,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to qdrant.NewVectorRepo of collection %q: %w", name, err)
+		}
+
+		collections[name] = &usecase.Collection{
+			Embedder:       cachedCollectionEmbedder,
+			VectorRepo:     collectionVectorRepo,
+			ChunkingConfig: &federatedConfig.ChunkingConfig,
+		}
+	}
+
+	var injectionScanner usecase.InjectionScanner
+	if config.ScreeningConfig.FlagInjections {
+		scanner, err := injection.NewScanner(config.ScreeningConfig.Patterns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to injection.NewScanner: %w", err)
+		}
+		injectionScanner = scanner
+	}
+
+	var redactor usecase.Redactor
+	if config.PIIConfig.Enabled {
+		piiRedactor, err := pii.NewRedactor(
+			pii.Policy{
+				Strategy: config.PIIConfig.Strategy,
+				Types:    config.PIIConfig.Types,
+				Allow:    config.PIIConfig.Allow,
+			},
+			config.PIIConfig.Tenants,
+			config.PIIConfig.HashKey,
+			meter,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pii.NewRedactor: %w", err)
+		}
+		redactor = piiRedactor
+	}
+
 	useCase := usecase.NewUseCase(
-		embedder,
+		useCaseEmbedder,
 		idGenerator,
 		vectorRepo,
+		injectionScanner,
+		redactor,
+		sparseEncoder,
+		documentChunker,
+		documentParser,
+		collections,
 		config,
 		tracer,
 		logger,
@@ -79,7 +217,15 @@ func New(
 	healthServer := health.NewServer()
 	// healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 
-	grpcServer := grpc.NewServer()
+	rateLimiter := ratelimit.New(func() (float64, int) {
+		rateLimitConfig := reloadableConfig.Load().RateLimitConfig
+		return rateLimitConfig.RequestsPerSecond, rateLimitConfig.Burst
+	})
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rateLimiter.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(rateLimiter.StreamServerInterceptor()),
+	)
 
 	vectorstorev1.RegisterVectorStoreServiceServer(grpcServer, vectorStoreGRPCServer)
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
@@ -110,6 +256,8 @@ func New(
 		return nil, fmt.Errorf("failed to vectorstorev1.RegisterVectorStoreServiceHandler: %w", err)
 	}
 
+	mux.HandlePath(http.MethodPost, gateway.UploadDocumentPath, gateway.NewUploadDocumentHandler(mux, vectorstorev1.NewVectorStoreServiceClient(grpcClientConn)))
+
 	// Configure CORS
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   config.ServerConfig.GatewayConfig.AllowedOrigins,
@@ -137,5 +285,5 @@ func New(
 		httpServer,
 	)
 
-	return template_app.New(server.Start, logger), nil
+	return template_app.New(server.Start, logger, cleanups...), nil
 }
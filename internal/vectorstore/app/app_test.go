@@ -180,7 +180,7 @@ func Test_App_InsertTexts_then_SearchText(t *testing.T) {
 		}).
 		Expect().
 		Status(http.StatusOK).
-		JSON().Object().IsEmpty()
+		JSON().Object().Value("ids").Array().Length().IsEqual(10)
 
 	// search text: validation failed
 	e.POST("/api/v1/search_text").
@@ -0,0 +1,156 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	vectorstorev1 "github.com/aria3ppp/rag-server/gen/go/vectorstore/v1"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+
+	goccy_json "github.com/goccy/go-json"
+	grpc_gateway_runtime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	grpc_codes "google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// UploadDocumentPath is the path of the multipart form uploads of the documents.
+	UploadDocumentPath = "/api/v1/upload_document"
+
+	// maxUploadBytes bounds the uploaded forms to the largest content the
+	// usecase takes and some room for the other fields.
+	maxUploadBytes = 3<<20 + 64<<10
+)
+
+// extensionMIMETypes are the mime types of the file extensions missing from
+// the mime types of the system.
+var extensionMIMETypes = map[string]string{
+	".md":       domain.MIMETypeMarkdown,
+	".markdown": domain.MIMETypeMarkdown,
+	".jsonl":    domain.MIMETypeJSONL,
+	".ndjson":   domain.MIMETypeJSONL,
+	".csv":      domain.MIMETypeCSV,
+	".txt":      domain.MIMETypePlain,
+	".html":     domain.MIMETypeHTML,
+	".htm":      domain.MIMETypeHTML,
+}
+
+// NewUploadDocumentHandler sends the documents uploaded as multipart forms to
+// the UploadDocument rpc. The form has the document in its file field and
+// optionally the id, mime_type, metadata (a json object), chunker, size,
+// overlap and collection fields. The mime type defaults to the content type of the file, or
+// else to the mime type of its extension.
+func NewUploadDocumentHandler(mux *grpc_gateway_runtime.ServeMux, client vectorstorev1.VectorStoreServiceClient) grpc_gateway_runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx := r.Context()
+		_, outboundMarshaler := grpc_gateway_runtime.MarshalerForRequest(mux, r)
+
+		req, err := uploadDocumentRequest(w, r)
+		if err != nil {
+			grpc_gateway_runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err())
+			return
+		}
+
+		ctx, err = grpc_gateway_runtime.AnnotateContext(
+			ctx,
+			mux,
+			r,
+			"/vectorstore.v1.VectorStoreService/UploadDocument",
+			grpc_gateway_runtime.WithHTTPPathPattern(UploadDocumentPath),
+		)
+		if err != nil {
+			grpc_gateway_runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		var metadata grpc_gateway_runtime.ServerMetadata
+		resp, err := client.UploadDocument(ctx, req, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+		ctx = grpc_gateway_runtime.NewServerMetadataContext(ctx, metadata)
+		if err != nil {
+			grpc_gateway_runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		grpc_gateway_runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, r, resp)
+	}
+}
+
+// uploadDocumentRequest reads the upload request from the multipart form of r.
+func uploadDocumentRequest(w http.ResponseWriter, r *http.Request) (*vectorstorev1.VectorStoreServiceUploadDocumentRequest, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes)
+	if err := r.ParseMultipartForm(maxUploadBytes); err != nil {
+		return nil, fmt.Errorf("malformed multipart form: %w", err)
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("file: %w", err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("file: %w", err)
+	}
+
+	mimeType := r.FormValue("mime_type")
+	if mimeType == "" {
+		mimeType = fileHeader.Header.Get("Content-Type")
+	}
+	if mimeType == "" || strings.HasPrefix(mimeType, "application/octet-stream") {
+		extension := strings.ToLower(filepath.Ext(fileHeader.Filename))
+		mimeType = extensionMIMETypes[extension]
+		if mimeType == "" {
+			mimeType = mime.TypeByExtension(extension)
+		}
+	}
+	if mimeType == "" {
+		return nil, fmt.Errorf("mime_type: unknown mime type of the file %q", fileHeader.Filename)
+	}
+
+	req := &vectorstorev1.VectorStoreServiceUploadDocumentRequest{
+		Id:         r.FormValue("id"),
+		MimeType:   mimeType,
+		Content:    content,
+		Collection: r.FormValue("collection"),
+	}
+
+	if value := r.FormValue("metadata"); value != "" {
+		var fields map[string]any
+		if err := goccy_json.Unmarshal([]byte(value), &fields); err != nil || fields == nil {
+			return nil, errors.New("metadata: want a json object")
+		}
+		req.Metadata, err = structpb.NewStruct(fields)
+		if err != nil {
+			return nil, fmt.Errorf("metadata: %w", err)
+		}
+	}
+
+	chunker, size, overlap := r.FormValue("chunker"), r.FormValue("size"), r.FormValue("overlap")
+	if chunker != "" || size != "" || overlap != "" {
+		req.Chunking = &vectorstorev1.VectorStoreServiceInsertDocumentsRequestChunking{Chunker: chunker}
+		if size != "" {
+			req.Chunking.Size, err = strconv.ParseInt(size, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("size: want an integer, got %q", size)
+			}
+		}
+		if overlap != "" {
+			value, err := strconv.ParseInt(overlap, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("overlap: want an integer, got %q", overlap)
+			}
+			req.Chunking.Overlap = &value
+		}
+	}
+
+	return req, nil
+}
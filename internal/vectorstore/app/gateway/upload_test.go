@@ -0,0 +1,191 @@
+package gateway_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	vectorstorev1 "github.com/aria3ppp/rag-server/gen/go/vectorstore/v1"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/app/gateway"
+
+	"github.com/google/go-cmp/cmp"
+	grpc_gateway_runtime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fakeClient records the UploadDocument request.
+type fakeClient struct {
+	vectorstorev1.VectorStoreServiceClient
+	req *vectorstorev1.VectorStoreServiceUploadDocumentRequest
+}
+
+func (c *fakeClient) UploadDocument(_ context.Context, req *vectorstorev1.VectorStoreServiceUploadDocumentRequest, _ ...grpc.CallOption) (*vectorstorev1.VectorStoreServiceUploadDocumentResponse, error) {
+	c.req = req
+	return &vectorstorev1.VectorStoreServiceUploadDocumentResponse{Id: req.Id, ChunkIds: []string{"8f2b6a52-3c57-5d43-9f0b-4e1d2c7a9b10"}}, nil
+}
+
+// form is a file of a multipart form and its other fields.
+type form struct {
+	filename    string
+	contentType string
+	content     string
+	fields      map[string]string
+}
+
+func (f *form) request(t *testing.T) *http.Request {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, value := range f.fields {
+		if err := writer.WriteField(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if f.filename != "" {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+f.filename+`"`)
+		if f.contentType != "" {
+			header.Set("Content-Type", f.contentType)
+		}
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(f.content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, gateway.UploadDocumentPath, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func Test_UploadDocumentHandler(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status int
+		req    *vectorstorev1.VectorStoreServiceUploadDocumentRequest
+	}
+
+	type testCase struct {
+		name string
+		form *form
+		want want
+	}
+
+	metadata, err := structpb.NewStruct(map[string]any{"source": "docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []testCase{
+		{
+			name: "ok",
+			form: &form{
+				filename:    "intro.html",
+				contentType: "text/html; charset=utf-8",
+				content:     "<p>Intro</p>",
+				fields: map[string]string{
+					"id":         "docs/intro.html",
+					"metadata":   `{"source": "docs"}`,
+					"chunker":    "markdown",
+					"size":       "800",
+					"overlap":    "0",
+					"collection": "docs",
+				},
+			},
+			want: want{
+				status: http.StatusOK,
+				req: &vectorstorev1.VectorStoreServiceUploadDocumentRequest{
+					Id:         "docs/intro.html",
+					MimeType:   "text/html; charset=utf-8",
+					Content:    []byte("<p>Intro</p>"),
+					Metadata:   metadata,
+					Chunking:   &vectorstorev1.VectorStoreServiceInsertDocumentsRequestChunking{Chunker: "markdown", Size: 800, Overlap: new(int64)},
+					Collection: "docs",
+				},
+			},
+		},
+		{
+			name: "ok mime type of extension",
+			form: &form{
+				filename:    "faq.JSONL",
+				contentType: "application/octet-stream",
+				content:     `{"text": "faq"}`,
+			},
+			want: want{
+				status: http.StatusOK,
+				req: &vectorstorev1.VectorStoreServiceUploadDocumentRequest{
+					MimeType: "application/jsonl",
+					Content:  []byte(`{"text": "faq"}`),
+				},
+			},
+		},
+		{
+			name: "ok mime type field",
+			form: &form{
+				filename: "notes",
+				content:  "notes",
+				fields:   map[string]string{"mime_type": "text/plain"},
+			},
+			want: want{
+				status: http.StatusOK,
+				req: &vectorstorev1.VectorStoreServiceUploadDocumentRequest{
+					MimeType: "text/plain",
+					Content:  []byte("notes"),
+				},
+			},
+		},
+		{
+			name: "no file",
+			form: &form{fields: map[string]string{"id": "a"}},
+			want: want{status: http.StatusBadRequest},
+		},
+		{
+			name: "unknown mime type",
+			form: &form{filename: "notes", content: "notes"},
+			want: want{status: http.StatusBadRequest},
+		},
+		{
+			name: "malformed metadata",
+			form: &form{filename: "a.txt", content: "a", fields: map[string]string{"metadata": "[1]"}},
+			want: want{status: http.StatusBadRequest},
+		},
+		{
+			name: "malformed size",
+			form: &form{filename: "a.txt", content: "a", fields: map[string]string{"size": "large"}},
+			want: want{status: http.StatusBadRequest},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := grpc_gateway_runtime.NewServeMux()
+			client := &fakeClient{}
+			handler := gateway.NewUploadDocumentHandler(mux, client)
+
+			recorder := httptest.NewRecorder()
+			handler(recorder, tt.form.request(t), nil)
+
+			if recorder.Code != tt.want.status {
+				t.Fatal(cmp.Diff(recorder.Code, tt.want.status), recorder.Body.String())
+			}
+
+			if diff := cmp.Diff(tt.want.req, client.req, protocmp.Transform()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/samber/lo"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 	grpc_codes "google.golang.org/grpc/codes"
 	grpc_status "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -50,16 +51,21 @@ func (grpcServer *grpcServer) InsertTexts(ctx context.Context, req *vectorstorev
 
 	texts := lo.Map(req.Texts, func(item *vectorstorev1.VectorStoreServiceInsertTextsRequestText, _ int) *domain.InsertTextsInputText {
 		return &domain.InsertTextsInputText{
+			ID:       item.Id,
 			Text:     item.Text,
 			Metadata: item.Metadata.AsMap(),
 		}
 	})
 
 	insertTextsInput := &domain.InsertTextsInput{
-		Texts: texts,
+		Texts:          texts,
+		IDMode:         req.IdMode,
+		IDNamespace:    req.IdNamespace,
+		DedupThreshold: req.DedupThreshold,
 	}
 
-	if err := grpcServer.uc.InsertTexts(ctx, insertTextsInput); err != nil {
+	insertTextsResult, err := grpcServer.uc.InsertTexts(ctx, insertTextsInput)
+	if err != nil {
 		grpcServer.logger.ErrorContext(ctx, "failed to usecase insert texts", slog.String("error", err.Error()))
 		if _, ok := err.(*internal_error.ValidationError); ok {
 			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
@@ -67,11 +73,162 @@ func (grpcServer *grpcServer) InsertTexts(ctx context.Context, req *vectorstorev
 		return nil, err
 	}
 
-	vectorStoreServiceInsertTextsResponse := &vectorstorev1.VectorStoreServiceInsertTextsResponse{}
+	skipped := lo.Map(insertTextsResult.Skipped, func(item *domain.InsertTextsResultSkipped, _ int) *vectorstorev1.VectorStoreServiceInsertTextsResponseSkipped {
+		return &vectorstorev1.VectorStoreServiceInsertTextsResponseSkipped{
+			Index:       int64(item.Index),
+			DuplicateId: item.DuplicateID,
+			Score:       item.Score,
+		}
+	})
+
+	vectorStoreServiceInsertTextsResponse := &vectorstorev1.VectorStoreServiceInsertTextsResponse{
+		Ids:     insertTextsResult.IDs,
+		Skipped: skipped,
+	}
 
 	return vectorStoreServiceInsertTextsResponse, nil
 }
 
+func (grpcServer *grpcServer) StreamInsertTexts(stream grpc.ClientStreamingServer[vectorstorev1.VectorStoreServiceStreamInsertTextsRequest, vectorstorev1.VectorStoreServiceStreamInsertTextsResponse]) (err error) {
+	ctx := stream.Context()
+
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.StreamInsertTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	streamInsertTextsResult, err := grpcServer.uc.StreamInsertTexts(ctx, func() (*domain.InsertTextsInputText, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return &domain.InsertTextsInputText{
+			ID:       req.Id,
+			Text:     req.Text,
+			Metadata: req.Metadata.AsMap(),
+		}, nil
+	})
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase stream insert texts", slog.String("error", err.Error()))
+		return err
+	}
+
+	vectorStoreServiceStreamInsertTextsResponse := &vectorstorev1.VectorStoreServiceStreamInsertTextsResponse{
+		FailedItems: lo.Map(streamInsertTextsResult.FailedItems, func(item *domain.StreamInsertTextsResultItem, _ int) *vectorstorev1.VectorStoreServiceStreamInsertTextsResponseItem {
+			return &vectorstorev1.VectorStoreServiceStreamInsertTextsResponseItem{
+				Index: int64(item.Index),
+				Error: item.Error,
+			}
+		}),
+		Inserted: int64(streamInsertTextsResult.Inserted),
+		Failed:   int64(streamInsertTextsResult.Failed),
+	}
+
+	return stream.SendAndClose(vectorStoreServiceStreamInsertTextsResponse)
+}
+
+func (grpcServer *grpcServer) InsertDocuments(ctx context.Context, req *vectorstorev1.VectorStoreServiceInsertDocumentsRequest) (_ *vectorstorev1.VectorStoreServiceInsertDocumentsResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.InsertDocuments")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	documents := lo.Map(req.Documents, func(item *vectorstorev1.VectorStoreServiceInsertDocumentsRequestDocument, _ int) *domain.InsertDocumentsInputDocument {
+		return &domain.InsertDocumentsInputDocument{
+			ID:       item.Id,
+			Text:     item.Text,
+			Metadata: item.Metadata.AsMap(),
+		}
+	})
+
+	insertDocumentsInput := &domain.InsertDocumentsInput{
+		Documents:  documents,
+		Chunking:   insertDocumentsChunking(req.Chunking),
+		Collection: req.Collection,
+	}
+
+	insertDocumentsResult, err := grpcServer.uc.InsertDocuments(ctx, insertDocumentsInput)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase insert documents", slog.String("error", err.Error()))
+		if _, ok := err.(*internal_error.ValidationError); ok {
+			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+		}
+		return nil, err
+	}
+
+	vectorStoreServiceInsertDocumentsResponse := &vectorstorev1.VectorStoreServiceInsertDocumentsResponse{
+		Documents: lo.Map(insertDocumentsResult.Documents, func(item *domain.InsertDocumentsResultDocument, _ int) *vectorstorev1.VectorStoreServiceInsertDocumentsResponseDocument {
+			return &vectorstorev1.VectorStoreServiceInsertDocumentsResponseDocument{
+				Id:       item.ID,
+				ChunkIds: item.ChunkIDs,
+			}
+		}),
+	}
+
+	return vectorStoreServiceInsertDocumentsResponse, nil
+}
+
+func (grpcServer *grpcServer) UploadDocument(ctx context.Context, req *vectorstorev1.VectorStoreServiceUploadDocumentRequest) (_ *vectorstorev1.VectorStoreServiceUploadDocumentResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.UploadDocument")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	uploadDocumentInput := &domain.UploadDocumentInput{
+		ID:         req.Id,
+		MIMEType:   req.MimeType,
+		Content:    req.Content,
+		Metadata:   req.Metadata.AsMap(),
+		Chunking:   insertDocumentsChunking(req.Chunking),
+		Collection: req.Collection,
+	}
+
+	uploadDocumentResult, err := grpcServer.uc.UploadDocument(ctx, uploadDocumentInput)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase upload document", slog.String("error", err.Error()))
+		if _, ok := err.(*internal_error.ValidationError); ok {
+			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+		}
+		return nil, err
+	}
+
+	vectorStoreServiceUploadDocumentResponse := &vectorstorev1.VectorStoreServiceUploadDocumentResponse{
+		Id:       uploadDocumentResult.ID,
+		ChunkIds: uploadDocumentResult.ChunkIDs,
+	}
+
+	return vectorStoreServiceUploadDocumentResponse, nil
+}
+
+// insertDocumentsChunking returns the chunking overrides of chunking, nil when
+// it is nil.
+func insertDocumentsChunking(chunking *vectorstorev1.VectorStoreServiceInsertDocumentsRequestChunking) *domain.InsertDocumentsChunking {
+	if chunking == nil {
+		return nil
+	}
+
+	result := &domain.InsertDocumentsChunking{
+		Chunker: chunking.Chunker,
+		Size:    int(chunking.Size),
+	}
+	if chunking.Overlap != nil {
+		result.Overlap = lo.ToPtr(int(chunking.GetOverlap()))
+	}
+	return result
+}
+
 func (grpcServer *grpcServer) SearchText(ctx context.Context, req *vectorstorev1.VectorStoreServiceSearchTextRequest) (_ *vectorstorev1.VectorStoreServiceSearchTextResponse, err error) {
 	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.SearchText")
 	defer func() {
@@ -87,6 +244,26 @@ func (grpcServer *grpcServer) SearchText(ctx context.Context, req *vectorstorev1
 		TopK:     int(req.TopK),
 		MinScore: req.MinScore,
 		Filter:   req.Filter.AsMap(),
+		Mode:     req.Mode,
+		Fusion:   req.Fusion,
+	}
+	if req.Mmr != nil {
+		searchTextInput.MMR = &domain.SearchTextMMR{
+			Lambda: req.Mmr.GetLambda(),
+			FetchK: int(req.Mmr.GetFetchK()),
+		}
+	}
+	if req.Federation != nil {
+		searchTextInput.Federation = &domain.SearchTextFederation{
+			Collections: make([]*domain.SearchTextFederationCollection, 0, len(req.Federation.GetCollections())),
+			Fusion:      req.Federation.GetFusion(),
+		}
+		for _, collection := range req.Federation.GetCollections() {
+			searchTextInput.Federation.Collections = append(searchTextInput.Federation.Collections, &domain.SearchTextFederationCollection{
+				Name:   collection.GetName(),
+				Weight: collection.GetWeight(),
+			})
+		}
 	}
 
 	searchTextResults, err := grpcServer.uc.SearchText(ctx, searchTextInput)
@@ -123,3 +300,239 @@ func (grpcServer *grpcServer) SearchText(ctx context.Context, req *vectorstorev1
 
 	return vectorStoreServiceSearchTextResponse, nil
 }
+
+func (grpcServer *grpcServer) LookupTexts(ctx context.Context, req *vectorstorev1.VectorStoreServiceLookupTextsRequest) (_ *vectorstorev1.VectorStoreServiceLookupTextsResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.LookupTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	var ranges map[string]*domain.LookupTextsRange
+	if len(req.Ranges) > 0 {
+		ranges = lo.MapValues(req.Ranges, func(r *vectorstorev1.VectorStoreServiceLookupTextsRequestRange, _ string) *domain.LookupTextsRange {
+			return &domain.LookupTextsRange{
+				Gte: r.GetGte(),
+				Lte: r.GetLte(),
+			}
+		})
+	}
+
+	var match map[string]any
+	if len(req.Match.GetFields()) > 0 {
+		match = req.Match.AsMap()
+	}
+
+	lookupTextsInput := &domain.LookupTextsInput{
+		Match:  match,
+		Ranges: ranges,
+		Limit:  int(req.Limit),
+	}
+
+	lookupTextsResult, err := grpcServer.uc.LookupTexts(ctx, lookupTextsInput)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase lookup texts", slog.String("error", err.Error()))
+		if _, ok := err.(*internal_error.ValidationError); ok {
+			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+		}
+		return nil, err
+	}
+
+	texts := make([]*vectorstorev1.VectorStoreServiceLookupTextsResponseText, 0, len(lookupTextsResult.Texts))
+
+	for _, text := range lookupTextsResult.Texts {
+		metadata, err := structpb.NewStruct(text.Metadata)
+		if err != nil {
+			grpcServer.logger.ErrorContext(ctx, "failed to structpb new struct", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		texts = append(
+			texts,
+			&vectorstorev1.VectorStoreServiceLookupTextsResponseText{
+				Text:     text.Text,
+				Metadata: metadata,
+			},
+		)
+	}
+
+	vectorStoreServiceLookupTextsResponse := &vectorstorev1.VectorStoreServiceLookupTextsResponse{
+		Texts: texts,
+	}
+
+	return vectorStoreServiceLookupTextsResponse, nil
+}
+
+func (grpcServer *grpcServer) GetTexts(ctx context.Context, req *vectorstorev1.VectorStoreServiceGetTextsRequest) (_ *vectorstorev1.VectorStoreServiceGetTextsResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.GetTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	getTextsInput := &domain.GetTextsInput{
+		IDs:         req.Ids,
+		WithVectors: req.WithVectors,
+	}
+
+	getTextsResult, err := grpcServer.uc.GetTexts(ctx, getTextsInput)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase get texts", slog.String("error", err.Error()))
+		if _, ok := err.(*internal_error.ValidationError); ok {
+			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+		}
+		return nil, err
+	}
+
+	texts, err := grpcServer.storedTexts(ctx, getTextsResult.Texts)
+	if err != nil {
+		return nil, err
+	}
+
+	vectorStoreServiceGetTextsResponse := &vectorstorev1.VectorStoreServiceGetTextsResponse{
+		Texts: texts,
+	}
+
+	return vectorStoreServiceGetTextsResponse, nil
+}
+
+func (grpcServer *grpcServer) ListTexts(ctx context.Context, req *vectorstorev1.VectorStoreServiceListTextsRequest) (_ *vectorstorev1.VectorStoreServiceListTextsResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.ListTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	var filter map[string]any
+	if len(req.Filter.GetFields()) > 0 {
+		filter = req.Filter.AsMap()
+	}
+
+	listTextsInput := &domain.ListTextsInput{
+		Filter:      filter,
+		Limit:       int(req.Limit),
+		Cursor:      req.Cursor,
+		WithVectors: req.WithVectors,
+	}
+
+	listTextsResult, err := grpcServer.uc.ListTexts(ctx, listTextsInput)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase list texts", slog.String("error", err.Error()))
+		if _, ok := err.(*internal_error.ValidationError); ok {
+			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+		}
+		return nil, err
+	}
+
+	texts, err := grpcServer.storedTexts(ctx, listTextsResult.Texts)
+	if err != nil {
+		return nil, err
+	}
+
+	vectorStoreServiceListTextsResponse := &vectorstorev1.VectorStoreServiceListTextsResponse{
+		Texts:      texts,
+		NextCursor: listTextsResult.NextCursor,
+	}
+
+	return vectorStoreServiceListTextsResponse, nil
+}
+
+func (grpcServer *grpcServer) storedTexts(ctx context.Context, storedTexts []*domain.StoredText) ([]*vectorstorev1.VectorStoreServiceStoredText, error) {
+	texts := make([]*vectorstorev1.VectorStoreServiceStoredText, 0, len(storedTexts))
+
+	for _, text := range storedTexts {
+		metadata, err := structpb.NewStruct(text.Metadata)
+		if err != nil {
+			grpcServer.logger.ErrorContext(ctx, "failed to structpb new struct", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		texts = append(
+			texts,
+			&vectorstorev1.VectorStoreServiceStoredText{
+				Id:       text.ID,
+				Text:     text.Text,
+				Metadata: metadata,
+				Vector:   text.Vector,
+			},
+		)
+	}
+
+	return texts, nil
+}
+
+func (grpcServer *grpcServer) DeleteTexts(ctx context.Context, req *vectorstorev1.VectorStoreServiceDeleteTextsRequest) (_ *vectorstorev1.VectorStoreServiceDeleteTextsResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.DeleteTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	deleteTextsInput := &domain.DeleteTextsInput{
+		IDs:    req.Ids,
+		DryRun: req.DryRun,
+	}
+
+	deleteTextsResult, err := grpcServer.uc.DeleteTexts(ctx, deleteTextsInput)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase delete texts", slog.String("error", err.Error()))
+		if _, ok := err.(*internal_error.ValidationError); ok {
+			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+		}
+		return nil, err
+	}
+
+	vectorStoreServiceDeleteTextsResponse := &vectorstorev1.VectorStoreServiceDeleteTextsResponse{
+		Deleted: int64(deleteTextsResult.Deleted),
+	}
+
+	return vectorStoreServiceDeleteTextsResponse, nil
+}
+
+func (grpcServer *grpcServer) DeleteByFilter(ctx context.Context, req *vectorstorev1.VectorStoreServiceDeleteByFilterRequest) (_ *vectorstorev1.VectorStoreServiceDeleteByFilterResponse, err error) {
+	ctx, span := grpcServer.tracer.Start(ctx, "grpcServer.DeleteByFilter")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	var filter map[string]any
+	if len(req.Filter.GetFields()) > 0 {
+		filter = req.Filter.AsMap()
+	}
+
+	deleteByFilterInput := &domain.DeleteByFilterInput{
+		Filter: filter,
+		DryRun: req.DryRun,
+	}
+
+	deleteByFilterResult, err := grpcServer.uc.DeleteByFilter(ctx, deleteByFilterInput)
+	if err != nil {
+		grpcServer.logger.ErrorContext(ctx, "failed to usecase delete by filter", slog.String("error", err.Error()))
+		if _, ok := err.(*internal_error.ValidationError); ok {
+			return nil, grpc_status.New(grpc_codes.InvalidArgument, err.Error()).Err()
+		}
+		return nil, err
+	}
+
+	vectorStoreServiceDeleteByFilterResponse := &vectorstorev1.VectorStoreServiceDeleteByFilterResponse{
+		Deleted: int64(deleteByFilterResult.Deleted),
+	}
+
+	return vectorStoreServiceDeleteByFilterResponse, nil
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/bm25"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/qdrant"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MigrateLegacyCollections copies the default and the federated collections
+// created without the sparse vectors into their copies with the sparse
+// vectors, served by the vectorstores started after it completes.
+func MigrateLegacyCollections(ctx context.Context, config *config.Config, tracer trace.Tracer, logger *slog.Logger) error {
+	sparseEncoder := bm25.NewSparseEncoder(config)
+
+	if err := qdrant.MigrateLegacyCollection(ctx, config, sparseEncoder, tracer, logger); err != nil {
+		return fmt.Errorf("failed to migrate collection %q: %w", config.QdrantConfig.CollectionName, err)
+	}
+
+	for name, collectionConfig := range config.FederationConfig.Collections {
+		federatedConfig := *config
+		federatedConfig.QdrantConfig.CollectionName = name
+		federatedConfig.QdrantConfig.VectorSize = collectionConfig.VectorSize
+
+		if err := qdrant.MigrateLegacyCollection(ctx, &federatedConfig, sparseEncoder, tracer, logger); err != nil {
+			return fmt.Errorf("failed to migrate collection %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
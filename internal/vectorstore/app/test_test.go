@@ -12,6 +12,8 @@ import (
 	"testing"
 	"time"
 
+	internal_config "github.com/aria3ppp/rag-server/internal/pkg/config"
+	"github.com/aria3ppp/rag-server/internal/pkg/test/cassette"
 	test_port "github.com/aria3ppp/rag-server/internal/pkg/test/port"
 	test_server "github.com/aria3ppp/rag-server/internal/pkg/test/server"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/app"
@@ -20,6 +22,7 @@ import (
 
 	goccy_json "github.com/goccy/go-json"
 	"github.com/google/go-cmp/cmp"
+	otel_metric_noop "go.opentelemetry.io/otel/metric/noop"
 	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
 )
 
@@ -36,8 +39,10 @@ func setupVectorStoreApp(t *testing.T, waitOpts *wait.Opts) (grpcGatewayPort uin
 	})
 	t.Cleanup(cleanup)
 
+	recorder := cassette.NewTest(t, t.Name(), nil)
+
 	embedderBaseURL := fmt.Sprintf("http://localhost:%d/v1", embedderPort)
-	embedderEmbeddingSize := test_server.GetEmbedderEmbeddingSize(t, embedderBaseURL)
+	embedderEmbeddingSize := test_server.GetEmbedderEmbeddingSize(t, embedderBaseURL, recorder.Client())
 
 	config := &config.Config{
 		ServerConfig: config.ServerConfig{
@@ -66,10 +71,11 @@ func setupVectorStoreApp(t *testing.T, waitOpts *wait.Opts) (grpcGatewayPort uin
 
 	app, err := app.New(
 		ctx,
-		config,
+		internal_config.NewReloadable(config),
 		slogHandler,
 		tracer,
-		http.DefaultClient,
+		otel_metric_noop.NewMeterProvider().Meter(""),
+		recorder.Client(),
 	)
 	if err != nil {
 		t.Fatal(cmp.Diff(err, nil))
@@ -135,7 +141,7 @@ func setupVectorStoreApp(t *testing.T, waitOpts *wait.Opts) (grpcGatewayPort uin
 func TestMain(m *testing.M) {
 	const embedderTestcontainer = "embedder_testcontainer"
 
-	ports, cleanup := test_server.SetupServers(
+	ports, cleanup := test_server.SetupModelServers(
 		test_server.NewFatalizer(runtime.FuncForPC(func() uintptr { pc, _, _, _ := runtime.Caller(1); return pc }()).Name()),
 		map[string]test_server.TestServerFunc{
 			embedderTestcontainer: test_server.SetupEmbedderServer,
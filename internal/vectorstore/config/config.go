@@ -1,35 +1,160 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/pkg/pii"
+)
 
 type Config struct {
-	ServerConfig   ServerConfig
-	EmbedderConfig EmbedderConfig
-	QdrantConfig   QdrantConfig
+	ServerConfig         ServerConfig         `yaml:"server" toml:"server"`
+	LogConfig            LogConfig            `yaml:"log" toml:"log" reload:"true"`
+	RateLimitConfig      RateLimitConfig      `yaml:"rate_limit" toml:"rate_limit" reload:"true"`
+	EmbedderConfig       EmbedderConfig       `yaml:"embedder" toml:"embedder"`
+	EmbeddingCacheConfig EmbeddingCacheConfig `yaml:"embedding_cache" toml:"embedding_cache"`
+	QdrantConfig         QdrantConfig         `yaml:"qdrant" toml:"qdrant"`
+	SparseConfig         SparseConfig         `yaml:"sparse" toml:"sparse"`
+	ChunkingConfig       ChunkingConfig       `yaml:"chunking" toml:"chunking"`
+	StreamingConfig      StreamingConfig      `yaml:"streaming" toml:"streaming"`
+	FederationConfig     FederationConfig     `yaml:"federation" toml:"federation"`
+	ScreeningConfig      ScreeningConfig      `yaml:"screening" toml:"screening"`
+	PIIConfig            PIIConfig            `yaml:"pii" toml:"pii"`
 }
 
 type ServerConfig struct {
-	GRPCConfig              GRPCConfig
-	GatewayConfig           GatewayConfig
-	GracefulShutdownTimeout time.Duration `env:"VECTORSTORE_SERVER_GRACEFUL_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	GRPCConfig              GRPCConfig    `yaml:"grpc" toml:"grpc"`
+	GatewayConfig           GatewayConfig `yaml:"gateway" toml:"gateway"`
+	GracefulShutdownTimeout time.Duration `env:"VECTORSTORE_SERVER_GRACEFUL_SHUTDOWN_TIMEOUT" envDefault:"30s" yaml:"graceful_shutdown_timeout" toml:"graceful_shutdown_timeout"`
 }
 
 type GRPCConfig struct {
-	Port uint16 `env:"VECTORSTORE_SERVER_GRPC_PORT" envDefault:"9091"`
+	Port uint16 `env:"VECTORSTORE_SERVER_GRPC_PORT" envDefault:"9091" yaml:"port" toml:"port"`
 }
 
 type GatewayConfig struct {
-	Port           uint16   `env:"VECTORSTORE_SERVER_GATEWAY_PORT" envDefault:"8080"`
-	AllowedOrigins []string `env:"VECTORSTORE_SERVER_GATEWAY_ALLOWED_ORIGINS"`
+	Port           uint16   `env:"VECTORSTORE_SERVER_GATEWAY_PORT" envDefault:"8080" yaml:"port" toml:"port"`
+	AllowedOrigins []string `env:"VECTORSTORE_SERVER_GATEWAY_ALLOWED_ORIGINS" yaml:"allowed_origins" toml:"allowed_origins"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error. Empty uses the build profile default.
+	Level string `env:"VECTORSTORE_LOG_LEVEL" yaml:"level" toml:"level" validate:"omitempty,oneof=debug info warn error"`
+}
+
+type RateLimitConfig struct {
+	// RequestsPerSecond limits the requests served by the grpc server (and so the gateway). Zero disables it.
+	RequestsPerSecond float64 `env:"VECTORSTORE_RATE_LIMIT_REQUESTS_PER_SECOND" yaml:"requests_per_second" toml:"requests_per_second" validate:"min=0"`
+	Burst             int     `env:"VECTORSTORE_RATE_LIMIT_BURST" envDefault:"1" yaml:"burst" toml:"burst" validate:"min=1"`
 }
 
 type EmbedderConfig struct {
-	BaseURL string `env:"EMBEDDER_BASEURL,notEmpty"`
+	BaseURL string `env:"EMBEDDER_BASEURL,notEmpty" yaml:"base_url" toml:"base_url"`
+	// MaxBatchSize bounds the texts of an embedding request. 0 doesn't bound them.
+	MaxBatchSize int `env:"EMBEDDER_MAX_BATCH_SIZE" envDefault:"32" yaml:"max_batch_size" toml:"max_batch_size" validate:"min=0"`
+	// MaxBatchTokens bounds the estimated tokens of an embedding request, e.g.
+	// to the llama.cpp batch size. A longer text is sent alone. 0 doesn't bound them.
+	MaxBatchTokens int `env:"EMBEDDER_MAX_BATCH_TOKENS" envDefault:"2048" yaml:"max_batch_tokens" toml:"max_batch_tokens" validate:"min=0"`
+	// Concurrency bounds the embedding requests in flight of an embedded set of texts.
+	Concurrency int `env:"EMBEDDER_CONCURRENCY" envDefault:"4" yaml:"concurrency" toml:"concurrency" validate:"min=1"`
+}
+
+type EmbeddingCacheConfig struct {
+	// Size is the embeddings kept in memory, the least recently used evicted
+	// first. 0 disables the cache.
+	Size int `env:"VECTORSTORE_EMBEDDING_CACHE_SIZE" envDefault:"10000" yaml:"size" toml:"size" validate:"min=0"`
+	// StorePath is the bbolt file persisting the cached embeddings across
+	// restarts. Empty keeps them in memory only.
+	StorePath string `env:"VECTORSTORE_EMBEDDING_CACHE_STORE_PATH" yaml:"store_path" toml:"store_path"`
+	// StoreTTL deletes the stored embeddings older than it, checked every
+	// hour, bounding the store file. 0 keeps them forever.
+	StoreTTL time.Duration `env:"VECTORSTORE_EMBEDDING_CACHE_STORE_TTL" envDefault:"720h" yaml:"store_ttl" toml:"store_ttl" validate:"min=0"`
 }
 
 type QdrantConfig struct {
-	Host           string `env:"QDRANT_HOST,notEmpty"`
-	GRPCPort       uint16 `env:"QDRANT_GRPC_PORT,notEmpty"`
-	CollectionName string `env:"QDRANT_COLLECTION_NAME,notEmpty"`
-	VectorSize     int    `env:"QDRANT_VECTOR_SIZE,notEmpty"`
+	Host           string `env:"QDRANT_HOST,notEmpty" yaml:"host" toml:"host"`
+	GRPCPort       uint16 `env:"QDRANT_GRPC_PORT,notEmpty" yaml:"grpc_port" toml:"grpc_port"`
+	CollectionName string `env:"QDRANT_COLLECTION_NAME,notEmpty" yaml:"collection_name" toml:"collection_name"`
+	VectorSize     int    `env:"QDRANT_VECTOR_SIZE,notEmpty" yaml:"vector_size" toml:"vector_size"`
+}
+
+type SparseConfig struct {
+	// K1 bounds the weight of the repeated terms of a text.
+	K1 float32 `env:"VECTORSTORE_SPARSE_K1" envDefault:"1.2" yaml:"k1" toml:"k1" validate:"gt=0"`
+	// B normalizes the term weights by the text length, from 0 (not at all) to 1 (fully).
+	B float32 `env:"VECTORSTORE_SPARSE_B" envDefault:"0.75" yaml:"b" toml:"b" validate:"min=0,max=1"`
+	// AverageLength is the average number of terms of the inserted texts.
+	AverageLength float32 `env:"VECTORSTORE_SPARSE_AVERAGE_LENGTH" envDefault:"256" yaml:"average_length" toml:"average_length" validate:"gt=0"`
+}
+
+// ChunkingConfig is the default splitting of the documents inserted into the collection.
+type ChunkingConfig struct {
+	// Chunker is one of fixed, sentence, recursive or markdown.
+	Chunker string `env:"VECTORSTORE_CHUNKING_CHUNKER" envDefault:"recursive" yaml:"chunker" toml:"chunker" validate:"oneof=fixed sentence recursive markdown"`
+	// Size is the maximum number of characters of a chunk.
+	Size int `env:"VECTORSTORE_CHUNKING_SIZE" envDefault:"1000" yaml:"size" toml:"size" validate:"min=2,max=2500"`
+	// Overlap is the number of characters a chunk repeats from the end of the previous one, at most.
+	Overlap int `env:"VECTORSTORE_CHUNKING_OVERLAP" envDefault:"100" yaml:"overlap" toml:"overlap" validate:"min=0,ltfield=Size"`
+}
+
+// ChunkingOverride overrides the fields of a ChunkingConfig it sets.
+type ChunkingOverride struct {
+	Chunker string `yaml:"chunker" toml:"chunker" validate:"omitempty,oneof=fixed sentence recursive markdown"`
+	Size    int    `yaml:"size" toml:"size" validate:"omitempty,min=2,max=2500"`
+	Overlap *int   `yaml:"overlap" toml:"overlap" validate:"omitempty,min=0"`
+}
+
+// Override returns the chunking config with the fields override sets.
+func (chunking ChunkingConfig) Override(override ChunkingOverride) ChunkingConfig {
+	if override.Chunker != "" {
+		chunking.Chunker = override.Chunker
+	}
+	if override.Size != 0 {
+		chunking.Size = override.Size
+	}
+	if override.Overlap != nil {
+		chunking.Overlap = *override.Overlap
+	}
+	return chunking
+}
+
+type StreamingConfig struct {
+	// BatchSize is the number of streamed texts embedded and inserted together.
+	BatchSize int `env:"VECTORSTORE_STREAMING_BATCH_SIZE" envDefault:"64" yaml:"batch_size" toml:"batch_size" validate:"min=1,max=1000"`
+}
+
+type FederationConfig struct {
+	// Collections are the other qdrant collections searchable and insertable by name, each embedded with its own model.
+	Collections map[string]FederatedCollectionConfig `yaml:"collections" toml:"collections" validate:"dive"`
+}
+
+type FederatedCollectionConfig struct {
+	// EmbedderBaseURL is the openai compatible embeddings api of the collection model.
+	EmbedderBaseURL string `yaml:"embedder_base_url" toml:"embedder_base_url" validate:"required,url"`
+	VectorSize      int    `yaml:"vector_size" toml:"vector_size" validate:"min=1"`
+	// Chunking overrides the default chunking of the documents inserted into the collection.
+	Chunking ChunkingOverride `yaml:"chunking" toml:"chunking"`
+}
+
+type ScreeningConfig struct {
+	// FlagInjections marks the inserted texts scoring as prompt injections in their metadata.
+	FlagInjections bool    `env:"VECTORSTORE_SCREENING_FLAG_INJECTIONS" envDefault:"true" yaml:"flag_injections" toml:"flag_injections"`
+	Threshold      float32 `env:"VECTORSTORE_SCREENING_THRESHOLD" envDefault:"0.5" yaml:"threshold" toml:"threshold" validate:"gt=0,lte=1"`
+	// Patterns are regular expressions flagged on top of the default rules.
+	Patterns []string `env:"VECTORSTORE_SCREENING_PATTERNS" yaml:"patterns" toml:"patterns"`
+}
+
+type PIIConfig struct {
+	// Enabled redacts the personal data in the inserted texts and metadata before they are embedded and stored.
+	Enabled bool `env:"VECTORSTORE_PII_ENABLED" yaml:"enabled" toml:"enabled"`
+	// Strategy replaces the personal data with its type (mask), its type and a keyed hash (hash) or nothing (drop).
+	Strategy string `env:"VECTORSTORE_PII_STRATEGY" envDefault:"mask" yaml:"strategy" toml:"strategy" validate:"oneof=mask hash drop"`
+	// Types are the redacted personal data types, all of them when empty.
+	Types []string `env:"VECTORSTORE_PII_TYPES" yaml:"types" toml:"types" validate:"dive,oneof=email phone card iban us_ssn ir_national_id"`
+	// Allow are regular expressions matching the whole values that are kept.
+	Allow   []string `env:"VECTORSTORE_PII_ALLOW" yaml:"allow" toml:"allow"`
+	HashKey string   `env:"VECTORSTORE_PII_HASH_KEY" yaml:"hash_key" toml:"hash_key" secret:"true"`
+	// TenantField is the metadata field selecting the tenant policy of an inserted text.
+	TenantField string `env:"VECTORSTORE_PII_TENANT_FIELD" envDefault:"tenant" yaml:"tenant_field" toml:"tenant_field"`
+	// Tenants are the policies of the tenants overriding the fields they set.
+	Tenants map[string]pii.Policy `yaml:"tenants" toml:"tenants" validate:"dive"`
 }
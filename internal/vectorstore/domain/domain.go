@@ -2,6 +2,12 @@ package domain
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
 
@@ -11,12 +17,41 @@ import (
 var validator = validatorPkg.New(validatorPkg.WithRequiredStructEnabled())
 
 type InsertTextsInputText struct {
+	// ID is a stable id of the text chosen by the client, replacing the text
+	// inserted with it before. A random id is generated when it is empty.
+	ID       string         `validate:"omitempty,max=256,printascii"`
 	Text     string         `validate:"required,min=2,max=2500"`
 	Metadata map[string]any `validate:"-"`
 }
 
+// Validate validates a text on its own, e.g. a streamed text.
+func (text *InsertTextsInputText) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, text); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// The id modes of the texts without a client id.
+const (
+	// IDModeRandom generates a random id. It is the default mode.
+	IDModeRandom = "random"
+	// IDModeContent hashes the id namespace and the text, so inserting the
+	// same text again replaces it instead of duplicating it.
+	IDModeContent = "content"
+)
+
 type InsertTextsInput struct {
-	Texts []*InsertTextsInputText `validate:"required,min=1,dive"`
+	Texts  []*InsertTextsInputText `validate:"required,min=1,dive"`
+	IDMode string                  `validate:"omitempty,oneof=random content"`
+	// IDNamespace scopes the content ids, e.g. to the source url of the texts.
+	IDNamespace string `validate:"omitempty,max=2048"`
+	// DedupThreshold skips the texts whose similarity to a stored text or to
+	// a preceding text of the input is at least the threshold. 0 disables it.
+	DedupThreshold float32 `validate:"min=0,max=1"`
 }
 
 func (input *InsertTextsInput) Validate(ctx context.Context) error {
@@ -26,14 +61,307 @@ func (input *InsertTextsInput) Validate(ctx context.Context) error {
 		}
 		return err
 	}
+
+	if input.IDNamespace != "" && input.IDMode != IDModeContent {
+		return internal_error.NewValidationError(fmt.Errorf("id namespace requires the %s id mode", IDModeContent))
+	}
+
+	indexes := make(map[string]int, len(input.Texts))
+	for i, text := range input.Texts {
+		if text.ID == "" {
+			continue
+		}
+		if index, ok := indexes[text.ID]; ok {
+			return internal_error.NewValidationError(fmt.Errorf("texts[%d].id: duplicate id %q of texts[%d]", i, text.ID, index))
+		}
+		indexes[text.ID] = i
+	}
+
 	return nil
 }
 
+// InsertTextsResultSkipped is a text skipped as a near duplicate.
+type InsertTextsResultSkipped struct {
+	// Index is the index of the text in the input texts.
+	Index int
+	// DuplicateID is the id of the stored or preceding text it duplicates.
+	DuplicateID string
+	// Score is the similarity of the text to its duplicate.
+	Score float32
+}
+
+type InsertTextsResult struct {
+	// IDs are the ids of the inserted texts in the order of the input texts,
+	// the id of their duplicate for the skipped texts.
+	IDs     []string
+	Skipped []*InsertTextsResultSkipped
+}
+
+// StreamInsertTextsResultItem is a failed text of the stream.
+type StreamInsertTextsResultItem struct {
+	// Index is the index of the text in the stream.
+	Index int
+	// Error is why the text failed.
+	Error string
+}
+
+type StreamInsertTextsResult struct {
+	// FailedItems are in the order of the stream, the inserted texts are only
+	// counted so the result doesn't grow with the stream.
+	FailedItems []*StreamInsertTextsResultItem
+	Inserted    int
+	Failed      int
+}
+
+// EmbedFailure is a text the embedder failed to embed even on its own, e.g.
+// a text longer than the context of the embedding model.
+type EmbedFailure struct {
+	// Index is the index of the text in the embedded texts.
+	Index int
+	Err   error
+}
+
+// EmbedError lists the texts the embedder failed to embed.
+type EmbedError struct {
+	// Failures are in the order of the texts.
+	Failures []*EmbedFailure
+}
+
+var _ error = (*EmbedError)(nil)
+
+func (e *EmbedError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		messages[i] = fmt.Sprintf("texts[%d]: %s", failure.Index, failure.Err)
+	}
+	return fmt.Sprintf("failed to embed %d texts: %s", len(e.Failures), strings.Join(messages, "; "))
+}
+
+// The chunkers of the inserted documents.
+const (
+	// ChunkerFixed splits fixed size windows of characters.
+	ChunkerFixed = "fixed"
+	// ChunkerSentence packs whole sentences.
+	ChunkerSentence = "sentence"
+	// ChunkerRecursive packs the paragraphs, splitting the large ones into
+	// lines, sentences and words in turn.
+	ChunkerRecursive = "recursive"
+	// ChunkerMarkdown splits the markdown sections by their headings and
+	// chunks each section recursively.
+	ChunkerMarkdown = "markdown"
+)
+
+// The metadata fields of the chunks of the inserted documents.
+const (
+	MetadataDocumentID = "document_id"
+	MetadataChunkIndex = "chunk_index"
+	// MetadataChunkStart and MetadataChunkEnd are the offsets of the chunk in
+	// its document, in characters. They refer to the document as it was sent,
+	// before its personal data was redacted from the chunks.
+	MetadataChunkStart = "chunk_start"
+	MetadataChunkEnd   = "chunk_end"
+	// MetadataHeadings are the markdown headings of the section of the chunk.
+	MetadataHeadings = "headings"
+	// MetadataTitle is the title of an uploaded document.
+	MetadataTitle = "title"
+	// MetadataRow is the row of a chunk of an uploaded table, from 1.
+	MetadataRow = "row"
+)
+
+type InsertDocumentsInputDocument struct {
+	// ID is a stable id of the document replacing the chunks of the document
+	// inserted with it before. A random id is generated when it is empty.
+	ID       string         `validate:"omitempty,max=240,printascii"`
+	Text     string         `validate:"required,min=2,max=1000000"`
+	Metadata map[string]any `validate:"-"`
+}
+
+// InsertDocumentsChunking overrides the chunking config of the collection
+// with the fields it sets.
+type InsertDocumentsChunking struct {
+	Chunker string `validate:"omitempty,oneof=fixed sentence recursive markdown"`
+	Size    int    `validate:"omitempty,min=2,max=2500"`
+	Overlap *int   `validate:"omitempty,min=0"`
+}
+
+type InsertDocumentsInput struct {
+	Documents []*InsertDocumentsInputDocument `validate:"required,min=1,max=100,dive"`
+	Chunking  *InsertDocumentsChunking        `validate:"omitempty"`
+	// Collection is the default collection or a federated collection the
+	// documents are inserted into, the default one when empty.
+	Collection string `validate:"max=255"`
+}
+
+func (input *InsertDocumentsInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+
+	indexes := make(map[string]int, len(input.Documents))
+	for i, document := range input.Documents {
+		if document.ID == "" {
+			continue
+		}
+		if index, ok := indexes[document.ID]; ok {
+			return internal_error.NewValidationError(fmt.Errorf("documents[%d].id: duplicate id %q of documents[%d]", i, document.ID, index))
+		}
+		indexes[document.ID] = i
+	}
+
+	return nil
+}
+
+type InsertDocumentsResultDocument struct {
+	ID string
+	// ChunkIDs are the ids of the inserted chunks in the order of the document.
+	ChunkIDs []string
+}
+
+type InsertDocumentsResult struct {
+	// Documents are in the order of the input documents.
+	Documents []*InsertDocumentsResultDocument
+}
+
+// The mime types of the uploaded documents.
+const (
+	MIMETypeHTML     = "text/html"
+	MIMETypeMarkdown = "text/markdown"
+	MIMETypePlain    = "text/plain"
+	MIMETypeCSV      = "text/csv"
+	MIMETypeJSONL    = "application/jsonl"
+)
+
+type UploadDocumentInput struct {
+	// ID is a stable id of the document replacing the chunks of the document
+	// uploaded with it before. A random id is generated when it is empty.
+	ID string `validate:"omitempty,max=240,printascii"`
+	// MIMEType is the media type of Content, its parameters are ignored.
+	MIMEType string                   `validate:"required,max=255"`
+	Content  []byte                   `validate:"required,max=3145728"`
+	Metadata map[string]any           `validate:"-"`
+	Chunking *InsertDocumentsChunking `validate:"omitempty"`
+	// Collection is the default collection or a federated collection the
+	// document is inserted into, the default one when empty.
+	Collection string `validate:"max=255"`
+}
+
+func (input *UploadDocumentInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+type UploadDocumentResult struct {
+	ID string
+	// ChunkIDs are the ids of the inserted chunks in the order of the document.
+	ChunkIDs []string
+}
+
+// ParsedDocument is the text extracted from an uploaded document.
+type ParsedDocument struct {
+	// Metadata is the structural metadata of the whole document, e.g. its title.
+	Metadata map[string]any
+	// Chunker is the chunker suiting the structure of the text, the
+	// configured one when it is empty.
+	Chunker  string
+	Sections []*ParsedSection
+}
+
+// ParsedSection is a part of a parsed document chunked on its own, e.g. a
+// table row.
+type ParsedSection struct {
+	Text string
+	// Metadata is the structural metadata of the section, e.g. its row.
+	Metadata map[string]any
+}
+
+// ChunkingOptions are the resolved chunking of a document.
+type ChunkingOptions struct {
+	Chunker string
+	Size    int
+	Overlap int
+}
+
+// Chunk is a chunk of a document.
+type Chunk struct {
+	Text string
+	// Start and End are the offsets of the chunk in the document, in characters.
+	Start, End int
+	// Headings are the markdown headings of the section of the chunk, outermost first.
+	Headings []string
+}
+
+// SearchTextMMR re-selects the top k of the fetch k most similar texts by
+// maximal marginal relevance, trading off their similarity to the query
+// (lambda 1) against their diversity (lambda 0).
+type SearchTextMMR struct {
+	Lambda float32 `validate:"min=0,max=1"`
+	FetchK int     `validate:"min=1,max=1000"`
+}
+
+// The search modes.
+const (
+	// SearchModeDense searches the embeddings. It is the default mode.
+	SearchModeDense = "dense"
+	// SearchModeSparse searches the bm25 term weights, matching exact identifiers and names.
+	SearchModeSparse = "sparse"
+	// SearchModeHybrid fuses the dense and the sparse results.
+	SearchModeHybrid = "hybrid"
+)
+
+// The fusions of the hybrid search results.
+const (
+	// FusionRRF is the reciprocal rank fusion. It is the default fusion.
+	FusionRRF = "rrf"
+	// FusionDBSF is the distribution based score fusion.
+	FusionDBSF = "dbsf"
+)
+
+// The fusions of the federated search results.
+const (
+	// CollectionFusionWeighted sums the weighted scores, min-max normalized
+	// per collection. It is the default fusion.
+	CollectionFusionWeighted = "weighted"
+	// CollectionFusionRRF sums the weighted reciprocal ranks.
+	CollectionFusionRRF = "rrf"
+)
+
+// MetadataCollection is the metadata field naming the collection of a federated search result,
+// reserved by its prefix so it doesn't overwrite a field of the texts.
+const MetadataCollection = "_collection"
+
+type SearchTextFederationCollection struct {
+	Name string `validate:"required"`
+	// Weight scales the scores of the collection results, 1 when 0.
+	Weight float32 `validate:"min=0"`
+}
+
+// SearchTextFederation searches the collections concurrently, each with its
+// own embedding model, and fuses their results.
+type SearchTextFederation struct {
+	Collections []*SearchTextFederationCollection `validate:"min=1,max=10,unique=Name,dive,required"`
+	Fusion      string                            `validate:"omitempty,oneof=weighted rrf"`
+}
+
 type SearchTextInput struct {
-	Text     string         `validate:"required"`
-	TopK     int            `validate:"min=1,max=100"`
-	MinScore float32        `validate:"-"`
-	Filter   map[string]any `validate:"-"`
+	Text     string  `validate:"required"`
+	TopK     int     `validate:"min=1,max=100"`
+	MinScore float32 `validate:"-"`
+	// Filter is a search filter parsed by ParseFilter.
+	Filter map[string]any `validate:"-"`
+	// MMR diversifies the results when not nil.
+	MMR    *SearchTextMMR `validate:"omitempty"`
+	Mode   string         `validate:"omitempty,oneof=dense sparse hybrid"`
+	Fusion string         `validate:"omitempty,oneof=rrf dbsf"`
+	// Federation searches the given collections instead of the default one when not nil.
+	Federation *SearchTextFederation `validate:"omitempty"`
 }
 
 func (input *SearchTextInput) Validate(ctx context.Context) error {
@@ -43,6 +371,15 @@ func (input *SearchTextInput) Validate(ctx context.Context) error {
 		}
 		return err
 	}
+
+	if input.MMR != nil && input.MMR.FetchK < input.TopK {
+		return internal_error.NewValidationError(fmt.Errorf("mmr fetch k %d is less than top k %d", input.MMR.FetchK, input.TopK))
+	}
+
+	if _, err := ParseFilter(input.Filter); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -55,3 +392,194 @@ type SearchTextResultItem struct {
 	Score    float32
 	Metadata map[string]any
 }
+
+type LookupTextsRange struct {
+	Gte float64 `validate:"-"`
+	Lte float64 `validate:"gtefield=Gte"`
+}
+
+type LookupTextsInput struct {
+	// Match are the metadata values the texts must equal: strings, booleans or numbers.
+	Match map[string]any `validate:"required_without=Ranges"`
+	// Ranges bound the numeric metadata values of the texts.
+	Ranges map[string]*LookupTextsRange `validate:"required_without=Match,dive,required"`
+	Limit  int                          `validate:"min=1,max=1000"`
+}
+
+func (input *LookupTextsInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+
+	for key, value := range input.Match {
+		switch value.(type) {
+		case string, bool, int, int64, float64:
+		default:
+			return internal_error.NewValidationError(fmt.Errorf("match %q: unsupported value type %T", key, value))
+		}
+	}
+
+	return nil
+}
+
+// Filter requires all the matches and the ranges of the input, in the order
+// of their keys. The integer matches are converted to float64.
+func (input *LookupTextsInput) Filter() *Filter {
+	conditions := make([]*FilterCondition, 0, len(input.Match)+len(input.Ranges))
+
+	for _, key := range slices.Sorted(maps.Keys(input.Match)) {
+		value := input.Match[key]
+		switch number := value.(type) {
+		case int:
+			value = float64(number)
+		case int64:
+			value = float64(number)
+		}
+		conditions = append(conditions, &FilterCondition{Key: key, Match: value})
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(input.Ranges)) {
+		r := input.Ranges[key]
+		conditions = append(conditions, &FilterCondition{Key: key, Range: &FilterRange{Gte: &r.Gte, Lte: &r.Lte}})
+	}
+
+	return &Filter{Must: conditions}
+}
+
+type LookupTextsResult struct {
+	Texts []*LookupTextsResultItem
+}
+
+type LookupTextsResultItem struct {
+	Text     string
+	Metadata map[string]any
+}
+
+// StoredText is a stored text with its id, and its vector when requested.
+type StoredText struct {
+	ID       string
+	Text     string
+	Metadata map[string]any
+	Vector   []float32
+}
+
+type GetTextsInput struct {
+	// IDs are the ids of the texts or the client ids they were inserted with.
+	IDs         []string `validate:"required,min=1,max=1000,dive,required,max=256,printascii"`
+	WithVectors bool     `validate:"-"`
+}
+
+func (input *GetTextsInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+type GetTextsResult struct {
+	// Texts are the found texts in the order of the input ids.
+	Texts []*StoredText
+}
+
+type ListTextsInput struct {
+	// Filter is a search filter parsed by ParseFilter, listing all the texts when empty.
+	Filter map[string]any `validate:"-"`
+	Limit  int            `validate:"min=1,max=1000"`
+	// Cursor is the next cursor of the previous page, the first page when empty.
+	Cursor      string `validate:"-"`
+	WithVectors bool   `validate:"-"`
+}
+
+func (input *ListTextsInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+
+	if _, err := ParseFilter(input.Filter); err != nil {
+		return err
+	}
+
+	if _, err := ParseCursor(input.Cursor); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type ListTextsResult struct {
+	// Texts are ordered by their ids.
+	Texts []*StoredText
+	// NextCursor lists the next page, empty on the last page.
+	NextCursor string
+}
+
+// NewCursor returns the opaque cursor of the page starting at the text with id.
+func NewCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// ParseCursor returns the id of the first text of the page of cursor. The
+// empty cursor is the empty id of the first page.
+func ParseCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+
+	id, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || validator.Var(string(id), "uuid") != nil {
+		return "", internal_error.NewValidationError(fmt.Errorf("cursor: malformed cursor %q", cursor))
+	}
+
+	return string(id), nil
+}
+
+type DeleteTextsInput struct {
+	// IDs are the ids of the texts or the client ids they were inserted with.
+	IDs []string `validate:"required,min=1,max=1000,dive,required,max=256,printascii"`
+	// DryRun counts the texts that would be deleted without deleting them.
+	DryRun bool `validate:"-"`
+}
+
+func (input *DeleteTextsInput) Validate(ctx context.Context) error {
+	if err := validator.StructCtx(ctx, input); err != nil {
+		if _, ok := err.(validatorPkg.ValidationErrors); ok {
+			return internal_error.NewValidationError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+type DeleteByFilterInput struct {
+	// Filter is a search filter parsed by ParseFilter. It is required so a
+	// mistaken request never deletes the whole collection.
+	Filter map[string]any `validate:"-"`
+	// DryRun counts the texts that would be deleted without deleting them.
+	DryRun bool `validate:"-"`
+}
+
+func (input *DeleteByFilterInput) Validate(ctx context.Context) error {
+	if len(input.Filter) == 0 {
+		return internal_error.NewValidationError(errors.New("filter: required, the whole collection can't be deleted"))
+	}
+
+	if _, err := ParseFilter(input.Filter); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type DeleteTextsResult struct {
+	// Deleted is the number of deleted texts, or of the texts that would be deleted on a dry run.
+	Deleted int
+}
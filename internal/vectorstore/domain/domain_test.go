@@ -9,6 +9,7 @@ import (
 	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
 	validatorPkg "github.com/go-playground/validator/v10"
 	"github.com/google/go-cmp/cmp"
+	"github.com/samber/lo"
 )
 
 func Test_InsertTextsInput_Validate(t *testing.T) {
@@ -75,6 +76,58 @@ func Test_InsertTextsInput_Validate(t *testing.T) {
 				}(),
 			},
 		},
+		{
+			name: "ok_client_ids",
+			domainObject: &domain.InsertTextsInput{
+				Texts: []*domain.InsertTextsInputText{
+					{ID: "doc#1", Text: "text 1"},
+					{ID: "doc#2", Text: "text 2"},
+					{Text: "text 3"},
+					{Text: "text 4"},
+				},
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			want: want{
+				err:                 false,
+				validationErr:       false,
+				validationErrString: "",
+			},
+		},
+		{
+			name: "validation_error_duplicate_id",
+			domainObject: &domain.InsertTextsInput{
+				Texts: []*domain.InsertTextsInputText{
+					{ID: "doc#1", Text: "text 1"},
+					{Text: "text 2"},
+					{ID: "doc#1", Text: "text 3"},
+				},
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			want: want{
+				err:                 true,
+				validationErr:       true,
+				validationErrString: `texts[2].id: duplicate id "doc#1" of texts[0]`,
+			},
+		},
+		{
+			name: "validation_error_id_namespace",
+			domainObject: &domain.InsertTextsInput{
+				Texts:       []*domain.InsertTextsInputText{{Text: "text 1"}},
+				IDNamespace: "https://example.com",
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			want: want{
+				err:                 true,
+				validationErr:       true,
+				validationErrString: "id namespace requires the content id mode",
+			},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -137,6 +190,90 @@ func Test_SearchTextInput_Validate(t *testing.T) {
 				validationErrString: "",
 			},
 		},
+		{
+			name: "ok_mmr",
+			domainObject: &domain.SearchTextInput{
+				Text: "t",
+				TopK: 2,
+				MMR:  &domain.SearchTextMMR{Lambda: 0.5, FetchK: 2},
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			want: want{
+				err:                 false,
+				validationErr:       false,
+				validationErrString: "",
+			},
+		},
+		{
+			name: "validation_error_mmr_fetch_k",
+			domainObject: &domain.SearchTextInput{
+				Text: "t",
+				TopK: 2,
+				MMR:  &domain.SearchTextMMR{Lambda: 0.5, FetchK: 1},
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			want: want{
+				err:                 true,
+				validationErr:       true,
+				validationErrString: "mmr fetch k 1 is less than top k 2",
+			},
+		},
+		{
+			name: "ok_federation",
+			domainObject: &domain.SearchTextInput{
+				Text: "t",
+				TopK: 2,
+				Federation: &domain.SearchTextFederation{
+					Collections: []*domain.SearchTextFederationCollection{{Name: "docs"}, {Name: "tickets", Weight: 0.5}},
+					Fusion:      domain.CollectionFusionRRF,
+				},
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			want: want{
+				err:                 false,
+				validationErr:       false,
+				validationErrString: "",
+			},
+		},
+		{
+			name: "validation_error_federation_duplicate_collections",
+			domainObject: &domain.SearchTextInput{
+				Text: "t",
+				TopK: 2,
+				Federation: &domain.SearchTextFederation{
+					Collections: []*domain.SearchTextFederationCollection{{Name: "docs"}, {Name: "docs", Weight: 0.5}},
+				},
+			},
+			input: input{
+				ctx: context.Background(),
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+				validationErrString: func() string {
+					d := &domain.SearchTextInput{
+						Text: "t",
+						TopK: 2,
+						Federation: &domain.SearchTextFederation{
+							Collections: []*domain.SearchTextFederationCollection{{Name: "docs"}, {Name: "docs", Weight: 0.5}},
+						},
+					}
+					validator := validatorPkg.New(validatorPkg.WithRequiredStructEnabled())
+					err := validator.StructCtx(context.Background(), d)
+					validationErr, ok := err.(validatorPkg.ValidationErrors)
+					if !ok {
+						panic("validator.ValidationErrors didn't happen")
+					}
+					return internal_error.NewValidationError(validationErr).Error()
+				}(),
+			},
+		},
 		{
 			name: "validation_error_texts",
 			domainObject: &domain.SearchTextInput{
@@ -192,3 +329,575 @@ func Test_SearchTextInput_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_LookupTextsInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err           bool
+		validationErr bool
+	}
+
+	type testCase struct {
+		name         string
+		domainObject *domain.LookupTextsInput
+		want         want
+	}
+	testCases := []testCase{
+		{
+			name: "ok_match",
+			domainObject: &domain.LookupTextsInput{
+				Match: map[string]any{"source": "example.com", "flagged": false, "chunk_id": 2.0},
+				Limit: 10,
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name: "ok_ranges",
+			domainObject: &domain.LookupTextsInput{
+				Ranges: map[string]*domain.LookupTextsRange{"chunk_id": {Gte: 1, Lte: 3}},
+				Limit:  10,
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name: "validation_error_no_conditions",
+			domainObject: &domain.LookupTextsInput{
+				Limit: 10,
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_limit",
+			domainObject: &domain.LookupTextsInput{
+				Match: map[string]any{"source": "example.com"},
+				Limit: 0,
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_range",
+			domainObject: &domain.LookupTextsInput{
+				Ranges: map[string]*domain.LookupTextsRange{"chunk_id": {Gte: 3, Lte: 1}},
+				Limit:  10,
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_match_type",
+			domainObject: &domain.LookupTextsInput{
+				Match: map[string]any{"tags": []any{"a"}},
+				Limit: 10,
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.domainObject.Validate(context.Background())
+			if (err != nil) != tt.want.err {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if _, ok := err.(*internal_error.ValidationError); ok != tt.want.validationErr {
+				t.Fatal(cmp.Diff(ok, tt.want.validationErr))
+			}
+		})
+	}
+}
+
+func Test_LookupTextsInput_Filter(t *testing.T) {
+	t.Parallel()
+
+	input := &domain.LookupTextsInput{
+		Match:  map[string]any{"source": "a.com", "chunk_id": 1, "flagged": false},
+		Ranges: map[string]*domain.LookupTextsRange{"page": {Gte: 1, Lte: 3}},
+		Limit:  10,
+	}
+
+	want := &domain.Filter{Must: []*domain.FilterCondition{
+		{Key: "chunk_id", Match: 1.0},
+		{Key: "flagged", Match: false},
+		{Key: "source", Match: "a.com"},
+		{Key: "page", Range: &domain.FilterRange{Gte: lo.ToPtr(1.0), Lte: lo.ToPtr(3.0)}},
+	}}
+
+	if diff := cmp.Diff(want, input.Filter()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_DeleteTextsInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err           bool
+		validationErr bool
+	}
+
+	type testCase struct {
+		name         string
+		domainObject *domain.DeleteTextsInput
+		want         want
+	}
+	testCases := []testCase{
+		{
+			name: "ok",
+			domainObject: &domain.DeleteTextsInput{
+				IDs:    []string{"5a0e9c8b-4d4f-4b8e-9a53-0b2d6c1e7f10"},
+				DryRun: true,
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name: "ok_client_id",
+			domainObject: &domain.DeleteTextsInput{
+				IDs: []string{"docs/intro.md#3"},
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name:         "validation_error_no_ids",
+			domainObject: &domain.DeleteTextsInput{},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_id",
+			domainObject: &domain.DeleteTextsInput{
+				IDs: []string{""},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.domainObject.Validate(context.Background())
+			if (err != nil) != tt.want.err {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if _, ok := err.(*internal_error.ValidationError); ok != tt.want.validationErr {
+				t.Fatal(cmp.Diff(ok, tt.want.validationErr))
+			}
+		})
+	}
+}
+
+func Test_DeleteByFilterInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err           bool
+		validationErr bool
+	}
+
+	type testCase struct {
+		name         string
+		domainObject *domain.DeleteByFilterInput
+		want         want
+	}
+	testCases := []testCase{
+		{
+			name: "ok",
+			domainObject: &domain.DeleteByFilterInput{
+				Filter: map[string]any{"must": []any{map[string]any{"key": "source", "match": "example.com"}}},
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name:         "validation_error_no_filter",
+			domainObject: &domain.DeleteByFilterInput{},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_empty_filter",
+			domainObject: &domain.DeleteByFilterInput{
+				Filter: map[string]any{},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_malformed_filter",
+			domainObject: &domain.DeleteByFilterInput{
+				Filter: map[string]any{"must": []any{map[string]any{"key": "source"}}},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.domainObject.Validate(context.Background())
+			if (err != nil) != tt.want.err {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if _, ok := err.(*internal_error.ValidationError); ok != tt.want.validationErr {
+				t.Fatal(cmp.Diff(ok, tt.want.validationErr))
+			}
+		})
+	}
+}
+
+func Test_InsertDocumentsInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err           bool
+		validationErr bool
+	}
+
+	type testCase struct {
+		name         string
+		domainObject *domain.InsertDocumentsInput
+		want         want
+	}
+	testCases := []testCase{
+		{
+			name: "ok",
+			domainObject: &domain.InsertDocumentsInput{
+				Documents: []*domain.InsertDocumentsInputDocument{
+					{ID: "docs/intro.md", Text: "# Intro", Metadata: map[string]any{"source": "docs"}},
+					{Text: "no id"},
+				},
+				Chunking: &domain.InsertDocumentsChunking{Chunker: domain.ChunkerMarkdown, Size: 500, Overlap: lo.ToPtr(0)},
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name:         "validation_error_no_documents",
+			domainObject: &domain.InsertDocumentsInput{},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_empty_text",
+			domainObject: &domain.InsertDocumentsInput{
+				Documents: []*domain.InsertDocumentsInputDocument{{ID: "a"}},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_long_id",
+			domainObject: &domain.InsertDocumentsInput{
+				Documents: []*domain.InsertDocumentsInputDocument{{ID: strings.Repeat("a", 241), Text: "text"}},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_duplicate_id",
+			domainObject: &domain.InsertDocumentsInput{
+				Documents: []*domain.InsertDocumentsInputDocument{
+					{ID: "a", Text: "first"},
+					{ID: "a", Text: "second"},
+				},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_unknown_chunker",
+			domainObject: &domain.InsertDocumentsInput{
+				Documents: []*domain.InsertDocumentsInputDocument{{Text: "text"}},
+				Chunking:  &domain.InsertDocumentsChunking{Chunker: "semantic"},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_size_too_large",
+			domainObject: &domain.InsertDocumentsInput{
+				Documents: []*domain.InsertDocumentsInputDocument{{Text: "text"}},
+				Chunking:  &domain.InsertDocumentsChunking{Size: 2501},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_negative_overlap",
+			domainObject: &domain.InsertDocumentsInput{
+				Documents: []*domain.InsertDocumentsInputDocument{{Text: "text"}},
+				Chunking:  &domain.InsertDocumentsChunking{Overlap: lo.ToPtr(-1)},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.domainObject.Validate(context.Background())
+			if (err != nil) != tt.want.err {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if _, ok := err.(*internal_error.ValidationError); ok != tt.want.validationErr {
+				t.Fatal(cmp.Diff(ok, tt.want.validationErr))
+			}
+		})
+	}
+}
+
+func Test_UploadDocumentInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err           bool
+		validationErr bool
+	}
+
+	type testCase struct {
+		name         string
+		domainObject *domain.UploadDocumentInput
+		want         want
+	}
+	testCases := []testCase{
+		{
+			name: "ok",
+			domainObject: &domain.UploadDocumentInput{
+				ID:       "docs/intro.html",
+				MIMEType: "text/html; charset=utf-8",
+				Content:  []byte("<p>Intro</p>"),
+				Chunking: &domain.InsertDocumentsChunking{Chunker: domain.ChunkerMarkdown},
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name: "validation_error_no_mime_type",
+			domainObject: &domain.UploadDocumentInput{
+				Content: []byte("text"),
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_empty_content",
+			domainObject: &domain.UploadDocumentInput{
+				MIMEType: domain.MIMETypePlain,
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_content_too_large",
+			domainObject: &domain.UploadDocumentInput{
+				MIMEType: domain.MIMETypePlain,
+				Content:  make([]byte, 3<<20+1),
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_unknown_chunker",
+			domainObject: &domain.UploadDocumentInput{
+				MIMEType: domain.MIMETypePlain,
+				Content:  []byte("text"),
+				Chunking: &domain.InsertDocumentsChunking{Chunker: "semantic"},
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.domainObject.Validate(context.Background())
+			if (err != nil) != tt.want.err {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if _, ok := err.(*internal_error.ValidationError); ok != tt.want.validationErr {
+				t.Fatal(cmp.Diff(ok, tt.want.validationErr))
+			}
+		})
+	}
+}
+
+func Test_ListTextsInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err           bool
+		validationErr bool
+	}
+
+	type testCase struct {
+		name         string
+		domainObject *domain.ListTextsInput
+		want         want
+	}
+	testCases := []testCase{
+		{
+			name: "ok",
+			domainObject: &domain.ListTextsInput{
+				Filter: map[string]any{"must": []any{map[string]any{"key": "source", "match": "example.com"}}},
+				Limit:  10,
+				Cursor: domain.NewCursor("5a0e9c8b-4d4f-4b8e-9a53-0b2d6c1e7f10"),
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name: "ok_first_page",
+			domainObject: &domain.ListTextsInput{
+				Limit: 10,
+			},
+			want: want{
+				err:           false,
+				validationErr: false,
+			},
+		},
+		{
+			name: "validation_error_limit",
+			domainObject: &domain.ListTextsInput{
+				Limit: 0,
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_filter",
+			domainObject: &domain.ListTextsInput{
+				Filter: map[string]any{"must": []any{}},
+				Limit:  10,
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+		{
+			name: "validation_error_cursor",
+			domainObject: &domain.ListTextsInput{
+				Limit:  10,
+				Cursor: domain.NewCursor("page 2"),
+			},
+			want: want{
+				err:           true,
+				validationErr: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.domainObject.Validate(context.Background())
+			if (err != nil) != tt.want.err {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if _, ok := err.(*internal_error.ValidationError); ok != tt.want.validationErr {
+				t.Fatal(cmp.Diff(ok, tt.want.validationErr))
+			}
+		})
+	}
+}
+
+func Test_ParseCursor(t *testing.T) {
+	t.Parallel()
+
+	id := "5a0e9c8b-4d4f-4b8e-9a53-0b2d6c1e7f10"
+
+	got, err := domain.ParseCursor(domain.NewCursor(id))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if diff := cmp.Diff(id, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := domain.ParseCursor("!"); err == nil {
+		t.Fatal("want an error parsing a malformed cursor")
+	}
+}
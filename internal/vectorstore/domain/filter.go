@@ -0,0 +1,279 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+)
+
+// maxFilterDepth bounds the nesting of the filters.
+const maxFilterDepth = 8
+
+// Filter requires all the Must conditions, at least one of the Should
+// conditions when there are any and none of the MustNot conditions.
+//
+// A search filter is written as
+//
+//	{"must": [...], "should": [...], "must_not": [...]}
+//
+// where each condition is a nested filter or a condition on the metadata key,
+// whose nested fields are joined with dots (e.g. "author.name"):
+//
+//	{"key": "source", "match": "en.wikipedia.org"}
+//	{"key": "lang", "in": ["en", "fr"]}
+//	{"key": "chunk_id", "range": {"gte": 2, "lt": 5}}
+//	{"key": "published_at", "range": {"gte": "2024-01-01T00:00:00Z"}}
+//	{"key": "author", "exists": true}
+type Filter struct {
+	Must    []*FilterCondition
+	Should  []*FilterCondition
+	MustNot []*FilterCondition
+}
+
+// FilterCondition is a nested filter or a condition on a metadata key.
+type FilterCondition struct {
+	// Filter is the nested filter, the other fields are not set.
+	Filter *Filter
+	Key    string
+	// Match equals a string, a bool or a float64.
+	Match any
+	// In equals one of the strings, the bools or the float64s.
+	In            []any
+	Range         *FilterRange
+	DatetimeRange *FilterDatetimeRange
+	Exists        *bool
+}
+
+type FilterRange struct {
+	Gt, Gte, Lt, Lte *float64
+}
+
+type FilterDatetimeRange struct {
+	Gt, Gte, Lt, Lte *time.Time
+}
+
+// filterClauses are the keys of a filter object.
+var filterClauses = []string{"must", "should", "must_not"}
+
+// filterOperators are the keys of a condition object besides the key.
+var filterOperators = []string{"match", "in", "range", "exists"}
+
+// rangeBounds are the keys of a range object.
+var rangeBounds = []string{"gt", "gte", "lt", "lte"}
+
+// ParseFilter parses a search filter, returning a validation error naming the
+// path of the first malformed value. The empty filter is nil.
+func ParseFilter(filter map[string]any) (*Filter, error) {
+	if len(filter) == 0 {
+		return nil, nil
+	}
+
+	parsed, err := parseFilter("filter", filter, 1)
+	if err != nil {
+		return nil, internal_error.NewValidationError(err)
+	}
+
+	return parsed, nil
+}
+
+func parseFilter(path string, filter map[string]any, depth int) (*Filter, error) {
+	if depth > maxFilterDepth {
+		return nil, fmt.Errorf("%s: filters are nested deeper than %d", path, maxFilterDepth)
+	}
+
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("%s: want at least one of must, should or must_not", path)
+	}
+
+	parsed := &Filter{}
+	for _, key := range slices.Sorted(maps.Keys(filter)) {
+		value := filter[key]
+		var conditions *[]*FilterCondition
+		switch key {
+		case "must":
+			conditions = &parsed.Must
+		case "should":
+			conditions = &parsed.Should
+		case "must_not":
+			conditions = &parsed.MustNot
+		default:
+			return nil, fmt.Errorf("%s: unknown clause %q, want one of %v", path, key, filterClauses)
+		}
+
+		list, ok := value.([]any)
+		if !ok || len(list) == 0 {
+			return nil, fmt.Errorf("%s.%s: want a non empty list of conditions, got %s", path, key, typeName(value))
+		}
+
+		for i, item := range list {
+			condition, err := parseFilterCondition(fmt.Sprintf("%s.%s[%d]", path, key, i), item, depth)
+			if err != nil {
+				return nil, err
+			}
+			*conditions = append(*conditions, condition)
+		}
+	}
+
+	return parsed, nil
+}
+
+func parseFilterCondition(path string, value any, depth int) (*FilterCondition, error) {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: want a condition object, got %s", path, typeName(value))
+	}
+
+	if _, ok := object["key"]; !ok {
+		nested, err := parseFilter(path, object, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return &FilterCondition{Filter: nested}, nil
+	}
+
+	key, ok := object["key"].(string)
+	if !ok || key == "" {
+		return nil, fmt.Errorf("%s.key: want a non empty string, got %s", path, typeName(object["key"]))
+	}
+
+	condition := &FilterCondition{Key: key}
+
+	var operator string
+	for _, name := range slices.Sorted(maps.Keys(object)) {
+		operand := object[name]
+		if name == "key" {
+			continue
+		}
+		if !slices.Contains(filterOperators, name) {
+			return nil, fmt.Errorf("%s: unknown operator %q, want one of %v", path, name, filterOperators)
+		}
+		if operator != "" {
+			return nil, fmt.Errorf("%s: want a single operator, got %s and %s", path, operator, name)
+		}
+		operator = name
+
+		operandPath := path + "." + name
+		switch name {
+		case "match":
+			if !isFilterScalar(operand) {
+				return nil, fmt.Errorf("%s: want a string, a bool or a number, got %s", operandPath, typeName(operand))
+			}
+			condition.Match = operand
+		case "in":
+			list, ok := operand.([]any)
+			if !ok || len(list) == 0 {
+				return nil, fmt.Errorf("%s: want a non empty list, got %s", operandPath, typeName(operand))
+			}
+			for i, item := range list {
+				if !isFilterScalar(item) {
+					return nil, fmt.Errorf("%s[%d]: want a string, a bool or a number, got %s", operandPath, i, typeName(item))
+				}
+			}
+			condition.In = list
+		case "range":
+			if err := parseFilterRange(operandPath, operand, condition); err != nil {
+				return nil, err
+			}
+		case "exists":
+			exists, ok := operand.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%s: want a bool, got %s", operandPath, typeName(operand))
+			}
+			condition.Exists = &exists
+		}
+	}
+
+	if operator == "" {
+		return nil, fmt.Errorf("%s: want one of the operators %v", path, filterOperators)
+	}
+
+	return condition, nil
+}
+
+// parseFilterRange parses a numeric range or, when its bounds are strings, a
+// datetime range of RFC 3339 dates.
+func parseFilterRange(path string, value any, condition *FilterCondition) error {
+	object, ok := value.(map[string]any)
+	if !ok || len(object) == 0 {
+		return fmt.Errorf("%s: want an object of at least one of %v, got %s", path, rangeBounds, typeName(value))
+	}
+
+	numbers := make(map[string]*float64, len(object))
+	dates := make(map[string]*time.Time, len(object))
+	for _, bound := range slices.Sorted(maps.Keys(object)) {
+		boundValue := object[bound]
+		if !slices.Contains(rangeBounds, bound) {
+			return fmt.Errorf("%s: unknown bound %q, want one of %v", path, bound, rangeBounds)
+		}
+
+		switch boundValue := boundValue.(type) {
+		case float64:
+			if math.IsNaN(boundValue) {
+				return fmt.Errorf("%s.%s: want a number, got NaN", path, bound)
+			}
+			numbers[bound] = &boundValue
+		case string:
+			date, err := time.Parse(time.RFC3339, boundValue)
+			if err != nil {
+				return fmt.Errorf("%s.%s: want an RFC 3339 date, got %q", path, bound, boundValue)
+			}
+			dates[bound] = &date
+		default:
+			return fmt.Errorf("%s.%s: want a number or an RFC 3339 date, got %s", path, bound, typeName(boundValue))
+		}
+	}
+
+	if len(numbers) > 0 && len(dates) > 0 {
+		return fmt.Errorf("%s: want either number or date bounds, got both", path)
+	}
+
+	if len(dates) > 0 {
+		condition.DatetimeRange = &FilterDatetimeRange{Gt: dates["gt"], Gte: dates["gte"], Lt: dates["lt"], Lte: dates["lte"]}
+		return nil
+	}
+
+	condition.Range = &FilterRange{Gt: numbers["gt"], Gte: numbers["gte"], Lt: numbers["lt"], Lte: numbers["lte"]}
+	return nil
+}
+
+func isFilterScalar(value any) bool {
+	switch value.(type) {
+	case string, bool, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// typeName names the type of a decoded json value in the filter errors.
+func typeName(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		if value == "" {
+			return "an empty string"
+		}
+		return "a string"
+	case bool:
+		return "a bool"
+	case float64:
+		return "a number"
+	case []any:
+		if len(value) == 0 {
+			return "an empty list"
+		}
+		return "a list"
+	case map[string]any:
+		if len(value) == 0 {
+			return "an empty object"
+		}
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,198 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/samber/lo"
+)
+
+func Test_ParseFilter(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name   string
+		filter map[string]any
+		want   *domain.Filter
+	}
+
+	testCases := []testCase{
+		{
+			name:   "empty",
+			filter: map[string]any{},
+			want:   nil,
+		},
+		{
+			name: "conditions",
+			filter: map[string]any{
+				"must": []any{
+					map[string]any{"key": "source", "match": "en.wikipedia.org"},
+					map[string]any{"key": "lang", "in": []any{"en", "fr"}},
+					map[string]any{"key": "chunk_id", "range": map[string]any{"gte": 2.0, "lt": 5.0}},
+					map[string]any{"key": "published_at", "range": map[string]any{"gte": "2024-01-01T00:00:00Z"}},
+				},
+				"must_not": []any{
+					map[string]any{"key": "author.name", "exists": false},
+				},
+			},
+			want: &domain.Filter{
+				Must: []*domain.FilterCondition{
+					{Key: "source", Match: "en.wikipedia.org"},
+					{Key: "lang", In: []any{"en", "fr"}},
+					{Key: "chunk_id", Range: &domain.FilterRange{Gte: lo.ToPtr(2.0), Lt: lo.ToPtr(5.0)}},
+					{Key: "published_at", DatetimeRange: &domain.FilterDatetimeRange{Gte: lo.ToPtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}},
+				},
+				MustNot: []*domain.FilterCondition{
+					{Key: "author.name", Exists: lo.ToPtr(false)},
+				},
+			},
+		},
+		{
+			name: "nested",
+			filter: map[string]any{
+				"should": []any{
+					map[string]any{"key": "flagged", "match": true},
+					map[string]any{"must": []any{map[string]any{"key": "score", "match": 1.0}}},
+				},
+			},
+			want: &domain.Filter{
+				Should: []*domain.FilterCondition{
+					{Key: "flagged", Match: true},
+					{Filter: &domain.Filter{Must: []*domain.FilterCondition{{Key: "score", Match: 1.0}}}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := domain.ParseFilter(tc.filter)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_ParseFilter_Malformed(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name   string
+		filter map[string]any
+		want   string
+	}
+
+	condition := func(c map[string]any) map[string]any { return map[string]any{"must": []any{c}} }
+
+	nested := map[string]any{"key": "k", "match": "v"}
+	for range 9 {
+		nested = condition(nested)
+	}
+
+	testCases := []testCase{
+		{
+			name:   "unknown_clause",
+			filter: map[string]any{"and": []any{}},
+			want:   `filter: unknown clause "and", want one of [must should must_not]`,
+		},
+		{
+			name:   "empty_clause",
+			filter: map[string]any{"must": []any{}},
+			want:   "filter.must: want a non empty list of conditions, got an empty list",
+		},
+		{
+			name:   "condition_not_object",
+			filter: map[string]any{"should": []any{"source"}},
+			want:   "filter.should[0]: want a condition object, got a string",
+		},
+		{
+			name:   "empty_key",
+			filter: condition(map[string]any{"key": "", "match": "v"}),
+			want:   "filter.must[0].key: want a non empty string, got an empty string",
+		},
+		{
+			name:   "no_operator",
+			filter: condition(map[string]any{"key": "k"}),
+			want:   "filter.must[0]: want one of the operators [match in range exists]",
+		},
+		{
+			name:   "two_operators",
+			filter: condition(map[string]any{"key": "k", "match": "v", "exists": true}),
+			want:   "filter.must[0]: want a single operator, got exists and match",
+		},
+		{
+			name:   "unknown_operator",
+			filter: condition(map[string]any{"key": "k", "like": "v"}),
+			want:   `filter.must[0]: unknown operator "like", want one of [match in range exists]`,
+		},
+		{
+			name:   "match_object",
+			filter: condition(map[string]any{"key": "k", "match": map[string]any{"a": 1.0}}),
+			want:   "filter.must[0].match: want a string, a bool or a number, got an object",
+		},
+		{
+			name:   "in_null",
+			filter: condition(map[string]any{"key": "k", "in": []any{"a", nil}}),
+			want:   "filter.must[0].in[1]: want a string, a bool or a number, got null",
+		},
+		{
+			name:   "range_unknown_bound",
+			filter: condition(map[string]any{"key": "k", "range": map[string]any{"from": 1.0}}),
+			want:   `filter.must[0].range: unknown bound "from", want one of [gt gte lt lte]`,
+		},
+		{
+			name:   "range_date",
+			filter: condition(map[string]any{"key": "k", "range": map[string]any{"gte": "yesterday"}}),
+			want:   `filter.must[0].range.gte: want an RFC 3339 date, got "yesterday"`,
+		},
+		{
+			name:   "range_mixed",
+			filter: condition(map[string]any{"key": "k", "range": map[string]any{"gte": 1.0, "lt": "2024-01-01T00:00:00Z"}}),
+			want:   "filter.must[0].range: want either number or date bounds, got both",
+		},
+		{
+			name:   "exists_string",
+			filter: condition(map[string]any{"key": "k", "exists": "yes"}),
+			want:   "filter.must[0].exists: want a bool, got a string",
+		},
+		{
+			name:   "nested_empty",
+			filter: condition(map[string]any{}),
+			want:   "filter.must[0]: want at least one of must, should or must_not",
+		},
+		{
+			name:   "too_deep",
+			filter: nested,
+			want:   "filter" + strings.Repeat(".must[0]", 8) + ": filters are nested deeper than 8",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := domain.ParseFilter(tc.filter)
+
+			validationErr, ok := err.(*internal_error.ValidationError)
+			if !ok {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if diff := cmp.Diff(tc.want, validationErr.Error()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
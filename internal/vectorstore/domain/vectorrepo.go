@@ -1,16 +1,27 @@
 package domain
 
+// SparseVector holds the non zero values of a sparse vector.
+type SparseVector struct {
+	Indices []uint32
+	Values  []float32
+}
+
 type VectorRepoInsertEmbedding struct {
 	ID       string
 	Vector   []float32
+	Sparse   *SparseVector
 	Metadata map[string]any
 }
 
+// VectorRepoQueryInput searches with Vector, Sparse or both fused with Fusion.
 type VectorRepoQueryInput struct {
-	Vector   []float32
-	TopK     int
+	Vector []float32
+	Sparse *SparseVector
+	Fusion string
+	TopK   int
+	// MinScore only applies to the dense similarity.
 	MinScore float32
-	Filter   map[string]any
+	Filter   *Filter
 }
 
 type VectorRepoQueryResult struct {
@@ -19,3 +30,48 @@ type VectorRepoQueryResult struct {
 	Vector   []float32
 	Metadata map[string]any
 }
+
+type VectorRepoLookupInput struct {
+	Filter *Filter
+	Limit  int
+}
+
+type VectorRepoLookupResult struct {
+	ID       string
+	Metadata map[string]any
+}
+
+type VectorRepoDeleteInput struct {
+	IDs    []string
+	DryRun bool
+}
+
+type VectorRepoDeleteByFilterInput struct {
+	Filter *Filter
+	DryRun bool
+}
+
+type VectorRepoGetInput struct {
+	IDs         []string
+	WithVectors bool
+}
+
+type VectorRepoScrollInput struct {
+	Filter *Filter
+	Limit  int
+	// Offset is the id of the first point, the first page when empty.
+	Offset      string
+	WithVectors bool
+}
+
+type VectorRepoPoint struct {
+	ID       string
+	Vector   []float32
+	Metadata map[string]any
+}
+
+type VectorRepoScrollResult struct {
+	Points []*VectorRepoPoint
+	// NextOffset is the id of the first point of the next page, empty on the last page.
+	NextOffset string
+}
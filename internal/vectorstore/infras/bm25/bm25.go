@@ -0,0 +1,105 @@
+package bm25
+
+import (
+	"hash/fnv"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/usecase"
+)
+
+// joiners are kept inside the terms so identifiers like ERR_CONN_RESET,
+// v1.2.3 or bge-small are matched whole as well as by their parts.
+const joiners = "-_./"
+
+type sparseEncoder struct {
+	k1            float64
+	b             float64
+	averageLength float64
+}
+
+var _ usecase.SparseEncoder = (*sparseEncoder)(nil)
+
+// NewSparseEncoder weights the terms of the documents with the bm25 term
+// frequency saturation and length normalization. The inverse document
+// frequency is left to the vector repo, which knows the whole collection.
+func NewSparseEncoder(config *config.Config) *sparseEncoder {
+	return &sparseEncoder{
+		k1:            float64(config.SparseConfig.K1),
+		b:             float64(config.SparseConfig.B),
+		averageLength: float64(config.SparseConfig.AverageLength),
+	}
+}
+
+func (e *sparseEncoder) EncodeDocument(text string) *domain.SparseVector {
+	terms := tokenize(text)
+
+	frequencies := make(map[uint32]float64, len(terms))
+	for _, term := range terms {
+		frequencies[termIndex(term)]++
+	}
+
+	norm := e.k1 * (1 - e.b + e.b*float64(len(terms))/e.averageLength)
+
+	return sparseVector(frequencies, func(frequency float64) float32 {
+		return float32(frequency * (e.k1 + 1) / (frequency + norm))
+	})
+}
+
+func (e *sparseEncoder) EncodeQuery(text string) *domain.SparseVector {
+	terms := make(map[uint32]float64)
+	for _, term := range tokenize(text) {
+		terms[termIndex(term)] = 1
+	}
+
+	return sparseVector(terms, func(weight float64) float32 { return float32(weight) })
+}
+
+func sparseVector(weights map[uint32]float64, value func(weight float64) float32) *domain.SparseVector {
+	vector := &domain.SparseVector{
+		Indices: make([]uint32, 0, len(weights)),
+		Values:  make([]float32, 0, len(weights)),
+	}
+
+	for index := range weights {
+		vector.Indices = append(vector.Indices, index)
+	}
+	slices.Sort(vector.Indices)
+
+	for _, index := range vector.Indices {
+		vector.Values = append(vector.Values, value(weights[index]))
+	}
+
+	return vector
+}
+
+// tokenize returns the lower cased terms of text: the words, numbers and
+// identifiers, followed by the parts of the identifiers.
+func tokenize(text string) []string {
+	var terms []string
+	for _, field := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(joiners, r)
+	}) {
+		term := strings.Trim(field, joiners)
+		if term == "" {
+			continue
+		}
+		terms = append(terms, term)
+
+		if strings.ContainsAny(term, joiners) {
+			for _, part := range strings.FieldsFunc(term, func(r rune) bool { return strings.ContainsRune(joiners, r) }) {
+				terms = append(terms, part)
+			}
+		}
+	}
+	return terms
+}
+
+func termIndex(term string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(term))
+	return hash.Sum32()
+}
@@ -0,0 +1,97 @@
+package bm25_test
+
+import (
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/bm25"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func newSparseEncoder(b float32) interface {
+	EncodeDocument(text string) *domain.SparseVector
+	EncodeQuery(text string) *domain.SparseVector
+} {
+	cfg := &config.Config{}
+	cfg.SparseConfig.K1 = 1.2
+	cfg.SparseConfig.B = b
+	cfg.SparseConfig.AverageLength = 4
+	return bm25.NewSparseEncoder(cfg)
+}
+
+// weights maps the terms to their weight in vector, indexing the terms as single term queries.
+func weights(t *testing.T, vector *domain.SparseVector, terms ...string) map[string]float32 {
+	t.Helper()
+
+	encoder := newSparseEncoder(0)
+
+	byIndex := make(map[uint32]float32, len(vector.Indices))
+	for i, index := range vector.Indices {
+		byIndex[index] = vector.Values[i]
+	}
+
+	result := make(map[string]float32, len(terms))
+	for _, term := range terms {
+		if weight, ok := byIndex[encoder.EncodeQuery(term).Indices[0]]; ok {
+			result[term] = weight
+		}
+	}
+	if len(result) != len(byIndex) {
+		t.Fatalf("%d terms of %d are not listed", len(byIndex)-len(result), len(byIndex))
+	}
+	return result
+}
+
+func Test_SparseEncoder_EncodeQuery(t *testing.T) {
+	t.Parallel()
+
+	got := weights(t, newSparseEncoder(0.75).EncodeQuery("Why ERR_CONN_RESET, why?"), "why", "err_conn_reset", "err", "conn", "reset")
+
+	want := map[string]float32{"why": 1, "err_conn_reset": 1, "err": 1, "conn": 1, "reset": 1}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_SparseEncoder_EncodeDocument(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		b    float32
+		text string
+		want map[string]float32
+	}
+
+	testCases := []testCase{
+		{
+			name: "saturation",
+			b:    0,
+			text: "qdrant qdrant go",
+			// tf * (k1 + 1) / (tf + k1)
+			want: map[string]float32{"qdrant": 1.375, "go": 1},
+		},
+		{
+			name: "length_normalization",
+			b:    1,
+			// twice the average length
+			text: "qdrant go a b c d e f",
+			// tf * (k1 + 1) / (tf + k1 * 2)
+			want: map[string]float32{"qdrant": 0.64705884, "go": 0.64705884, "a": 0.64705884, "b": 0.64705884, "c": 0.64705884, "d": 0.64705884, "e": 0.64705884, "f": 0.64705884},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := weights(t, newSparseEncoder(tc.b).EncodeDocument(tc.text), "qdrant", "go", "a", "b", "c", "d", "e", "f")
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateApprox(0, 1e-6)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
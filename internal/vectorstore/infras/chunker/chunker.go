@@ -0,0 +1,285 @@
+package chunker
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/usecase"
+)
+
+// minChunkLength drops the chunks too short to be inserted as texts.
+const minChunkLength = 2
+
+// separators split the oversized spans of the recursive chunker, coarsest first.
+var separators = []string{"\n\n", "\n", ". ", " "}
+
+type chunker struct{}
+
+var _ usecase.Chunker = (*chunker)(nil)
+
+// NewChunker splits the documents into chunks by their characters, keeping
+// the offsets of the chunks in the documents.
+func NewChunker() *chunker {
+	return &chunker{}
+}
+
+// span is the characters of a document from start to end, exclusive.
+type span struct {
+	start, end int
+}
+
+func (s span) length() int {
+	return s.end - s.start
+}
+
+func (*chunker) Chunk(text string, options *domain.ChunkingOptions) []*domain.Chunk {
+	runes := []rune(text)
+	whole := span{start: 0, end: len(runes)}
+
+	switch options.Chunker {
+	case domain.ChunkerFixed:
+		return chunks(runes, windows(whole, options.Size, options.Overlap), nil)
+	case domain.ChunkerSentence:
+		var pieces []span
+		for _, sentence := range sentences(runes, whole) {
+			pieces = append(pieces, windows(sentence, options.Size, 0)...)
+		}
+		return chunks(runes, merge(pieces, options.Size, options.Overlap), nil)
+	case domain.ChunkerMarkdown:
+		var result []*domain.Chunk
+		for _, section := range sections(runes) {
+			pieces := recursive(runes, section.span, separators, options.Size)
+			result = append(result, chunks(runes, merge(pieces, options.Size, options.Overlap), section.headings)...)
+		}
+		return result
+	default:
+		return chunks(runes, merge(recursive(runes, whole, separators, options.Size), options.Size, options.Overlap), nil)
+	}
+}
+
+// chunks trims the spaces around the spans and returns the chunks of the
+// spans long enough.
+func chunks(runes []rune, spans []span, headings []string) []*domain.Chunk {
+	result := make([]*domain.Chunk, 0, len(spans))
+	for _, s := range spans {
+		for s.start < s.end && unicode.IsSpace(runes[s.start]) {
+			s.start++
+		}
+		for s.end > s.start && unicode.IsSpace(runes[s.end-1]) {
+			s.end--
+		}
+		if s.length() < minChunkLength {
+			continue
+		}
+
+		result = append(result, &domain.Chunk{
+			Text:     string(runes[s.start:s.end]),
+			Start:    s.start,
+			End:      s.end,
+			Headings: headings,
+		})
+	}
+	return result
+}
+
+// windows splits s into windows of size characters, each starting overlap
+// characters before the end of the previous one.
+func windows(s span, size, overlap int) []span {
+	if s.length() <= size {
+		return []span{s}
+	}
+
+	var result []span
+	for start := s.start; ; start += size - overlap {
+		end := min(start+size, s.end)
+		result = append(result, span{start: start, end: end})
+		if end == s.end {
+			return result
+		}
+	}
+}
+
+// merge packs the consecutive pieces, each of at most size characters, into
+// spans of at most size characters. A span starts with the trailing pieces of
+// the previous span fitting overlap characters.
+func merge(pieces []span, size, overlap int) []span {
+	var result []span
+	for i := 0; i < len(pieces); {
+		j := i + 1
+		for j < len(pieces) && pieces[j].end-pieces[i].start <= size {
+			j++
+		}
+		result = append(result, span{start: pieces[i].start, end: pieces[j-1].end})
+		if j == len(pieces) {
+			break
+		}
+
+		// the next span still has to fit the first piece after this one
+		next := j
+		for next-1 > i && pieces[j-1].end-pieces[next-1].start <= overlap && pieces[j].end-pieces[next-1].start <= size {
+			next--
+		}
+		i = next
+	}
+	return result
+}
+
+// recursive splits s after the first of the separators it contains into
+// pieces of at most size characters, splitting the larger pieces with the
+// next separators in turn and into windows at last.
+func recursive(runes []rune, s span, separators []string, size int) []span {
+	if s.length() <= size {
+		return []span{s}
+	}
+
+	for i, separator := range separators {
+		pieces := splitAfter(runes, s, []rune(separator))
+		if len(pieces) == 1 {
+			continue
+		}
+
+		var result []span
+		for _, piece := range pieces {
+			result = append(result, recursive(runes, piece, separators[i+1:], size)...)
+		}
+		return result
+	}
+
+	return windows(s, size, 0)
+}
+
+// splitAfter splits s after each occurrence of separator.
+func splitAfter(runes []rune, s span, separator []rune) []span {
+	var result []span
+	start := s.start
+	for i := s.start; i+len(separator) <= s.end; i++ {
+		if !hasPrefix(runes[i:s.end], separator) {
+			continue
+		}
+		i += len(separator)
+		result = append(result, span{start: start, end: i})
+		start = i
+		i--
+	}
+	if start < s.end {
+		result = append(result, span{start: start, end: s.end})
+	}
+	return result
+}
+
+func hasPrefix(runes, prefix []rune) bool {
+	if len(runes) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if runes[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// sentences splits s after the sentence terminators followed by a space and
+// after the line breaks, keeping the spaces with the preceding sentence.
+func sentences(runes []rune, s span) []span {
+	var result []span
+	start := s.start
+	for i := s.start; i < s.end; i++ {
+		if !strings.ContainsRune(".!?\n", runes[i]) {
+			continue
+		}
+
+		end := i + 1
+		// the terminators and closing quotes and brackets end the sentence too
+		for end < s.end && strings.ContainsRune(".!?\"')]", runes[end]) {
+			end++
+		}
+		if runes[i] != '\n' && end < s.end && !unicode.IsSpace(runes[end]) {
+			// e.g. a version or a domain name
+			continue
+		}
+		for end < s.end && unicode.IsSpace(runes[end]) {
+			end++
+		}
+
+		result = append(result, span{start: start, end: end})
+		start = end
+		i = end - 1
+	}
+	if start < s.end {
+		result = append(result, span{start: start, end: s.end})
+	}
+	return result
+}
+
+type section struct {
+	span     span
+	headings []string
+}
+
+// sections splits a markdown document before its headings. The headings of a
+// section are the heading starting it and its enclosing headings. The lines
+// of the fenced code blocks are never headings.
+func sections(runes []rune) []section {
+	var (
+		result   []section
+		stack    []string
+		levels   []int
+		start    int
+		fenced   bool
+		headings []string
+	)
+
+	for lineStart := 0; lineStart < len(runes); {
+		lineEnd := lineStart
+		for lineEnd < len(runes) && runes[lineEnd] != '\n' {
+			lineEnd++
+		}
+		line := strings.TrimSpace(string(runes[lineStart:lineEnd]))
+
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			fenced = !fenced
+		}
+
+		if level, title := heading(line); !fenced && level > 0 {
+			if lineStart > start {
+				result = append(result, section{span: span{start: start, end: lineStart}, headings: headings})
+			}
+			start = lineStart
+
+			for len(levels) > 0 && levels[len(levels)-1] >= level {
+				stack, levels = stack[:len(stack)-1], levels[:len(levels)-1]
+			}
+			stack, levels = append(stack, title), append(levels, level)
+			headings = append([]string(nil), stack...)
+		}
+
+		lineStart = lineEnd + 1
+	}
+
+	if start < len(runes) {
+		result = append(result, section{span: span{start: start, end: len(runes)}, headings: headings})
+	}
+
+	return result
+}
+
+// heading returns the level and the title of an atx heading line, or a zero
+// level when the line is not a heading.
+func heading(line string) (int, string) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, ""
+	}
+
+	title := line[level:]
+	if title != "" && title[0] != ' ' && title[0] != '\t' {
+		return 0, ""
+	}
+
+	return level, strings.TrimSpace(strings.TrimRight(strings.TrimSpace(title), "#"))
+}
@@ -0,0 +1,119 @@
+package chunker_test
+
+import (
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/chunker"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Chunker_Chunk(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name    string
+		text    string
+		options *domain.ChunkingOptions
+		want    []*domain.Chunk
+	}
+
+	testCases := []testCase{
+		{
+			name:    "fixed",
+			text:    "abcdefghij",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerFixed, Size: 4, Overlap: 1},
+			want: []*domain.Chunk{
+				{Text: "abcd", Start: 0, End: 4},
+				{Text: "defg", Start: 3, End: 7},
+				{Text: "ghij", Start: 6, End: 10},
+			},
+		},
+		{
+			name:    "fixed_characters",
+			text:    "héllo wörld",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerFixed, Size: 5, Overlap: 0},
+			want: []*domain.Chunk{
+				{Text: "héllo", Start: 0, End: 5},
+				{Text: "wörl", Start: 6, End: 10},
+			},
+		},
+		{
+			name:    "sentence",
+			text:    "One. Two two. Three!",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerSentence, Size: 10, Overlap: 0},
+			want: []*domain.Chunk{
+				{Text: "One.", Start: 0, End: 4},
+				{Text: "Two two.", Start: 5, End: 13},
+				{Text: "Three!", Start: 14, End: 20},
+			},
+		},
+		{
+			name:    "sentence_version",
+			text:    "Use v1.2 now. Done.",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerSentence, Size: 14, Overlap: 0},
+			want: []*domain.Chunk{
+				{Text: "Use v1.2 now.", Start: 0, End: 13},
+				{Text: "Done.", Start: 14, End: 19},
+			},
+		},
+		{
+			name:    "recursive",
+			text:    "para one\n\npara two is longer",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerRecursive, Size: 12, Overlap: 0},
+			want: []*domain.Chunk{
+				{Text: "para one", Start: 0, End: 8},
+				{Text: "para two is", Start: 10, End: 21},
+				{Text: "longer", Start: 22, End: 28},
+			},
+		},
+		{
+			name:    "recursive_overlap",
+			text:    "aa bb cc dd",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerRecursive, Size: 6, Overlap: 3},
+			want: []*domain.Chunk{
+				{Text: "aa bb", Start: 0, End: 5},
+				{Text: "bb cc", Start: 3, End: 8},
+				{Text: "cc dd", Start: 6, End: 11},
+			},
+		},
+		{
+			name:    "markdown",
+			text:    "# Title\nintro\n## Part\nbody\n```\n# not heading\n```\n# Next\nend",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerMarkdown, Size: 100, Overlap: 0},
+			want: []*domain.Chunk{
+				{Text: "# Title\nintro", Start: 0, End: 13, Headings: []string{"Title"}},
+				{Text: "## Part\nbody\n```\n# not heading\n```", Start: 14, End: 48, Headings: []string{"Title", "Part"}},
+				{Text: "# Next\nend", Start: 49, End: 59, Headings: []string{"Next"}},
+			},
+		},
+		{
+			name:    "markdown_preamble",
+			text:    "preamble\n#hashtag\n### Deep ###\ntext",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerMarkdown, Size: 100, Overlap: 0},
+			want: []*domain.Chunk{
+				{Text: "preamble\n#hashtag", Start: 0, End: 17},
+				{Text: "### Deep ###\ntext", Start: 18, End: 35, Headings: []string{"Deep"}},
+			},
+		},
+		{
+			name:    "blank",
+			text:    " \n\n x ",
+			options: &domain.ChunkingOptions{Chunker: domain.ChunkerRecursive, Size: 100, Overlap: 0},
+			want:    []*domain.Chunk{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := chunker.NewChunker().Chunk(tc.text, tc.options)
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
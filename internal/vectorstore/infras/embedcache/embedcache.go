@@ -0,0 +1,386 @@
+package embedcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/usecase"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/text/unicode/norm"
+)
+
+const pruneInterval = time.Hour
+
+// The tiers of the cache hits.
+const (
+	tierMemory = "memory"
+	tierStore  = "store"
+)
+
+// key is the embedding model and the hash of the normalized text of an embedding.
+type key struct {
+	model string
+	hash  [sha256.Size]byte
+}
+
+func newKey(model, text string) key {
+	return key{model: model, hash: sha256.Sum256([]byte(normalize(text)))}
+}
+
+// normalize composes the unicode characters and collapses the spaces, so
+// texts only differing in them share their embedding.
+func normalize(text string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(text)), " ")
+}
+
+type entry struct {
+	key       key
+	embedding []float32
+}
+
+type cache struct {
+	mu sync.Mutex
+	// entries is ordered from the most to the least recently used.
+	entries *list.List
+	byKey   map[key]*list.Element
+	size    int
+
+	// store is nil when the embeddings are kept in memory only.
+	store    *store
+	storeTTL time.Duration
+	// stop stops pruning the store, which is done once done is closed.
+	stop chan struct{}
+	done chan struct{}
+
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+	tracer trace.Tracer
+	logger *slog.Logger
+}
+
+// NewCache keeps the most recently used embeddings in memory, evicting the
+// least recently used beyond the cache size, and with a store path persists
+// them in a bbolt file. With a store ttl, the stored embeddings are pruned
+// every hour once they are older than it, otherwise the file grows with
+// every embedded text. The hits, by their memory or store tier, and the
+// misses are counted in the embedding_cache.hits and embedding_cache.misses
+// counters of meter.
+func NewCache(
+	config *config.Config,
+	meter metric.Meter,
+	tracer trace.Tracer,
+	logger *slog.Logger,
+) (*cache, error) {
+	hits, err := meter.Int64Counter(
+		"embedding_cache.hits",
+		metric.WithDescription("The number of texts embedded from the embedding cache."),
+		metric.WithUnit("{text}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the hits counter: %w", err)
+	}
+
+	misses, err := meter.Int64Counter(
+		"embedding_cache.misses",
+		metric.WithDescription("The number of texts missing from the embedding cache."),
+		metric.WithUnit("{text}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the misses counter: %w", err)
+	}
+
+	c := &cache{
+		entries:  list.New(),
+		byKey:    make(map[key]*list.Element),
+		size:     config.EmbeddingCacheConfig.Size,
+		storeTTL: config.EmbeddingCacheConfig.StoreTTL,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		hits:     hits,
+		misses:   misses,
+		tracer:   tracer,
+		logger:   logger,
+	}
+
+	if config.EmbeddingCacheConfig.StorePath != "" {
+		c.store, err = openStore(config.EmbeddingCacheConfig.StorePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.store != nil && c.storeTTL > 0 {
+		go c.pruneEvery(pruneInterval)
+	} else {
+		close(c.done)
+	}
+
+	return c, nil
+}
+
+// Close stops pruning the store and closes it.
+func (c *cache) Close() error {
+	close(c.stop)
+	<-c.done
+
+	if c.store == nil {
+		return nil
+	}
+	return c.store.close()
+}
+
+// Prune deletes the embeddings stored before before and returns their count.
+func (c *cache) Prune(ctx context.Context, before time.Time) (pruned int, err error) {
+	ctx, span := c.tracer.Start(ctx, "cache.Prune")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if c.store == nil {
+		return 0, nil
+	}
+
+	return c.store.prune(before)
+}
+
+func (c *cache) pruneEvery(interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			pruned, err := c.Prune(context.Background(), now.Add(-c.storeTTL))
+			if err != nil {
+				c.logger.Error("failed to prune embedding cache store", slog.String("error", err.Error()))
+				continue
+			}
+			c.logger.Debug("pruned embedding cache store", slog.Int("count", pruned))
+		}
+	}
+}
+
+type cachedEmbedder struct {
+	embedder usecase.Embedder
+	model    string
+	cache    *cache
+}
+
+var _ usecase.Embedder = (*cachedEmbedder)(nil)
+
+// Embedder caches the embeddings of embedder, the embedder of collection
+// with model. The stored embeddings of the previous model of the collection
+// are deleted when its model changes.
+func (c *cache) Embedder(collection string, embedder usecase.Embedder, model string) (*cachedEmbedder, error) {
+	if c.store != nil {
+		previousModel, err := c.store.use(collection, model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to use model %s for collection %s: %w", model, collection, err)
+		}
+		if previousModel != "" {
+			c.logger.Info("invalidated the embedding cache of the changed model", slog.String("collection", collection), slog.String("previous model", previousModel), slog.String("model", model))
+		}
+	}
+
+	return &cachedEmbedder{embedder: embedder, model: model, cache: c}, nil
+}
+
+// Embed embeds the texts missing from the cache with the embedder, once per
+// normalized text, and caches their embeddings.
+func (e *cachedEmbedder) Embed(ctx context.Context, texts []string) (_ [][]float32, err error) {
+	ctx, span := e.cache.tracer.Start(ctx, "cachedEmbedder.Embed")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if len(texts) == 0 {
+		return e.embedder.Embed(ctx, texts)
+	}
+
+	keys := make([]key, len(texts))
+	for i, text := range texts {
+		keys[i] = newKey(e.model, text)
+	}
+
+	embeddings := e.cache.lookup(ctx, keys)
+
+	// the missing texts are embedded once per key
+	var (
+		missingTexts   []string
+		missingKeys    []key
+		missingIndexes [][]int
+		missing        = make(map[key]int)
+	)
+	for i, embedding := range embeddings {
+		if embedding != nil {
+			continue
+		}
+		if j, ok := missing[keys[i]]; ok {
+			missingIndexes[j] = append(missingIndexes[j], i)
+			continue
+		}
+		missing[keys[i]] = len(missingTexts)
+		missingTexts = append(missingTexts, texts[i])
+		missingKeys = append(missingKeys, keys[i])
+		missingIndexes = append(missingIndexes, []int{i})
+	}
+	if len(missingTexts) == 0 {
+		return embeddings, nil
+	}
+
+	missingEmbeddings, err := e.embedder.Embed(ctx, missingTexts)
+	embedError, partial := err.(*domain.EmbedError)
+	if err != nil && !partial {
+		return nil, err
+	}
+
+	if len(missingEmbeddings) != len(missingTexts) {
+		return nil, fmt.Errorf("invalid embeddings length: texts length = %d, embeddings length = %d", len(missingTexts), len(missingEmbeddings))
+	}
+
+	// the embedded texts are cached even when others failed
+	var (
+		embeddedKeys       []key
+		embeddedEmbeddings [][]float32
+	)
+	for j, embedding := range missingEmbeddings {
+		if embedding == nil {
+			continue
+		}
+		for _, index := range missingIndexes[j] {
+			embeddings[index] = embedding
+		}
+		embeddedKeys = append(embeddedKeys, missingKeys[j])
+		embeddedEmbeddings = append(embeddedEmbeddings, embedding)
+	}
+
+	if len(embeddedKeys) > 0 {
+		e.cache.add(ctx, embeddedKeys, embeddedEmbeddings)
+	}
+
+	if partial {
+		// the failures are reported at the indexes of the texts
+		textsEmbedError := &domain.EmbedError{}
+		for _, failure := range embedError.Failures {
+			for _, index := range missingIndexes[failure.Index] {
+				textsEmbedError.Failures = append(textsEmbedError.Failures, &domain.EmbedFailure{Index: index, Err: failure.Err})
+			}
+		}
+		slices.SortFunc(textsEmbedError.Failures, func(a, b *domain.EmbedFailure) int { return a.Index - b.Index })
+		return embeddings, textsEmbedError
+	}
+
+	return embeddings, nil
+}
+
+// lookup returns the cached embeddings of the keys, nil for the missing ones.
+// The memory is looked up before the store.
+func (c *cache) lookup(ctx context.Context, keys []key) [][]float32 {
+	embeddings := make([][]float32, len(keys))
+
+	var storeKeys []int
+	c.mu.Lock()
+	for i, k := range keys {
+		element, ok := c.byKey[k]
+		if !ok {
+			storeKeys = append(storeKeys, i)
+			continue
+		}
+		c.entries.MoveToFront(element)
+		embeddings[i] = slices.Clone(element.Value.(*entry).embedding)
+	}
+	c.mu.Unlock()
+	c.hits.Add(ctx, int64(len(keys)-len(storeKeys)), metric.WithAttributes(attribute.String("tier", tierMemory)))
+
+	storeHits := 0
+	if c.store != nil && len(storeKeys) > 0 {
+		stored, err := c.store.get(lookupKeys(keys, storeKeys))
+		if err != nil {
+			c.logger.WarnContext(ctx, "failed to get stored embeddings", slog.String("error", err.Error()))
+		}
+
+		var hitKeys []key
+		var hitEmbeddings [][]float32
+		for j, embedding := range stored {
+			if embedding == nil {
+				continue
+			}
+			i := storeKeys[j]
+			embeddings[i] = embedding
+			hitKeys = append(hitKeys, keys[i])
+			hitEmbeddings = append(hitEmbeddings, embedding)
+		}
+		storeHits = len(hitKeys)
+		c.remember(hitKeys, hitEmbeddings)
+	}
+	c.hits.Add(ctx, int64(storeHits), metric.WithAttributes(attribute.String("tier", tierStore)))
+	c.misses.Add(ctx, int64(len(storeKeys)-storeHits))
+
+	return embeddings
+}
+
+func lookupKeys(keys []key, indexes []int) []key {
+	selected := make([]key, len(indexes))
+	for j, i := range indexes {
+		selected[j] = keys[i]
+	}
+	return selected
+}
+
+// add caches the embeddings of the keys in memory and in the store.
+func (c *cache) add(ctx context.Context, keys []key, embeddings [][]float32) {
+	c.remember(keys, embeddings)
+
+	if c.store != nil {
+		if err := c.store.put(keys, embeddings, time.Now()); err != nil {
+			c.logger.WarnContext(ctx, "failed to store embeddings", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// remember keeps the embeddings of the keys in memory.
+func (c *cache) remember(keys []key, embeddings [][]float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, k := range keys {
+		embedding := slices.Clone(embeddings[i])
+		if element, ok := c.byKey[k]; ok {
+			element.Value.(*entry).embedding = embedding
+			c.entries.MoveToFront(element)
+			continue
+		}
+		c.byKey[k] = c.entries.PushFront(&entry{key: k, embedding: embedding})
+	}
+
+	for c.entries.Len() > c.size {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.byKey, oldest.Value.(*entry).key)
+	}
+}
@@ -0,0 +1,255 @@
+package embedcache_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/embedcache"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	otel_metric_noop "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	otel_trace_noop "go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	tracer = otel_trace_noop.NewTracerProvider().Tracer("")
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+)
+
+// fakeEmbedder embeds a text into its length and records the embedded texts.
+// The texts of failing fail to embed, returned with the embeddings of the others.
+type fakeEmbedder struct {
+	calls   [][]string
+	failing map[string]bool
+}
+
+func (e *fakeEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.calls = append(e.calls, texts)
+
+	embedError := &domain.EmbedError{}
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		if e.failing[text] {
+			embedError.Failures = append(embedError.Failures, &domain.EmbedFailure{Index: i, Err: errors.New("input is too large")})
+			continue
+		}
+		embeddings[i] = []float32{float32(len(text))}
+	}
+	if len(embedError.Failures) > 0 {
+		return embeddings, embedError
+	}
+	return embeddings, nil
+}
+
+func Test_CachedEmbedder_Embed(t *testing.T) {
+	t.Parallel()
+
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("")
+
+	cache, err := embedcache.NewCache(&config.Config{EmbeddingCacheConfig: config.EmbeddingCacheConfig{Size: 2}}, meter, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	fake := &fakeEmbedder{}
+	embedder, err := cache.Embedder("collection", fake, "model")
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	type call struct {
+		texts []string
+		want  [][]float32
+	}
+
+	calls := []call{
+		// the texts only differing in their spaces are embedded once
+		{texts: []string{"a b", "abc", " a  b\n"}, want: [][]float32{{3}, {3}, {3}}},
+		{texts: []string{"abc", "abcd"}, want: [][]float32{{3}, {4}}},
+		// a b is the least recently used, so it was evicted
+		{texts: []string{"a b"}, want: [][]float32{{3}}},
+	}
+	for _, call := range calls {
+		embeddings, err := embedder.Embed(context.Background(), call.texts)
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		if diff := cmp.Diff(call.want, embeddings); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+
+	if diff := cmp.Diff([][]string{{"a b", "abc"}, {"abcd"}, {"a b"}}, fake.calls); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	counts := make(map[string]int64)
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		for _, dataPoint := range m.Data.(metricdata.Sum[int64]).DataPoints {
+			tier, _ := dataPoint.Attributes.Value(attribute.Key("tier"))
+			counts[m.Name+"/"+tier.AsString()] += dataPoint.Value
+		}
+	}
+	if diff := cmp.Diff(map[string]int64{"embedding_cache.hits/memory": 1, "embedding_cache.hits/store": 0, "embedding_cache.misses/": 5}, counts); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_CachedEmbedder_Embed_Failures(t *testing.T) {
+	t.Parallel()
+
+	cache, err := embedcache.NewCache(&config.Config{EmbeddingCacheConfig: config.EmbeddingCacheConfig{Size: 10}}, otel_metric_noop.NewMeterProvider().Meter(""), tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	fake := &fakeEmbedder{failing: map[string]bool{"too large": true}}
+	embedder, err := cache.Embedder("collection", fake, "model")
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if _, err := embedder.Embed(context.Background(), []string{"cached"}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// the failures are at the indexes of the texts, not of the embedded ones
+	embeddings, err := embedder.Embed(context.Background(), []string{"cached", "ok", "too large", "too  large"})
+
+	var embedError *domain.EmbedError
+	if !errors.As(err, &embedError) {
+		t.Fatalf("want a *domain.EmbedError, got %v", err)
+	}
+	indexes := make([]int, len(embedError.Failures))
+	for i, failure := range embedError.Failures {
+		indexes[i] = failure.Index
+	}
+	if diff := cmp.Diff([]int{2, 3}, indexes); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// the others are returned and cached
+	if diff := cmp.Diff([][]float32{{6}, {2}, nil, nil}, embeddings); diff != "" {
+		t.Fatal(diff)
+	}
+	if _, err := embedder.Embed(context.Background(), []string{"ok"}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff([][]string{{"cached"}, {"ok", "too large"}}, fake.calls); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_CachedEmbedder_Embed_Store(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "embeddings.db")
+
+	// embed embeds the texts with a new cache of the store, as after a
+	// restart, and returns the texts the embedder embedded.
+	embed := func(model string, texts []string) [][]string {
+		t.Helper()
+
+		cache, err := embedcache.NewCache(
+			&config.Config{EmbeddingCacheConfig: config.EmbeddingCacheConfig{Size: 10, StorePath: path}},
+			otel_metric_noop.NewMeterProvider().Meter(""),
+			tracer,
+			logger,
+		)
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		defer cache.Close()
+
+		fake := &fakeEmbedder{}
+		embedder, err := cache.Embedder("collection", fake, model)
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+
+		embeddings, err := embedder.Embed(context.Background(), texts)
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		want := make([][]float32, len(texts))
+		for i, text := range texts {
+			want[i] = []float32{float32(len(text))}
+		}
+		if diff := cmp.Diff(want, embeddings); diff != "" {
+			t.Fatal(diff)
+		}
+
+		return fake.calls
+	}
+
+	if diff := cmp.Diff([][]string{{"a", "bb"}}, embed("model-1", []string{"a", "bb"})); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([][]string{{"ccc"}}, embed("model-1", []string{"a", "bb", "ccc"})); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// a changed model misses and deletes the embeddings of the previous one
+	if diff := cmp.Diff([][]string{{"a"}}, embed("model-2", []string{"a"})); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([][]string{{"bb"}}, embed("model-1", []string{"bb"})); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_Cache_Prune(t *testing.T) {
+	t.Parallel()
+
+	cache, err := embedcache.NewCache(
+		&config.Config{EmbeddingCacheConfig: config.EmbeddingCacheConfig{Size: 10, StorePath: filepath.Join(t.TempDir(), "embeddings.db"), StoreTTL: time.Hour}},
+		otel_metric_noop.NewMeterProvider().Meter(""),
+		tracer,
+		logger,
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	defer cache.Close()
+
+	embedder, err := cache.Embedder("collection", &fakeEmbedder{}, "model")
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if _, err := embedder.Embed(context.Background(), []string{"a", "bb"}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// nothing was stored before an hour ago
+	pruned, err := cache.Prune(context.Background(), time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(0, pruned); diff != "" {
+		t.Fatal(diff)
+	}
+
+	pruned, err = cache.Prune(context.Background(), time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(2, pruned); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,177 @@
+package embedcache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var collectionsBucket = []byte("collections")
+
+// openTimeout bounds waiting for the file lock held by another process.
+const openTimeout = time.Second
+
+// storedAtSize is the size of the store time prefixing the stored embeddings.
+const storedAtSize = 8
+
+// store persists the embeddings in a bucket per model, keyed by the hash of
+// their normalized text and prefixed with the unix milliseconds they were
+// stored at. The collections bucket records the model of every collection.
+type store struct {
+	db *bbolt.DB
+}
+
+func openStore(path string) (*store, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedding cache store %s: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(collectionsBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create embedding cache store buckets: %w", err)
+	}
+
+	return &store{db: db}, nil
+}
+
+func (s *store) close() error {
+	return s.db.Close()
+}
+
+func modelBucket(model string) []byte {
+	return []byte("model:" + model)
+}
+
+// use records model as the model of collection. When it replaces another
+// model, it returns the previous model and deletes its embeddings unless
+// another collection still uses it.
+func (s *store) use(collection, model string) (previousModel string, err error) {
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		collections := tx.Bucket(collectionsBucket)
+
+		previousModel = string(collections.Get([]byte(collection)))
+		if previousModel == model {
+			previousModel = ""
+			return nil
+		}
+
+		if err := collections.Put([]byte(collection), []byte(model)); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(modelBucket(model)); err != nil {
+			return err
+		}
+
+		if previousModel == "" {
+			return nil
+		}
+		inUse := false
+		if err := collections.ForEach(func(_, value []byte) error {
+			inUse = inUse || string(value) == previousModel
+			return nil
+		}); err != nil {
+			return err
+		}
+		if inUse {
+			return nil
+		}
+		if err := tx.DeleteBucket(modelBucket(previousModel)); err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return err
+		}
+		return nil
+	})
+	return previousModel, err
+}
+
+// get returns the stored embeddings of the keys, nil for the missing ones.
+func (s *store) get(keys []key) ([][]float32, error) {
+	embeddings := make([][]float32, len(keys))
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		for i, k := range keys {
+			bucket := tx.Bucket(modelBucket(k.model))
+			if bucket == nil {
+				continue
+			}
+			if value := bucket.Get(k.hash[:]); value != nil {
+				embeddings[i] = decodeEmbedding(value[storedAtSize:])
+			}
+		}
+		return nil
+	})
+	return embeddings, err
+}
+
+// put stores the embeddings of the keys at now.
+func (s *store) put(keys []key, embeddings [][]float32, now time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		for i, k := range keys {
+			bucket, err := tx.CreateBucketIfNotExists(modelBucket(k.model))
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(k.hash[:], encodeEmbedding(embeddings[i], now)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// prune deletes the embeddings stored before before and returns their count.
+func (s *store) prune(before time.Time) (pruned int, err error) {
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bbolt.Bucket) error {
+			if bytes.Equal(name, collectionsBucket) {
+				return nil
+			}
+
+			// deleting while iterating skips keys so the keys are collected first
+			var expired [][]byte
+			if err := bucket.ForEach(func(key, value []byte) error {
+				if int64(binary.LittleEndian.Uint64(value)) < before.UnixMilli() {
+					expired = append(expired, key)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			for _, key := range expired {
+				if err := bucket.Delete(key); err != nil {
+					return err
+				}
+			}
+			pruned += len(expired)
+			return nil
+		})
+	})
+	return pruned, err
+}
+
+func encodeEmbedding(embedding []float32, storedAt time.Time) []byte {
+	value := make([]byte, storedAtSize+4*len(embedding))
+	binary.LittleEndian.PutUint64(value, uint64(storedAt.UnixMilli()))
+	for i, component := range embedding {
+		binary.LittleEndian.PutUint32(value[storedAtSize+4*i:], math.Float32bits(component))
+	}
+	return value
+}
+
+// decodeEmbedding copies the embedding out of value, which is only valid in
+// its transaction.
+func decodeEmbedding(value []byte) []float32 {
+	embedding := make([]float32, len(value)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(value[4*i:]))
+	}
+	return embedding
+}
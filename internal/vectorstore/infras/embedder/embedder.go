@@ -2,11 +2,19 @@ package embedder
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"sync"
 
+	"github.com/aria3ppp/rag-server/internal/pkg/tokenizer"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/usecase"
 	"github.com/tmc/langchaingo/llms/openai"
 	"go.opentelemetry.io/otel/codes"
@@ -15,6 +23,7 @@ import (
 
 type embedder struct {
 	llmClient *openai.LLM
+	model     string
 	config    *config.EmbedderConfig
 	tracer    trace.Tracer
 	logger    *slog.Logger
@@ -49,12 +58,24 @@ func NewEmbedder(
 
 	return &embedder{
 		llmClient: llmClient,
+		model:     fingerprint(embeddings[0]),
 		config:    &config.EmbedderConfig,
 		tracer:    tracer,
 		logger:    logger,
 	}, nil
 }
 
+// Model identifies the embedding model by a fingerprint of its embedding of
+// the empty text, so a model swapped behind the same url is told apart.
+func (e *embedder) Model() string {
+	return e.model
+}
+
+// Embed embeds the texts in batches bounded by the max batch size and tokens,
+// sending at most the configured concurrency of batches at a time. The texts
+// of a failed batch are embedded again one by one, and the ones failing alone
+// are returned in a *domain.EmbedError along with the embeddings of the
+// others.
 func (e *embedder) Embed(ctx context.Context, texts []string) (_ [][]float32, err error) {
 	ctx, span := e.tracer.Start(ctx, "embedder.Embed")
 	defer func() {
@@ -65,11 +86,133 @@ func (e *embedder) Embed(ctx context.Context, texts []string) (_ [][]float32, er
 		}
 	}()
 
+	if len(texts) == 0 {
+		return nil, errors.New("no texts to embed")
+	}
+
+	var (
+		wg         sync.WaitGroup
+		semaphore  = make(chan struct{}, max(e.config.Concurrency, 1))
+		embeddings = make([][]float32, len(texts))
+		errs       = make([]error, len(texts))
+	)
+	for _, batch := range e.batches(texts) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				for index := batch.start; index < batch.end; index++ {
+					errs[index] = ctx.Err()
+				}
+				return
+			}
+
+			e.embedBatch(ctx, texts, batch, embeddings, errs)
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	embedError := &domain.EmbedError{}
+	for index, err := range errs {
+		if err != nil {
+			embedError.Failures = append(embedError.Failures, &domain.EmbedFailure{Index: index, Err: err})
+		}
+	}
+	if len(embedError.Failures) > 0 {
+		e.logger.ErrorContext(ctx, "failed to embed texts", slog.Int("texts", len(texts)), slog.Int("failed texts", len(embedError.Failures)), slog.String("error", embedError.Error()))
+		return embeddings, embedError
+	}
+
+	return embeddings, nil
+}
+
+// batch is the texts[start:end] of an embedding request.
+type batch struct {
+	start, end int
+}
+
+// batches splits the texts into consecutive batches within the max batch
+// size and tokens.
+func (e *embedder) batches(texts []string) []batch {
+	var (
+		batches []batch
+		current batch
+		tokens  int
+	)
+	for index, text := range texts {
+		textTokens := tokenizer.EstimateTokens(text)
+
+		size := current.end - current.start
+		if size > 0 &&
+			(e.config.MaxBatchSize > 0 && size >= e.config.MaxBatchSize ||
+				e.config.MaxBatchTokens > 0 && tokens+textTokens > e.config.MaxBatchTokens) {
+			batches = append(batches, current)
+			current, tokens = batch{start: index}, 0
+		}
+
+		current.end = index + 1
+		tokens += textTokens
+	}
+
+	return append(batches, current)
+}
+
+// embedBatch embeds the batch of texts into embeddings, falling back to
+// embedding its texts one by one when the batch fails. The errors of the
+// texts failing alone are set in errs.
+func (e *embedder) embedBatch(ctx context.Context, texts []string, batch batch, embeddings [][]float32, errs []error) {
+	batchEmbeddings, err := e.createEmbedding(ctx, texts[batch.start:batch.end])
+	if err == nil {
+		copy(embeddings[batch.start:batch.end], batchEmbeddings)
+		return
+	}
+
+	if batch.end-batch.start == 1 {
+		e.logger.ErrorContext(ctx, "failed to llm client create embedding", slog.Int("index", batch.start), slog.String("error", err.Error()))
+		errs[batch.start] = err
+		return
+	}
+
+	e.logger.WarnContext(ctx, "failed to embed batch, embedding its texts one by one", slog.Int("first index", batch.start), slog.Int("batch size", batch.end-batch.start), slog.String("error", err.Error()))
+
+	for index := batch.start; index < batch.end; index++ {
+		if ctx.Err() != nil {
+			errs[index] = ctx.Err()
+			continue
+		}
+
+		textEmbeddings, err := e.createEmbedding(ctx, texts[index:index+1])
+		if err != nil {
+			e.logger.ErrorContext(ctx, "failed to llm client create embedding", slog.Int("index", index), slog.String("error", err.Error()))
+			errs[index] = err
+			continue
+		}
+		embeddings[index] = textEmbeddings[0]
+	}
+}
+
+func (e *embedder) createEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
 	embeddings, err := e.llmClient.CreateEmbedding(ctx, texts)
 	if err != nil {
-		e.logger.ErrorContext(ctx, "failed to llm client create embedding", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("failed to llm client create embedding: %w", err)
 	}
-
 	return embeddings, nil
 }
+
+// fingerprint hashes the embedding rounded to 4 decimals, so the float noise
+// of different hardware doesn't change it.
+func fingerprint(embedding []float32) string {
+	hash := sha256.New()
+	for _, value := range embedding {
+		binary.Write(hash, binary.LittleEndian, int32(math.Round(float64(value)*1e4)))
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
@@ -2,16 +2,21 @@ package embedder_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"runtime"
+	"sync/atomic"
 	"testing"
 
+	"github.com/aria3ppp/rag-server/internal/pkg/fakemodel"
+	"github.com/aria3ppp/rag-server/internal/pkg/test/cassette"
 	test_server "github.com/aria3ppp/rag-server/internal/pkg/test/server"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/embedder"
 
 	"github.com/google/go-cmp/cmp"
@@ -73,8 +78,10 @@ func TestNewEmbedder(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			recorder := cassette.NewTest(t, t.Name(), nil)
+
 			embedderBaseURL := fmt.Sprintf("http://localhost:%d/v1", embedderPort)
-			embedderEmbeddingSize := test_server.GetEmbedderEmbeddingSize(t, embedderBaseURL)
+			embedderEmbeddingSize := test_server.GetEmbedderEmbeddingSize(t, embedderBaseURL, recorder.Client())
 
 			tt.input.config.EmbedderConfig.BaseURL = embedderBaseURL
 			tt.input.config.QdrantConfig.VectorSize = embedderEmbeddingSize
@@ -83,7 +90,7 @@ func TestNewEmbedder(t *testing.T) {
 				tt.input.config,
 				tt.input.tracer,
 				tt.input.logger,
-				http.DefaultClient,
+				recorder.Client(),
 			)
 			if (err != nil) != tt.want.err {
 				t.Fatal(cmp.Diff(err, nil))
@@ -96,6 +103,112 @@ func TestNewEmbedder(t *testing.T) {
 	}
 }
 
+func TestEmbedder_Batches(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		// requests include the embedding size probe of the embedder
+		requests      int64
+		failedIndexes []int
+	}
+
+	type testCase struct {
+		name     string
+		opts     *fakemodel.Opts
+		embedder config.EmbedderConfig
+		want     want
+	}
+
+	texts := []string{"the quick brown fox", "jumps over the lazy dog", "and runs away", "tax returns are due", "today"}
+
+	testCases := []testCase{
+		{
+			name:     "batch_size",
+			opts:     &fakemodel.Opts{MaxBatchSize: 2},
+			embedder: config.EmbedderConfig{MaxBatchSize: 2, Concurrency: 2},
+			want:     want{requests: 1 + 3},
+		},
+		{
+			name: "batch_tokens",
+			opts: &fakemodel.Opts{MaxBatchTokens: 9},
+			// estimated 5+6 and 4+5+2 tokens
+			embedder: config.EmbedderConfig{MaxBatchTokens: 11, Concurrency: 4},
+			want:     want{requests: 1 + 2},
+		},
+		{
+			name:     "failed_batch_embedded_one_by_one",
+			opts:     &fakemodel.Opts{MaxBatchSize: 2},
+			embedder: config.EmbedderConfig{Concurrency: 1},
+			want:     want{requests: 1 + 1 + 5},
+		},
+		{
+			name:     "failed_text",
+			opts:     &fakemodel.Opts{MaxBatchTokens: 4},
+			embedder: config.EmbedderConfig{MaxBatchSize: 3, Concurrency: 2},
+			want:     want{requests: 1 + 2 + 5, failedIndexes: []int{1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int64
+			tc.opts.EmbeddingSize = 16
+			tc.opts.Fault = func(r *http.Request) int {
+				requests.Add(1)
+				return 0
+			}
+			server := fakemodel.NewTestServer(t, tc.opts)
+
+			cfg := &config.Config{EmbedderConfig: tc.embedder}
+			cfg.EmbedderConfig.BaseURL = server.URL + "/v1"
+			cfg.QdrantConfig.VectorSize = 16
+
+			em, err := embedder.NewEmbedder(
+				context.Background(),
+				cfg,
+				otel_trace_noop.NewTracerProvider().Tracer(""),
+				slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+				server.Client(),
+			)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			embeddings, err := em.Embed(context.Background(), texts)
+
+			if diff := cmp.Diff(tc.want.requests, requests.Load()); diff != "" {
+				t.Fatal(diff)
+			}
+
+			if tc.want.failedIndexes != nil {
+				var embedError *domain.EmbedError
+				if !errors.As(err, &embedError) {
+					t.Fatalf("want a *domain.EmbedError, got %v", err)
+				}
+				failedIndexes := make([]int, len(embedError.Failures))
+				for i, failure := range embedError.Failures {
+					failedIndexes[i] = failure.Index
+				}
+				if diff := cmp.Diff(tc.want.failedIndexes, failedIndexes); diff != "" {
+					t.Fatal(diff)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+			for i, text := range texts {
+				if diff := cmp.Diff(fakemodel.Embed(text, 16), embeddings[i]); diff != "" {
+					t.Fatalf("text %d: %s", i, diff)
+				}
+			}
+		})
+	}
+}
+
 func Test_Embedder_Embed(t *testing.T) {
 	t.Parallel()
 
@@ -147,8 +260,10 @@ func Test_Embedder_Embed(t *testing.T) {
 			t.Parallel()
 			ctx := context.Background()
 
+			recorder := cassette.NewTest(t, t.Name(), nil)
+
 			embedderBaseURL := fmt.Sprintf("http://localhost:%d/v1", embedderPort)
-			embedderEmbeddingSize := test_server.GetEmbedderEmbeddingSize(t, embedderBaseURL)
+			embedderEmbeddingSize := test_server.GetEmbedderEmbeddingSize(t, embedderBaseURL, recorder.Client())
 
 			tt.config.EmbedderConfig.BaseURL = embedderBaseURL
 			tt.config.QdrantConfig.VectorSize = embedderEmbeddingSize
@@ -157,7 +272,7 @@ func Test_Embedder_Embed(t *testing.T) {
 				&tt.config,
 				otel_trace_noop.NewTracerProvider().Tracer(""),
 				slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
-				http.DefaultClient,
+				recorder.Client(),
 			)
 
 			if err != nil {
@@ -181,7 +296,7 @@ var embedderPort int
 func TestMain(m *testing.M) {
 	const embedderTestcontainer = "embedder_testcontainer"
 
-	ports, cleanup := test_server.SetupServers(
+	ports, cleanup := test_server.SetupModelServers(
 		test_server.NewFatalizer(runtime.FuncForPC(func() uintptr { pc, _, _, _ := runtime.Caller(1); return pc }()).Name()),
 		map[string]test_server.TestServerFunc{
 			embedderTestcontainer: test_server.SetupEmbedderServer,
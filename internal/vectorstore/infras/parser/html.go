@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// headingLevels are the levels of the html headings.
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1,
+	atom.H2: 2,
+	atom.H3: 3,
+	atom.H4: 4,
+	atom.H5: 5,
+	atom.H6: 6,
+}
+
+// parseHTML extracts the paragraphs of the main content of a page, like the
+// populate script: the first main or article element, else the element of id
+// content, else the whole page. The headings are kept as markdown headings so
+// the markdown chunker tracks the headings path of the chunks.
+func parseHTML(content string) (*domain.ParsedDocument, error) {
+	root, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("malformed html: %w", err)
+	}
+
+	source := find(root, func(n *html.Node) bool { return n.DataAtom == atom.Main || n.DataAtom == atom.Article })
+	if source == nil {
+		source = find(root, func(n *html.Node) bool { return attribute(n, "id") == "content" })
+	}
+	if source == nil {
+		source = root
+	}
+
+	var (
+		blocks       []string
+		firstHeading string
+	)
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+
+			if level, ok := headingLevels[child.DataAtom]; ok {
+				if text := textContent(child); text != "" {
+					blocks = append(blocks, strings.Repeat("#", level)+" "+text)
+					if firstHeading == "" && level == 1 {
+						firstHeading = text
+					}
+				}
+				continue
+			}
+
+			if child.DataAtom == atom.P {
+				if text := textContent(child); text != "" {
+					blocks = append(blocks, text)
+				}
+				continue
+			}
+
+			walk(child)
+		}
+	}
+	walk(source)
+
+	title := firstHeading
+	if titleNode := find(root, func(n *html.Node) bool { return n.DataAtom == atom.Title }); titleNode != nil && textContent(titleNode) != "" {
+		title = textContent(titleNode)
+	}
+
+	return &domain.ParsedDocument{
+		Metadata: titleMetadata(title),
+		Chunker:  domain.ChunkerMarkdown,
+		Sections: sections(strings.Join(blocks, "\n\n")),
+	}, nil
+}
+
+// find returns the first element of the tree of n in document order
+// matching match, or nil.
+func find(n *html.Node, match func(n *html.Node) bool) *html.Node {
+	if n.Type == html.ElementNode && match(n) {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := find(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func attribute(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// textContent returns the text of the tree of n without the scripts and the
+// styles, its spaces collapsed.
+func textContent(n *html.Node) string {
+	var builder strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			builder.WriteString(n.Data)
+		case n.DataAtom == atom.Script || n.DataAtom == atom.Style:
+			return
+		case n.DataAtom == atom.Br:
+			builder.WriteByte(' ')
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseMarkdown drops the yaml front matter of a markdown document, taking
+// the title from it or else from the first level one heading.
+func parseMarkdown(content string) (*domain.ParsedDocument, error) {
+	var title string
+
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		frontMatter, body, found := strings.Cut(rest, "\n---\n")
+		if !found {
+			frontMatter, found = strings.CutSuffix(rest, "\n---")
+		}
+		if found {
+			var fields map[string]any
+			if err := yaml.Unmarshal([]byte(frontMatter), &fields); err != nil {
+				return nil, fmt.Errorf("malformed front matter: %w", err)
+			}
+			title, _ = fields["title"].(string)
+			content = body
+		}
+	}
+
+	if title == "" {
+		for _, line := range strings.Split(content, "\n") {
+			if heading, ok := strings.CutPrefix(line, "# "); ok {
+				title = strings.TrimSpace(heading)
+				break
+			}
+		}
+	}
+
+	return &domain.ParsedDocument{
+		Metadata: titleMetadata(title),
+		Chunker:  domain.ChunkerMarkdown,
+		Sections: sections(content),
+	}, nil
+}
+
+func titleMetadata(title string) map[string]any {
+	if title == "" {
+		return nil
+	}
+	return map[string]any{domain.MetadataTitle: title}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/usecase"
+)
+
+// utf8BOM is dropped from the start of the contents.
+var utf8BOM = []byte("\uFEFF")
+
+// parsers parse the contents of the mime types and their aliases.
+var parsers = map[string]func(content string) (*domain.ParsedDocument, error){
+	domain.MIMETypeHTML:       parseHTML,
+	"application/xhtml+xml":   parseHTML,
+	domain.MIMETypeMarkdown:   parseMarkdown,
+	"text/x-markdown":         parseMarkdown,
+	domain.MIMETypePlain:      parsePlain,
+	domain.MIMETypeCSV:        parseCSV,
+	domain.MIMETypeJSONL:      parseJSONL,
+	"application/x-ndjson":    parseJSONL,
+	"application/x-jsonlines": parseJSONL,
+}
+
+type parser struct{}
+
+var _ usecase.Parser = (*parser)(nil)
+
+// NewParser parses the utf-8 encoded html, markdown, plain text, csv and
+// jsonl documents.
+func NewParser() *parser {
+	return &parser{}
+}
+
+func (*parser) Parse(content []byte, mimeType string) (*domain.ParsedDocument, error) {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("malformed mime type %q: %w", mimeType, err)
+	}
+
+	parse, ok := parsers[mediaType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported mime type %q, want one of %v", mediaType, slices.Sorted(maps.Keys(parsers)))
+	}
+
+	if charset, ok := params["charset"]; ok && !strings.EqualFold(charset, "utf-8") && !strings.EqualFold(charset, "us-ascii") {
+		return nil, fmt.Errorf("unsupported charset %q, want utf-8", charset)
+	}
+	if !utf8.Valid(content) {
+		return nil, errors.New("the content is not utf-8 encoded")
+	}
+
+	// the carriage returns would split the lines twice
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(content, utf8BOM)), "\r\n", "\n")
+
+	return parse(text)
+}
+
+func parsePlain(content string) (*domain.ParsedDocument, error) {
+	return &domain.ParsedDocument{Sections: sections(content)}, nil
+}
+
+// sections returns the single section of text, or none when it is blank.
+func sections(text string) []*domain.ParsedSection {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	return []*domain.ParsedSection{{Text: text}}
+}
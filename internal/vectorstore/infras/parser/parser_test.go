@@ -0,0 +1,193 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/parser"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Parser_Parse(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		content  string
+		mimeType string
+		want     *domain.ParsedDocument
+	}
+
+	testCases := []testCase{
+		{
+			name: "html",
+			content: `<html><head><title>Go (programming language)</title><script>var p = "<p>x</p>";</script></head>
+<body>
+<nav><p>Navigation</p></nav>
+<main>
+<h1>Go</h1>
+<p>Go is a   statically typed,
+compiled language.</p>
+<div><h2>History</h2><p>Designed at <b>Google</b> in 2007.</p><p>  </p></div>
+</main>
+<footer><p>Footer</p></footer>
+</body></html>`,
+			mimeType: "text/html; charset=utf-8",
+			want: &domain.ParsedDocument{
+				Metadata: map[string]any{domain.MetadataTitle: "Go (programming language)"},
+				Chunker:  domain.ChunkerMarkdown,
+				Sections: []*domain.ParsedSection{{Text: "# Go\n\nGo is a statically typed, compiled language.\n\n## History\n\nDesigned at Google in 2007."}},
+			},
+		},
+		{
+			name:     "html_content_id",
+			content:  `<body><div id="sidebar"><p>Links</p></div><div id="content"><p>First.</p><p>Second.</p></div></body>`,
+			mimeType: "application/xhtml+xml",
+			want: &domain.ParsedDocument{
+				Chunker:  domain.ChunkerMarkdown,
+				Sections: []*domain.ParsedSection{{Text: "First.\n\nSecond."}},
+			},
+		},
+		{
+			name:     "html_whole_page",
+			content:  `<body><h1>Title</h1><p>Only paragraph.</p></body>`,
+			mimeType: "text/html",
+			want: &domain.ParsedDocument{
+				Metadata: map[string]any{domain.MetadataTitle: "Title"},
+				Chunker:  domain.ChunkerMarkdown,
+				Sections: []*domain.ParsedSection{{Text: "# Title\n\nOnly paragraph."}},
+			},
+		},
+		{
+			name:     "markdown_front_matter",
+			content:  "\uFEFF---\r\ntitle: Getting Started\r\ntags: [intro]\r\n---\r\n# Install\r\nRun it.\r\n",
+			mimeType: "text/markdown",
+			want: &domain.ParsedDocument{
+				Metadata: map[string]any{domain.MetadataTitle: "Getting Started"},
+				Chunker:  domain.ChunkerMarkdown,
+				Sections: []*domain.ParsedSection{{Text: "# Install\nRun it.\n"}},
+			},
+		},
+		{
+			name:     "markdown_heading_title",
+			content:  "Intro\n\n## Not the title\n# Title\ntext",
+			mimeType: "text/x-markdown",
+			want: &domain.ParsedDocument{
+				Metadata: map[string]any{domain.MetadataTitle: "Title"},
+				Chunker:  domain.ChunkerMarkdown,
+				Sections: []*domain.ParsedSection{{Text: "Intro\n\n## Not the title\n# Title\ntext"}},
+			},
+		},
+		{
+			name:     "plain",
+			content:  "line 1\r\nline 2",
+			mimeType: "text/plain",
+			want: &domain.ParsedDocument{
+				Sections: []*domain.ParsedSection{{Text: "line 1\nline 2"}},
+			},
+		},
+		{
+			name:     "plain_blank",
+			content:  " \n ",
+			mimeType: "text/plain",
+			want:     &domain.ParsedDocument{},
+		},
+		{
+			name:     "csv",
+			content:  "name,,price\nWidget,blue, 9.99\n,,\nGadget,,\n",
+			mimeType: "text/csv",
+			want: &domain.ParsedDocument{
+				Sections: []*domain.ParsedSection{
+					{Text: "name: Widget\ncolumn 2: blue\nprice: 9.99", Metadata: map[string]any{domain.MetadataRow: 1}},
+					{Text: "name: Gadget", Metadata: map[string]any{domain.MetadataRow: 3}},
+				},
+			},
+		},
+		{
+			name:     "jsonl",
+			content:  "{\"text\": \"first\", \"lang\": \"en\"}\n\n{\"question\": \"why?\", \"votes\": 3, \"tags\": [\"a\"]}\n",
+			mimeType: "application/x-ndjson",
+			want: &domain.ParsedDocument{
+				Sections: []*domain.ParsedSection{
+					{Text: "first", Metadata: map[string]any{"lang": "en", domain.MetadataRow: 1}},
+					{Text: "question: why?\ntags: [\"a\"]\nvotes: 3", Metadata: map[string]any{domain.MetadataRow: 2}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parser.NewParser().Parse([]byte(tc.content), tc.mimeType)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_Parser_Parse_Malformed(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		content  string
+		mimeType string
+		want     string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "unsupported_mime_type",
+			content:  "%PDF-1.7",
+			mimeType: "application/pdf",
+			want:     `unsupported mime type "application/pdf", want one of [application/jsonl application/x-jsonlines application/x-ndjson application/xhtml+xml text/csv text/html text/markdown text/plain text/x-markdown]`,
+		},
+		{
+			name:     "unsupported_charset",
+			content:  "text",
+			mimeType: "text/plain; charset=iso-8859-1",
+			want:     `unsupported charset "iso-8859-1", want utf-8`,
+		},
+		{
+			name:     "not_utf8",
+			content:  "caf\xe9",
+			mimeType: "text/plain",
+			want:     "the content is not utf-8 encoded",
+		},
+		{
+			name:     "csv_ragged",
+			content:  "a,b\n1,2,3\n",
+			mimeType: "text/csv",
+			want:     "malformed csv: record on line 2: wrong number of fields",
+		},
+		{
+			name:     "jsonl_not_object",
+			content:  "{\"text\": \"ok\"}\n[1, 2]\n",
+			mimeType: "application/jsonl",
+			want:     "malformed jsonl: line 2: want a json object",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parser.NewParser().Parse([]byte(tc.content), tc.mimeType)
+			if err == nil {
+				t.Fatal("want an error, got nil")
+			}
+
+			if diff := cmp.Diff(tc.want, err.Error()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
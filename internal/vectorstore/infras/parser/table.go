@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+
+	goccy_json "github.com/goccy/go-json"
+	"github.com/samber/lo"
+)
+
+// textField is the jsonl field holding the text of a row.
+const textField = "text"
+
+// parseCSV returns a section per row of a csv table with a header, its text
+// the lines of the non empty cells prefixed with their column.
+func parseCSV(content string) (*domain.ParsedDocument, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return &domain.ParsedDocument{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("malformed csv: %w", err)
+	}
+
+	document := &domain.ParsedDocument{}
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("malformed csv: %w", err)
+		}
+
+		var lines []string
+		for i, cell := range record {
+			if cell = strings.TrimSpace(cell); cell == "" {
+				continue
+			}
+			column := strings.TrimSpace(header[i])
+			if column == "" {
+				column = fmt.Sprintf("column %d", i+1)
+			}
+			lines = append(lines, column+": "+cell)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		document.Sections = append(document.Sections, &domain.ParsedSection{
+			Text:     strings.Join(lines, "\n"),
+			Metadata: map[string]any{domain.MetadataRow: row},
+		})
+	}
+
+	return document, nil
+}
+
+// parseJSONL returns a section per json object line. The text of a row is
+// its text field, the other fields becoming its metadata, or else the lines
+// of its fields prefixed with their key.
+func parseJSONL(content string) (*domain.ParsedDocument, error) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	// a line may be as long as the whole content
+	scanner.Buffer(nil, len(content)+1)
+
+	document := &domain.ParsedDocument{}
+	for line, row := 1, 0; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		row++
+
+		var object map[string]any
+		if err := goccy_json.Unmarshal(scanner.Bytes(), &object); err != nil || object == nil {
+			return nil, fmt.Errorf("malformed jsonl: line %d: want a json object", line)
+		}
+
+		section := &domain.ParsedSection{Metadata: map[string]any{domain.MetadataRow: row}}
+		if text, ok := object[textField].(string); ok {
+			delete(object, textField)
+			section.Text = text
+			section.Metadata = lo.Assign(object, section.Metadata)
+		} else {
+			var lines []string
+			for _, key := range slices.Sorted(maps.Keys(object)) {
+				value, ok := object[key].(string)
+				if !ok {
+					encoded, err := goccy_json.Marshal(object[key])
+					if err != nil {
+						return nil, fmt.Errorf("malformed jsonl: line %d: %w", line, err)
+					}
+					value = string(encoded)
+				}
+				lines = append(lines, key+": "+value)
+			}
+			section.Text = strings.Join(lines, "\n")
+		}
+
+		if strings.TrimSpace(section.Text) == "" {
+			continue
+		}
+		document.Sections = append(document.Sections, section)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("malformed jsonl: %w", err)
+	}
+
+	return document, nil
+}
@@ -0,0 +1,101 @@
+package qdrant
+
+import (
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+
+	"github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewFilter converts a search filter to a qdrant filter. The nil filter is nil.
+func NewFilter(filter *domain.Filter) *qdrant.Filter {
+	if filter == nil {
+		return nil
+	}
+
+	return &qdrant.Filter{
+		Must:    newConditions(filter.Must),
+		Should:  newConditions(filter.Should),
+		MustNot: newConditions(filter.MustNot),
+	}
+}
+
+func newConditions(conditions []*domain.FilterCondition) []*qdrant.Condition {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	result := make([]*qdrant.Condition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = newCondition(condition)
+	}
+	return result
+}
+
+func newCondition(condition *domain.FilterCondition) *qdrant.Condition {
+	switch {
+	case condition.Filter != nil:
+		return qdrant.NewFilterAsCondition(NewFilter(condition.Filter))
+	case condition.Match != nil:
+		return newMatch(condition.Key, condition.Match)
+	case condition.In != nil:
+		keywords := make([]string, 0, len(condition.In))
+		for _, value := range condition.In {
+			if keyword, ok := value.(string); ok {
+				keywords = append(keywords, keyword)
+			}
+		}
+		if len(keywords) == len(condition.In) {
+			return qdrant.NewMatchKeywords(condition.Key, keywords...)
+		}
+
+		// the bools and the numbers have no any-of match
+		matches := make([]*qdrant.Condition, len(condition.In))
+		for i, value := range condition.In {
+			matches[i] = newMatch(condition.Key, value)
+		}
+		return qdrant.NewFilterAsCondition(&qdrant.Filter{Should: matches})
+	case condition.Range != nil:
+		return qdrant.NewRange(condition.Key, &qdrant.Range{
+			Gt:  condition.Range.Gt,
+			Gte: condition.Range.Gte,
+			Lt:  condition.Range.Lt,
+			Lte: condition.Range.Lte,
+		})
+	case condition.DatetimeRange != nil:
+		return qdrant.NewDatetimeRange(condition.Key, &qdrant.DatetimeRange{
+			Gt:  newTimestamp(condition.DatetimeRange.Gt),
+			Gte: newTimestamp(condition.DatetimeRange.Gte),
+			Lt:  newTimestamp(condition.DatetimeRange.Lt),
+			Lte: newTimestamp(condition.DatetimeRange.Lte),
+		})
+	case condition.Exists != nil && *condition.Exists:
+		return qdrant.NewFilterAsCondition(&qdrant.Filter{MustNot: []*qdrant.Condition{qdrant.NewIsEmpty(condition.Key)}})
+	default:
+		// the missing, null and empty list values
+		return qdrant.NewIsEmpty(condition.Key)
+	}
+}
+
+// newMatch equals a string, a bool or a number. The numbers are matched with
+// a closed range so the integer and the float payloads match alike.
+func newMatch(key string, value any) *qdrant.Condition {
+	switch value := value.(type) {
+	case string:
+		return qdrant.NewMatchKeyword(key, value)
+	case bool:
+		return qdrant.NewMatchBool(key, value)
+	default:
+		number := value.(float64)
+		return qdrant.NewRange(key, &qdrant.Range{Gte: qdrant.PtrOf(number), Lte: qdrant.PtrOf(number)})
+	}
+}
+
+func newTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,124 @@
+package qdrant_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	qdrant_infras "github.com/aria3ppp/rag-server/internal/vectorstore/infras/qdrant"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewFilter(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name   string
+		filter map[string]any
+		want   *qdrant.Filter
+	}
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []testCase{
+		{
+			name:   "empty",
+			filter: nil,
+			want:   nil,
+		},
+		{
+			name: "match",
+			filter: map[string]any{"must": []any{
+				map[string]any{"key": "source", "match": "a.com"},
+				map[string]any{"key": "flagged", "match": false},
+				map[string]any{"key": "chunk_id", "match": 2.0},
+			}},
+			want: &qdrant.Filter{Must: []*qdrant.Condition{
+				qdrant.NewMatchKeyword("source", "a.com"),
+				qdrant.NewMatchBool("flagged", false),
+				qdrant.NewRange("chunk_id", &qdrant.Range{Gte: qdrant.PtrOf(2.0), Lte: qdrant.PtrOf(2.0)}),
+			}},
+		},
+		{
+			name: "in",
+			filter: map[string]any{"should": []any{
+				map[string]any{"key": "lang", "in": []any{"en", "fr"}},
+				map[string]any{"key": "year", "in": []any{2023.0, 2024.0}},
+			}},
+			want: &qdrant.Filter{Should: []*qdrant.Condition{
+				qdrant.NewMatchKeywords("lang", "en", "fr"),
+				qdrant.NewFilterAsCondition(&qdrant.Filter{Should: []*qdrant.Condition{
+					qdrant.NewRange("year", &qdrant.Range{Gte: qdrant.PtrOf(2023.0), Lte: qdrant.PtrOf(2023.0)}),
+					qdrant.NewRange("year", &qdrant.Range{Gte: qdrant.PtrOf(2024.0), Lte: qdrant.PtrOf(2024.0)}),
+				}}),
+			}},
+		},
+		{
+			name: "ranges",
+			filter: map[string]any{"must": []any{
+				map[string]any{"key": "chunk_id", "range": map[string]any{"gt": 1.0, "lte": 5.0}},
+				map[string]any{"key": "meta.published_at", "range": map[string]any{"gte": "2024-01-01T00:00:00Z"}},
+			}},
+			want: &qdrant.Filter{Must: []*qdrant.Condition{
+				qdrant.NewRange("chunk_id", &qdrant.Range{Gt: qdrant.PtrOf(1.0), Lte: qdrant.PtrOf(5.0)}),
+				qdrant.NewDatetimeRange("meta.published_at", &qdrant.DatetimeRange{Gte: timestamppb.New(date)}),
+			}},
+		},
+		{
+			name: "exists",
+			filter: map[string]any{"must": []any{
+				map[string]any{"key": "author", "exists": true},
+				map[string]any{"key": "deleted_at", "exists": false},
+			}},
+			want: &qdrant.Filter{Must: []*qdrant.Condition{
+				qdrant.NewFilterAsCondition(&qdrant.Filter{MustNot: []*qdrant.Condition{qdrant.NewIsEmpty("author")}}),
+				qdrant.NewIsEmpty("deleted_at"),
+			}},
+		},
+		{
+			name: "nested",
+			filter: map[string]any{
+				"must": []any{
+					map[string]any{"should": []any{
+						map[string]any{"key": "source", "match": "a.com"},
+						map[string]any{"key": "source", "match": "b.com"},
+					}},
+				},
+				"must_not": []any{
+					map[string]any{"key": "injection_flagged", "match": true},
+				},
+			},
+			want: &qdrant.Filter{
+				Must: []*qdrant.Condition{
+					qdrant.NewFilterAsCondition(&qdrant.Filter{Should: []*qdrant.Condition{
+						qdrant.NewMatchKeyword("source", "a.com"),
+						qdrant.NewMatchKeyword("source", "b.com"),
+					}}),
+				},
+				MustNot: []*qdrant.Condition{
+					qdrant.NewMatchBool("injection_flagged", true),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := domain.ParseFilter(tc.filter)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			got := qdrant_infras.NewFilter(filter)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
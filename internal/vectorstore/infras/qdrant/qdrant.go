@@ -2,35 +2,132 @@ package qdrant
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/usecase"
 
 	"github.com/qdrant/go-client/qdrant"
+	"github.com/samber/lo"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	grpc_codes "google.golang.org/grpc/codes"
 	grpc_status "google.golang.org/grpc/status"
 )
 
+// The named vectors of the collection points.
+const (
+	denseVectorName  = "dense"
+	sparseVectorName = "sparse"
+)
+
+// legacyCollectionSuffix names the copy of a legacy collection migrated to the named vectors.
+const legacyCollectionSuffix = "_hybrid"
+
+// migrationBatchSize is the number of points copied at a time from a legacy collection.
+const migrationBatchSize = 256
+
 type qdrantRepo struct {
 	client *qdrant.Client
 	config *config.QdrantConfig
+	// collectionName is the served collection, the migrated copy of a legacy collection or the configured collection.
+	collectionName string
+	// legacy is set when the served collection has a single unnamed dense
+	// vector and no sparse vector, so it only serves the dense searches.
+	legacy bool
 	tracer trace.Tracer
 	logger *slog.Logger
 }
 
 var _ usecase.VectorRepo = (*qdrantRepo)(nil)
 
+// NewVectorRepo creates the collection with a dense and a sparse named vector
+// when it does not exist. A legacy collection is served from its copy once
+// MigrateLegacyCollection completed it, and only serves the dense searches
+// until then.
 func NewVectorRepo(
 	ctx context.Context,
 	config *config.Config,
 	tracer trace.Tracer,
 	logger *slog.Logger,
 ) (*qdrantRepo, error) {
+	repo, err := newRepo(ctx, config, tracer, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	legacy, err := repo.isLegacy(ctx)
+	if err != nil {
+		if grpc_status.Code(err) != grpc_codes.NotFound {
+			return nil, err
+		}
+
+		if err := repo.createCollection(ctx, repo.collectionName); err != nil {
+			return nil, err
+		}
+
+		return repo, nil
+	}
+
+	if !legacy {
+		return repo, nil
+	}
+
+	migrated, err := repo.migrated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if migrated {
+		repo.collectionName += legacyCollectionSuffix
+		return repo, nil
+	}
+
+	logger.WarnContext(
+		ctx,
+		"legacy collection without sparse vectors only serves dense searches until it is migrated",
+		slog.String("collection", repo.collectionName),
+	)
+	repo.legacy = true
+
+	return repo, nil
+}
+
+// MigrateLegacyCollection copies the points of the legacy collection of config
+// into its named vectors copy, encoding their sparse vectors from their text.
+// The vector repos created after it completes serve the copy. An interrupted
+// migration is resumed by running it again, which upserts the already copied
+// points.
+func MigrateLegacyCollection(
+	ctx context.Context,
+	config *config.Config,
+	sparseEncoder usecase.SparseEncoder,
+	tracer trace.Tracer,
+	logger *slog.Logger,
+) error {
+	repo, err := newRepo(ctx, config, tracer, logger)
+	if err != nil {
+		return err
+	}
+	defer repo.client.Close()
+
+	legacy, err := repo.isLegacy(ctx)
+	if err != nil {
+		return err
+	}
+	if !legacy {
+		logger.InfoContext(ctx, "collection is not legacy", slog.String("collection", repo.collectionName))
+		return nil
+	}
+
+	return repo.migrateLegacyCollection(ctx, sparseEncoder)
+}
+
+func newRepo(ctx context.Context, config *config.Config, tracer trace.Tracer, logger *slog.Logger) (*qdrantRepo, error) {
 	client, err := qdrant.NewClient(&qdrant.Config{
 		Host: config.QdrantConfig.Host,
 		Port: int(config.QdrantConfig.GRPCPort),
@@ -44,30 +141,185 @@ func NewVectorRepo(
 		return nil, err
 	}
 
-	if _, err := client.GetCollectionInfo(ctx, config.QdrantConfig.CollectionName); err != nil {
-		if grpc_status.Code(err) != grpc_codes.NotFound {
-			return nil, fmt.Errorf("failed to get collection info: %w", err)
+	return &qdrantRepo{
+		client:         client,
+		config:         &config.QdrantConfig,
+		collectionName: config.QdrantConfig.CollectionName,
+		tracer:         tracer,
+		logger:         logger,
+	}, nil
+}
+
+// isLegacy reports whether the collection has a single unnamed dense vector.
+// The error of a missing collection has the NotFound code.
+func (repo *qdrantRepo) isLegacy(ctx context.Context) (bool, error) {
+	info, err := repo.client.GetCollectionInfo(ctx, repo.collectionName)
+	if err != nil {
+		if grpc_status.Code(err) == grpc_codes.NotFound {
+			return false, err
 		}
+		return false, fmt.Errorf("failed to get collection info: %w", err)
+	}
+
+	return info.GetConfig().GetParams().GetVectorsConfig().GetParamsMap() == nil, nil
+}
+
+// migrated reports whether the copy of the legacy collection has all its points.
+func (repo *qdrantRepo) migrated(ctx context.Context) (bool, error) {
+	exists, err := repo.client.CollectionExists(ctx, repo.collectionName+legacyCollectionSuffix)
+	if err != nil {
+		return false, fmt.Errorf("failed to check collection exists: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
 
-		createCollection := &qdrant.CreateCollection{
-			CollectionName: config.QdrantConfig.CollectionName,
-			VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
-				Size:     uint64(config.QdrantConfig.VectorSize),
+	legacyCount, targetCount, err := repo.migrationCounts(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return targetCount >= legacyCount, nil
+}
+
+// migrationCounts returns the points of the legacy collection and of its copy.
+func (repo *qdrantRepo) migrationCounts(ctx context.Context) (legacyCount, targetCount uint64, err error) {
+	legacyCount, err = repo.client.Count(ctx, &qdrant.CountPoints{CollectionName: repo.collectionName, Exact: qdrant.PtrOf(true)})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count legacy collection points: %w", err)
+	}
+
+	targetCount, err = repo.client.Count(ctx, &qdrant.CountPoints{CollectionName: repo.collectionName + legacyCollectionSuffix, Exact: qdrant.PtrOf(true)})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count target collection points: %w", err)
+	}
+
+	return legacyCount, targetCount, nil
+}
+
+func (repo *qdrantRepo) createCollection(ctx context.Context, collectionName string) error {
+	createCollection := &qdrant.CreateCollection{
+		CollectionName: collectionName,
+		VectorsConfig: qdrant.NewVectorsConfigMap(map[string]*qdrant.VectorParams{
+			denseVectorName: {
+				Size:     uint64(repo.config.VectorSize),
 				Distance: qdrant.Distance_Cosine,
-			}),
+			},
+		}),
+		// the term weights are multiplied by the inverse document frequencies on query
+		SparseVectorsConfig: qdrant.NewSparseVectorsConfig(map[string]*qdrant.SparseVectorParams{
+			sparseVectorName: {Modifier: qdrant.Modifier_Idf.Enum()},
+		}),
+	}
+
+	if err := repo.client.CreateCollection(ctx, createCollection); err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	return nil
+}
+
+// migrateLegacyCollection copies the points of the legacy collection into its
+// named vectors copy, encoding their sparse vectors from their text.
+func (repo *qdrantRepo) migrateLegacyCollection(ctx context.Context, sparseEncoder usecase.SparseEncoder) error {
+	legacyCollectionName := repo.collectionName
+	targetCollectionName := legacyCollectionName + legacyCollectionSuffix
+
+	exists, err := repo.client.CollectionExists(ctx, targetCollectionName)
+	if err != nil {
+		return fmt.Errorf("failed to check collection exists: %w", err)
+	}
+	if !exists {
+		if err := repo.createCollection(ctx, targetCollectionName); err != nil {
+			return err
+		}
+	}
+
+	legacyCount, targetCount, err := repo.migrationCounts(ctx)
+	if err != nil {
+		return err
+	}
+	if targetCount >= legacyCount {
+		repo.logger.InfoContext(ctx, "legacy collection is already migrated", slog.String("collection", legacyCollectionName))
+		return nil
+	}
+
+	repo.logger.InfoContext(
+		ctx,
+		"migrating legacy collection",
+		slog.String("from", legacyCollectionName),
+		slog.String("to", targetCollectionName),
+		slog.Uint64("points", legacyCount),
+	)
+
+	var offset *qdrant.PointId
+	for {
+		response, err := repo.client.GetPointsClient().Scroll(ctx, &qdrant.ScrollPoints{
+			CollectionName: legacyCollectionName,
+			Offset:         offset,
+			Limit:          qdrant.PtrOf(uint32(migrationBatchSize)),
+			WithPayload:    qdrant.NewWithPayload(true),
+			WithVectors:    qdrant.NewWithVectors(true),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scroll legacy collection: %w", err)
+		}
+
+		if len(response.GetResult()) > 0 {
+			points := make([]*qdrant.PointStruct, 0, len(response.GetResult()))
+			for _, point := range response.GetResult() {
+				var sparse *domain.SparseVector
+				if text, ok := point.GetPayload()["text"]; ok {
+					sparse = sparseEncoder.EncodeDocument(text.GetStringValue())
+				}
+
+				points = append(points, &qdrant.PointStruct{
+					Id:      point.GetId(),
+					Vectors: namedVectors(point.GetVectors().GetVector().GetData(), sparse),
+					Payload: point.GetPayload(),
+				})
+			}
+
+			_, err = repo.client.Upsert(ctx, &qdrant.UpsertPoints{
+				CollectionName: targetCollectionName,
+				Wait:           qdrant.PtrOf(true),
+				Points:         points,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to upsert migrated points: %w", err)
+			}
 		}
 
-		if err := client.CreateCollection(ctx, createCollection); err != nil {
-			return nil, fmt.Errorf("failed to create collection: %w", err)
+		offset = response.GetNextPageOffset()
+		if offset == nil {
+			repo.logger.InfoContext(ctx, "migrated legacy collection", slog.String("to", targetCollectionName))
+			return nil
 		}
 	}
+}
 
-	return &qdrantRepo{
-		client: client,
-		config: &config.QdrantConfig,
-		tracer: tracer,
-		logger: logger,
-	}, nil
+// namedVectors returns the named vectors of a point, without the sparse vector when it has no terms.
+func namedVectors(dense []float32, sparse *domain.SparseVector) *qdrant.Vectors {
+	vectors := map[string]*qdrant.Vector{
+		denseVectorName: qdrant.NewVectorDense(dense),
+	}
+	if sparse != nil && len(sparse.Indices) > 0 {
+		vectors[sparseVectorName] = qdrant.NewVectorSparse(sparse.Indices, sparse.Values)
+	}
+	return qdrant.NewVectorsMap(vectors)
+}
+
+// denseVector returns the dense vector of a point, named or not.
+func denseVector(vectors *qdrant.Vectors) []float32 {
+	if named := vectors.GetVectors(); named != nil {
+		return named.GetVectors()[denseVectorName].GetData()
+	}
+	return vectors.GetVector().GetData()
+}
+
+var fusions = map[string]qdrant.Fusion{
+	domain.FusionRRF:  qdrant.Fusion_RRF,
+	domain.FusionDBSF: qdrant.Fusion_DBSF,
 }
 
 func (repo *qdrantRepo) Insert(ctx context.Context, embeddings []*domain.VectorRepoInsertEmbedding) (err error) {
@@ -88,15 +340,20 @@ func (repo *qdrantRepo) Insert(ctx context.Context, embeddings []*domain.VectorR
 			return err
 		}
 
+		vectors := qdrant.NewVectors(embedding.Vector...)
+		if !repo.legacy {
+			vectors = namedVectors(embedding.Vector, embedding.Sparse)
+		}
+
 		points = append(points, &qdrant.PointStruct{
 			Id:      qdrant.NewID(embedding.ID),
-			Vectors: qdrant.NewVectors(embedding.Vector...),
+			Vectors: vectors,
 			Payload: payload,
 		})
 	}
 
 	_, err = repo.client.Upsert(ctx, &qdrant.UpsertPoints{
-		CollectionName: repo.config.CollectionName,
+		CollectionName: repo.collectionName,
 		Points:         points,
 	})
 	if err != nil {
@@ -118,13 +375,54 @@ func (repo *qdrantRepo) Query(ctx context.Context, query *domain.VectorRepoQuery
 	}()
 
 	searchParams := &qdrant.QueryPoints{
-		CollectionName: repo.config.CollectionName,
-		Query:          qdrant.NewQueryDense(query.Vector),
+		CollectionName: repo.collectionName,
 		Limit:          qdrant.PtrOf(uint64(query.TopK)),
 		WithPayload:    qdrant.NewWithPayload(true),
-		WithVectors:    qdrant.NewWithVectors(true),
-		Filter:         nil, // TODO: convert query.Filter to *qdrant.Filter
-		ScoreThreshold: &query.MinScore,
+		WithVectors:    qdrant.NewWithVectorsInclude(denseVectorName),
+		Filter:         NewFilter(query.Filter),
+	}
+
+	switch {
+	case query.Sparse != nil && repo.legacy:
+		err := internal_error.NewValidationError(errors.New("the legacy collection has no sparse vectors"))
+		repo.logger.ErrorContext(ctx, "failed to query legacy collection", slog.String("error", err.Error()))
+		return nil, err
+	case query.Sparse == nil && repo.legacy:
+		searchParams.Query = qdrant.NewQueryDense(query.Vector)
+		searchParams.WithVectors = qdrant.NewWithVectors(true)
+		searchParams.ScoreThreshold = &query.MinScore
+	case query.Sparse == nil:
+		searchParams.Query = qdrant.NewQueryDense(query.Vector)
+		searchParams.Using = qdrant.PtrOf(denseVectorName)
+		searchParams.ScoreThreshold = &query.MinScore
+	case query.Vector == nil:
+		searchParams.Query = qdrant.NewQuerySparse(query.Sparse.Indices, query.Sparse.Values)
+		searchParams.Using = qdrant.PtrOf(sparseVectorName)
+	default:
+		fusion, ok := fusions[query.Fusion]
+		if !ok {
+			err := fmt.Errorf("unknown fusion %q", query.Fusion)
+			repo.logger.ErrorContext(ctx, "failed to query", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		// each search prefetches top k candidates and the fusion ranks their union
+		searchParams.Query = qdrant.NewQueryFusion(fusion)
+		searchParams.Prefetch = []*qdrant.PrefetchQuery{
+			{
+				Query:          qdrant.NewQueryDense(query.Vector),
+				Using:          qdrant.PtrOf(denseVectorName),
+				Filter:         searchParams.Filter,
+				ScoreThreshold: &query.MinScore,
+				Limit:          qdrant.PtrOf(uint64(query.TopK)),
+			},
+			{
+				Query:  qdrant.NewQuerySparse(query.Sparse.Indices, query.Sparse.Values),
+				Using:  qdrant.PtrOf(sparseVectorName),
+				Filter: searchParams.Filter,
+				Limit:  qdrant.PtrOf(uint64(query.TopK)),
+			},
+		}
 	}
 
 	response, err := repo.client.Query(ctx, searchParams)
@@ -144,7 +442,7 @@ func (repo *qdrantRepo) Query(ctx context.Context, query *domain.VectorRepoQuery
 		results = append(results, &domain.VectorRepoQueryResult{
 			ID:       point.Id.GetUuid(),
 			Score:    point.GetScore(),
-			Vector:   point.GetVectors().GetVector().GetData(),
+			Vector:   denseVector(point.GetVectors()),
 			Metadata: metadata,
 		})
 	}
@@ -152,6 +450,223 @@ func (repo *qdrantRepo) Query(ctx context.Context, query *domain.VectorRepoQuery
 	return results, nil
 }
 
+func (repo *qdrantRepo) Lookup(ctx context.Context, input *domain.VectorRepoLookupInput) (_ []*domain.VectorRepoLookupResult, err error) {
+	ctx, span := repo.tracer.Start(ctx, "qdrantRepo.Lookup")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	response, err := repo.client.Scroll(ctx, &qdrant.ScrollPoints{
+		CollectionName: repo.collectionName,
+		Filter:         NewFilter(input.Filter),
+		Limit:          qdrant.PtrOf(uint32(input.Limit)),
+		WithPayload:    qdrant.NewWithPayload(true),
+	})
+	if err != nil {
+		repo.logger.ErrorContext(ctx, "failed to qdrant client scroll", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to qdrant client scroll: %v", err)
+	}
+
+	results := make([]*domain.VectorRepoLookupResult, 0, len(response))
+	for _, point := range response {
+		metadata, err := convertFromQdrantMap(point.Payload)
+		if err != nil {
+			repo.logger.ErrorContext(ctx, "failed to convert from qdrant map", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		results = append(results, &domain.VectorRepoLookupResult{
+			ID:       point.Id.GetUuid(),
+			Metadata: metadata,
+		})
+	}
+
+	return results, nil
+}
+
+func (repo *qdrantRepo) Get(ctx context.Context, input *domain.VectorRepoGetInput) (_ []*domain.VectorRepoPoint, err error) {
+	ctx, span := repo.tracer.Start(ctx, "qdrantRepo.Get")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	ids := make([]*qdrant.PointId, len(input.IDs))
+	for i, id := range input.IDs {
+		ids[i] = qdrant.NewID(id)
+	}
+
+	response, err := repo.client.Get(ctx, &qdrant.GetPoints{
+		CollectionName: repo.collectionName,
+		Ids:            ids,
+		WithPayload:    qdrant.NewWithPayload(true),
+		WithVectors:    repo.withVectors(input.WithVectors),
+	})
+	if err != nil {
+		repo.logger.ErrorContext(ctx, "failed to qdrant client get", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to qdrant client get: %v", err)
+	}
+
+	points, err := repo.points(ctx, response)
+	if err != nil {
+		return nil, err
+	}
+
+	// qdrant returns the points in no particular order
+	pointsByID := lo.KeyBy(points, func(point *domain.VectorRepoPoint) string { return point.ID })
+	ordered := make([]*domain.VectorRepoPoint, 0, len(points))
+	for _, id := range input.IDs {
+		if point, ok := pointsByID[strings.ToLower(id)]; ok {
+			ordered = append(ordered, point)
+			delete(pointsByID, strings.ToLower(id))
+		}
+	}
+
+	return ordered, nil
+}
+
+func (repo *qdrantRepo) Scroll(ctx context.Context, input *domain.VectorRepoScrollInput) (_ *domain.VectorRepoScrollResult, err error) {
+	ctx, span := repo.tracer.Start(ctx, "qdrantRepo.Scroll")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	var offset *qdrant.PointId
+	if input.Offset != "" {
+		offset = qdrant.NewID(input.Offset)
+	}
+
+	response, err := repo.client.GetPointsClient().Scroll(ctx, &qdrant.ScrollPoints{
+		CollectionName: repo.collectionName,
+		Filter:         NewFilter(input.Filter),
+		Offset:         offset,
+		Limit:          qdrant.PtrOf(uint32(input.Limit)),
+		WithPayload:    qdrant.NewWithPayload(true),
+		WithVectors:    repo.withVectors(input.WithVectors),
+	})
+	if err != nil {
+		repo.logger.ErrorContext(ctx, "failed to qdrant client scroll", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to qdrant client scroll: %v", err)
+	}
+
+	points, err := repo.points(ctx, response.GetResult())
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.VectorRepoScrollResult{
+		Points:     points,
+		NextOffset: response.GetNextPageOffset().GetUuid(),
+	}, nil
+}
+
+// withVectors selects the dense vectors of the points when include is set.
+func (repo *qdrantRepo) withVectors(include bool) *qdrant.WithVectorsSelector {
+	switch {
+	case !include:
+		return qdrant.NewWithVectors(false)
+	case repo.legacy:
+		return qdrant.NewWithVectors(true)
+	default:
+		return qdrant.NewWithVectorsInclude(denseVectorName)
+	}
+}
+
+func (repo *qdrantRepo) points(ctx context.Context, retrievedPoints []*qdrant.RetrievedPoint) ([]*domain.VectorRepoPoint, error) {
+	points := make([]*domain.VectorRepoPoint, 0, len(retrievedPoints))
+	for _, point := range retrievedPoints {
+		metadata, err := convertFromQdrantMap(point.Payload)
+		if err != nil {
+			repo.logger.ErrorContext(ctx, "failed to convert from qdrant map", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		points = append(points, &domain.VectorRepoPoint{
+			ID:       point.Id.GetUuid(),
+			Vector:   denseVector(point.GetVectors()),
+			Metadata: metadata,
+		})
+	}
+	return points, nil
+}
+
+func (repo *qdrantRepo) Delete(ctx context.Context, input *domain.VectorRepoDeleteInput) (_ int, err error) {
+	ctx, span := repo.tracer.Start(ctx, "qdrantRepo.Delete")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	ids := make([]*qdrant.PointId, len(input.IDs))
+	for i, id := range input.IDs {
+		ids[i] = qdrant.NewID(id)
+	}
+
+	return repo.delete(ctx, &qdrant.Filter{Must: []*qdrant.Condition{qdrant.NewHasID(ids...)}}, input.DryRun)
+}
+
+func (repo *qdrantRepo) DeleteByFilter(ctx context.Context, input *domain.VectorRepoDeleteByFilterInput) (_ int, err error) {
+	ctx, span := repo.tracer.Start(ctx, "qdrantRepo.DeleteByFilter")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if input.Filter == nil {
+		err := errors.New("refused to delete the whole collection with a nil filter")
+		repo.logger.ErrorContext(ctx, "failed to delete by filter", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return repo.delete(ctx, NewFilter(input.Filter), input.DryRun)
+}
+
+// delete counts the points matching filter and deletes them unless dryRun.
+func (repo *qdrantRepo) delete(ctx context.Context, filter *qdrant.Filter, dryRun bool) (int, error) {
+	count, err := repo.client.Count(ctx, &qdrant.CountPoints{
+		CollectionName: repo.collectionName,
+		Filter:         filter,
+		Exact:          qdrant.PtrOf(true),
+	})
+	if err != nil {
+		repo.logger.ErrorContext(ctx, "failed to qdrant client count", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to qdrant client count: %v", err)
+	}
+
+	if dryRun || count == 0 {
+		return int(count), nil
+	}
+
+	_, err = repo.client.Delete(ctx, &qdrant.DeletePoints{
+		CollectionName: repo.collectionName,
+		Wait:           qdrant.PtrOf(true),
+		Points:         qdrant.NewPointsSelectorFilter(filter),
+	})
+	if err != nil {
+		repo.logger.ErrorContext(ctx, "failed to qdrant client delete", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to qdrant client delete: %v", err)
+	}
+
+	return int(count), nil
+}
+
 func convertFromQdrantMap(input map[string]*qdrant.Value) (map[string]any, error) {
 	result := make(map[string]any, len(input))
 	for key, value := range input {
@@ -5,11 +5,14 @@ import (
 	"io"
 	"log/slog"
 	"math"
+	"slices"
 	"testing"
 
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
 	test_server "github.com/aria3ppp/rag-server/internal/pkg/test/server"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/infras/bm25"
 	qdrant_infras "github.com/aria3ppp/rag-server/internal/vectorstore/infras/qdrant"
 
 	"github.com/google/go-cmp/cmp"
@@ -673,6 +676,275 @@ func Test_QdrantRepo_Query(t *testing.T) {
 	}
 }
 
+func Test_QdrantRepo_Lookup(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name  string
+		input *domain.VectorRepoLookupInput
+		want  []int
+	}
+
+	collectionName := "collection"
+	vectorSize := 3
+
+	// the chunk ids are stored as integers and as floats, as the json inserted texts are
+	ids := []string{uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()}
+	metadata := []map[string]any{
+		{"source": "a.com", "chunk_id": int64(0), "flagged": false},
+		{"source": "a.com", "chunk_id": 1.0, "flagged": true},
+		{"source": "a.com", "chunk_id": int64(2), "flagged": false},
+		{"source": "b.com", "chunk_id": 1.0, "flagged": false},
+	}
+
+	testCases := []testCase{
+		{
+			name:  "match",
+			input: &domain.VectorRepoLookupInput{Filter: &domain.Filter{Must: []*domain.FilterCondition{{Key: "source", Match: "a.com"}}}, Limit: 10},
+			want:  []int{0, 1, 2},
+		},
+		{
+			name: "match_number_and_bool",
+			input: &domain.VectorRepoLookupInput{
+				Filter: &domain.Filter{Must: []*domain.FilterCondition{{Key: "chunk_id", Match: 1.0}, {Key: "flagged", Match: false}}},
+				Limit:  10,
+			},
+			want: []int{3},
+		},
+		{
+			name: "ranges",
+			input: &domain.VectorRepoLookupInput{
+				Filter: &domain.Filter{Must: []*domain.FilterCondition{
+					{Key: "source", Match: "a.com"},
+					{Key: "chunk_id", Range: &domain.FilterRange{Gte: qdrant.PtrOf(1.0), Lte: qdrant.PtrOf(2.0)}},
+				}},
+				Limit: 10,
+			},
+			want: []int{1, 2},
+		},
+		{
+			name:  "limit",
+			input: &domain.VectorRepoLookupInput{Filter: &domain.Filter{Must: []*domain.FilterCondition{{Key: "source", Match: "a.com"}}}, Limit: 1},
+			want:  nil,
+		},
+	}
+
+	ctx := context.Background()
+
+	qdrantGRPCPort, cleanup := test_server.SetupQdrantServer(t)
+	t.Cleanup(cleanup)
+
+	client, err := qdrant.NewClient(&qdrant.Config{
+		Port: qdrantGRPCPort,
+	})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if err := client.CreateCollection(ctx, &qdrant.CreateCollection{
+		CollectionName: collectionName,
+		VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
+			Size:     uint64(vectorSize),
+			Distance: qdrant.Distance_Cosine,
+		}),
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	points := make([]*qdrant.PointStruct, len(ids))
+	for i, id := range ids {
+		points[i] = &qdrant.PointStruct{
+			Id:      qdrant.NewID(id),
+			Vectors: qdrant.NewVectors(1, 2, 3),
+			Payload: qdrant.NewValueMap(metadata[i]),
+		}
+	}
+	if _, err := client.Upsert(ctx, &qdrant.UpsertPoints{
+		Wait:           qdrant.PtrOf(true),
+		CollectionName: collectionName,
+		Points:         points,
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	repo, err := qdrant_infras.NewVectorRepo(
+		ctx,
+		&config.Config{
+			QdrantConfig: config.QdrantConfig{
+				Host:           "localhost",
+				GRPCPort:       uint16(qdrantGRPCPort),
+				CollectionName: collectionName,
+				VectorSize:     vectorSize,
+			},
+		},
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results, err := repo.Lookup(ctx, tt.input)
+			if err != nil {
+				t.Fatal(cmp.Diff(err, nil))
+			}
+
+			// the limit only bounds the number of results
+			if tt.want == nil {
+				if diff := cmp.Diff(tt.input.Limit, len(results)); diff != "" {
+					t.Fatal(diff)
+				}
+				return
+			}
+
+			want := make([]*domain.VectorRepoLookupResult, len(tt.want))
+			for i, index := range tt.want {
+				want[i] = &domain.VectorRepoLookupResult{ID: ids[index], Metadata: metadata[index]}
+			}
+
+			sortSlicesOpts := cmpopts.SortSlices(func(a, b *domain.VectorRepoLookupResult) bool { return a.ID < b.ID })
+
+			if diff := cmp.Diff(want, results, sortSlicesOpts); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_QdrantRepo_MigrateLegacyCollection(t *testing.T) {
+	t.Parallel()
+
+	collectionName := "collection"
+
+	ids := []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+	vectors := [][]float32{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	metadata := []map[string]any{
+		{"text": "the pod restarts with ERR_CONN_RESET"},
+		{"text": "the deployment scales the pods"},
+		{"text": "the weather is sunny"},
+	}
+
+	ctx := context.Background()
+
+	qdrantGRPCPort, cleanup := test_server.SetupQdrantServer(t)
+	t.Cleanup(cleanup)
+
+	client, err := qdrant.NewClient(&qdrant.Config{
+		Port: qdrantGRPCPort,
+	})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if err := client.CreateCollection(ctx, &qdrant.CreateCollection{
+		CollectionName: collectionName,
+		VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
+			Size:     3,
+			Distance: qdrant.Distance_Cosine,
+		}),
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	points := make([]*qdrant.PointStruct, len(ids))
+	for i, id := range ids {
+		points[i] = &qdrant.PointStruct{
+			Id:      qdrant.NewID(id),
+			Vectors: qdrant.NewVectors(vectors[i]...),
+			Payload: qdrant.NewValueMap(metadata[i]),
+		}
+	}
+	if _, err := client.Upsert(ctx, &qdrant.UpsertPoints{
+		Wait:           qdrant.PtrOf(true),
+		CollectionName: collectionName,
+		Points:         points,
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	cfg := &config.Config{
+		QdrantConfig: config.QdrantConfig{
+			Host:           "localhost",
+			GRPCPort:       uint16(qdrantGRPCPort),
+			CollectionName: collectionName,
+			VectorSize:     3,
+		},
+		SparseConfig: config.SparseConfig{K1: 1.2, B: 0.75, AverageLength: 8},
+	}
+	sparseEncoder := bm25.NewSparseEncoder(cfg)
+	tracer := otel_trace_noop.NewTracerProvider().Tracer("")
+	logger := slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	// the legacy collection only serves the dense searches until it is migrated
+	legacyRepo, err := qdrant_infras.NewVectorRepo(ctx, cfg, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	_, err = legacyRepo.Query(ctx, &domain.VectorRepoQueryInput{
+		Sparse: sparseEncoder.EncodeQuery("err_conn_reset"),
+		TopK:   10,
+	})
+	if _, ok := err.(*internal_error.ValidationError); !ok {
+		t.Fatalf("want a validation error, got %v", err)
+	}
+
+	if err := qdrant_infras.MigrateLegacyCollection(ctx, cfg, sparseEncoder, tracer, logger); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	repo, err := qdrant_infras.NewVectorRepo(ctx, cfg, tracer, logger)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	count, err := client.Count(ctx, &qdrant.CountPoints{CollectionName: collectionName + "_hybrid", Exact: qdrant.PtrOf(true)})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(uint64(len(ids)), count); diff != "" {
+		t.Fatal(diff)
+	}
+
+	resultIDs := func(results []*domain.VectorRepoQueryResult) []string {
+		ids := make([]string, len(results))
+		for i, result := range results {
+			ids[i] = result.ID
+		}
+		return ids
+	}
+
+	// only the first text has the identifier
+	sparseResults, err := repo.Query(ctx, &domain.VectorRepoQueryInput{
+		Sparse: sparseEncoder.EncodeQuery("err_conn_reset"),
+		TopK:   10,
+	})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff([]string{ids[0]}, resultIDs(sparseResults)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// the dense search finds the third text and the sparse search the second one
+	hybridResults, err := repo.Query(ctx, &domain.VectorRepoQueryInput{
+		Vector:   vectors[2],
+		Sparse:   sparseEncoder.EncodeQuery("deployment"),
+		Fusion:   domain.FusionRRF,
+		TopK:     10,
+		MinScore: 0.5,
+	})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff([]string{ids[1], ids[2]}, resultIDs(hybridResults), cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func cosineNormalize(vector []float32) []float32 {
 	normalized := make([]float32, len(vector))
 	var magnitude float64
@@ -687,3 +959,225 @@ func cosineNormalize(vector []float32) []float32 {
 	}
 	return normalized
 }
+
+func Test_QdrantRepo_Delete(t *testing.T) {
+	t.Parallel()
+
+	collectionName := "collection"
+	vectorSize := 3
+
+	ids := []string{uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()}
+	metadata := []map[string]any{
+		{"source": "a.com"},
+		{"source": "a.com"},
+		{"source": "b.com"},
+		{"source": "b.com"},
+	}
+
+	ctx := context.Background()
+
+	qdrantGRPCPort, cleanup := test_server.SetupQdrantServer(t)
+	t.Cleanup(cleanup)
+
+	repo, err := qdrant_infras.NewVectorRepo(
+		ctx,
+		&config.Config{
+			QdrantConfig: config.QdrantConfig{
+				Host:           "localhost",
+				GRPCPort:       uint16(qdrantGRPCPort),
+				CollectionName: collectionName,
+				VectorSize:     vectorSize,
+			},
+		},
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	client, err := qdrant.NewClient(&qdrant.Config{
+		Port: qdrantGRPCPort,
+	})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	points := make([]*qdrant.PointStruct, len(ids))
+	for i, id := range ids {
+		points[i] = &qdrant.PointStruct{
+			Id:      qdrant.NewID(id),
+			Vectors: qdrant.NewVectorsMap(map[string]*qdrant.Vector{"dense": qdrant.NewVectorDense([]float32{1, 2, 3})}),
+			Payload: qdrant.NewValueMap(metadata[i]),
+		}
+	}
+	if _, err := client.Upsert(ctx, &qdrant.UpsertPoints{
+		Wait:           qdrant.PtrOf(true),
+		CollectionName: collectionName,
+		Points:         points,
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	filter := &domain.Filter{Must: []*domain.FilterCondition{{Key: "source", Match: "b.com"}}}
+
+	// the dry runs count without deleting
+	deleted, err := repo.Delete(ctx, &domain.VectorRepoDeleteInput{IDs: []string{ids[0], uuid.NewString()}, DryRun: true})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(1, deleted); diff != "" {
+		t.Fatal(diff)
+	}
+
+	deleted, err = repo.DeleteByFilter(ctx, &domain.VectorRepoDeleteByFilterInput{Filter: filter, DryRun: true})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(2, deleted); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// the unknown ids are not counted
+	deleted, err = repo.Delete(ctx, &domain.VectorRepoDeleteInput{IDs: []string{ids[0], uuid.NewString()}})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(1, deleted); diff != "" {
+		t.Fatal(diff)
+	}
+
+	deleted, err = repo.DeleteByFilter(ctx, &domain.VectorRepoDeleteByFilterInput{Filter: filter})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+	if diff := cmp.Diff(2, deleted); diff != "" {
+		t.Fatal(diff)
+	}
+
+	results, err := repo.Lookup(ctx, &domain.VectorRepoLookupInput{Limit: 10})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	want := []*domain.VectorRepoLookupResult{{ID: ids[1], Metadata: metadata[1]}}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := repo.DeleteByFilter(ctx, &domain.VectorRepoDeleteByFilterInput{}); err == nil {
+		t.Fatal("want an error deleting with a nil filter")
+	}
+}
+
+func Test_QdrantRepo_GetAndScroll(t *testing.T) {
+	t.Parallel()
+
+	collectionName := "collection"
+	vectorSize := 3
+
+	ids := []string{uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()}
+	slices.Sort(ids)
+	metadata := []map[string]any{
+		{"source": "a.com"},
+		{"source": "b.com"},
+		{"source": "a.com"},
+		{"source": "a.com"},
+		{"source": "b.com"},
+	}
+	vector := []float32{1, 0, 0}
+
+	ctx := context.Background()
+
+	qdrantGRPCPort, cleanup := test_server.SetupQdrantServer(t)
+	t.Cleanup(cleanup)
+
+	repo, err := qdrant_infras.NewVectorRepo(
+		ctx,
+		&config.Config{
+			QdrantConfig: config.QdrantConfig{
+				Host:           "localhost",
+				GRPCPort:       uint16(qdrantGRPCPort),
+				CollectionName: collectionName,
+				VectorSize:     vectorSize,
+			},
+		},
+		otel_trace_noop.NewTracerProvider().Tracer(""),
+		slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError})),
+	)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	client, err := qdrant.NewClient(&qdrant.Config{
+		Port: qdrantGRPCPort,
+	})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	points := make([]*qdrant.PointStruct, len(ids))
+	for i, id := range ids {
+		points[i] = &qdrant.PointStruct{
+			Id:      qdrant.NewID(id),
+			Vectors: qdrant.NewVectorsMap(map[string]*qdrant.Vector{"dense": qdrant.NewVectorDense(vector)}),
+			Payload: qdrant.NewValueMap(metadata[i]),
+		}
+	}
+	if _, err := client.Upsert(ctx, &qdrant.UpsertPoints{
+		Wait:           qdrant.PtrOf(true),
+		CollectionName: collectionName,
+		Points:         points,
+	}); err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	// get returns the points in the order of the ids without the missing ones
+	got, err := repo.Get(ctx, &domain.VectorRepoGetInput{IDs: []string{ids[3], uuid.NewString(), ids[0]}, WithVectors: true})
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	want := []*domain.VectorRepoPoint{
+		{ID: ids[3], Vector: vector, Metadata: metadata[3]},
+		{ID: ids[0], Vector: vector, Metadata: metadata[0]},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// scroll pages through the matching points ordered by their ids
+	filter := &domain.Filter{Must: []*domain.FilterCondition{{Key: "source", Match: "a.com"}}}
+
+	var (
+		scrolled []*domain.VectorRepoPoint
+		offset   string
+		pages    int
+	)
+	for {
+		result, err := repo.Scroll(ctx, &domain.VectorRepoScrollInput{Filter: filter, Limit: 2, Offset: offset})
+		if err != nil {
+			t.Fatal(cmp.Diff(err, nil))
+		}
+		scrolled = append(scrolled, result.Points...)
+		pages++
+
+		offset = result.NextOffset
+		if offset == "" {
+			break
+		}
+	}
+
+	want = []*domain.VectorRepoPoint{
+		{ID: ids[0], Metadata: metadata[0]},
+		{ID: ids[2], Metadata: metadata[2]},
+		{ID: ids[3], Metadata: metadata[3]},
+	}
+	if diff := cmp.Diff(want, scrolled); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if diff := cmp.Diff(2, pages); diff != "" {
+		t.Fatal(diff)
+	}
+}
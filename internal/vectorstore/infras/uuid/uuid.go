@@ -6,6 +6,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// clientIDNamespace is the namespace of the name based uuids of the client
+// ids, fixed so a client id always maps to the same uuid.
+var clientIDNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/aria3ppp/rag-server/client-ids"))
+
+// contentIDNamespace is the namespace of the name based uuids of the texts.
+var contentIDNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/aria3ppp/rag-server/content-ids"))
+
 type uuidIDGenerator struct{}
 
 var _ usecase.IDGenerator = (*uuidIDGenerator)(nil)
@@ -22,3 +29,19 @@ func (*uuidIDGenerator) NewID() (string, error) {
 
 	return randomUUID.String(), nil
 }
+
+// IDFromClientID returns the canonical form of the uuid client ids and the
+// uuid v5 of the others, as qdrant only takes uuid or integer point ids.
+func (*uuidIDGenerator) IDFromClientID(clientID string) string {
+	if parsedUUID, err := uuid.Parse(clientID); err == nil {
+		return parsedUUID.String()
+	}
+
+	return uuid.NewSHA1(clientIDNamespace, []byte(clientID)).String()
+}
+
+// IDFromContent returns the uuid v5 of the namespace and the text, separated
+// by a null byte so the namespaces never run into the texts.
+func (*uuidIDGenerator) IDFromContent(namespace, text string) string {
+	return uuid.NewSHA1(contentIDNamespace, []byte(namespace+"\x00"+text)).String()
+}
@@ -47,3 +47,70 @@ func Test_UuidIDGenerator_NewID(t *testing.T) {
 		})
 	}
 }
+
+func Test_UuidIDGenerator_IDFromClientID(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		clientID string
+		want     string
+	}
+	testCases := []testCase{
+		{
+			name:     "uuid",
+			clientID: "5A0E9C8B-4D4F-4B8E-9A53-0B2D6C1E7F10",
+			want:     "5a0e9c8b-4d4f-4b8e-9a53-0b2d6c1e7f10",
+		},
+		{
+			name:     "name",
+			clientID: "docs/intro.md#3",
+			want:     "1dd018f9-e237-57c1-a93d-7055654882bd",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			idGenerator := vectorstore_uuid.NewIDGenerator()
+
+			id := idGenerator.IDFromClientID(tt.clientID)
+			if diff := cmp.Diff(tt.want, id); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_UuidIDGenerator_IDFromContent(t *testing.T) {
+	t.Parallel()
+
+	idGenerator := vectorstore_uuid.NewIDGenerator()
+
+	id := idGenerator.IDFromContent("https://example.com", "text")
+
+	if diff := cmp.Diff(id, idGenerator.IDFromContent("https://example.com", "text")); diff != "" {
+		t.Fatal(diff)
+	}
+
+	for _, other := range []string{
+		idGenerator.IDFromContent("https://example.org", "text"),
+		idGenerator.IDFromContent("", "text"),
+		idGenerator.IDFromContent("https://example.com", "text 2"),
+		idGenerator.IDFromClientID("text"),
+	} {
+		if id == other {
+			t.Fatalf("want distinct ids, got %s twice", id)
+		}
+	}
+
+	parsedUUID, err := uuid.Parse(id)
+	if err != nil {
+		t.Fatal(cmp.Diff(err, nil))
+	}
+
+	if diff := cmp.Diff(uuid.Version(5), parsedUUID.Version()); diff != "" {
+		t.Fatal(diff)
+	}
+}
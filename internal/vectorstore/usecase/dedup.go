@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+)
+
+// nearDuplicate returns the id and the similarity of the first of the
+// preceding texts or of the most similar text stored in vectorRepo whose
+// similarity to the vector of the text with id is at least threshold. The text with the same id
+// is not a duplicate but replaced. The id is empty when there is none.
+func (uc *usecase) nearDuplicate(ctx context.Context, vectorRepo VectorRepo, id string, vector []float32, preceding []*domain.VectorRepoInsertEmbedding, threshold float32) (string, float32, error) {
+	for _, embedding := range preceding {
+		if score := cosine(vector, embedding.Vector); score >= threshold && embedding.ID != id {
+			return embedding.ID, score, nil
+		}
+	}
+
+	// the second result is the most similar when the first is the text itself
+	queryResults, err := vectorRepo.Query(ctx, &domain.VectorRepoQueryInput{
+		Vector:   vector,
+		TopK:     2,
+		MinScore: threshold,
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to repo query near duplicates", slog.String("error", err.Error()))
+		return "", 0, err
+	}
+
+	for _, result := range queryResults {
+		if result.ID != id {
+			return result.ID, result.Score, nil
+		}
+	}
+
+	return "", 0, nil
+}
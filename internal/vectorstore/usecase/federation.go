@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+
+	"github.com/samber/lo"
+)
+
+// rrfK damps the reciprocal ranks so the top ranks do not dominate the fusion.
+const rrfK = 60
+
+// Collection is a searched collection with the embedder of its texts.
+type Collection struct {
+	Embedder   Embedder
+	VectorRepo VectorRepo
+	// ChunkingConfig is the default chunking of the documents inserted into
+	// the collection, the chunking config of the default collection when nil.
+	ChunkingConfig *config.ChunkingConfig
+}
+
+// defaultCollection returns the default collection.
+func (uc *usecase) defaultCollection() *Collection {
+	return &Collection{Embedder: uc.embedder, VectorRepo: uc.vectorRepo, ChunkingConfig: &uc.config.ChunkingConfig}
+}
+
+// collection returns the default collection or the named federated collection.
+func (uc *usecase) collection(name string) (*Collection, bool) {
+	if name == uc.config.QdrantConfig.CollectionName {
+		return uc.defaultCollection(), true
+	}
+	collection, ok := uc.collections[name]
+	return collection, ok
+}
+
+// federatedSearch searches the collections of the input federation
+// concurrently and returns the top k of their fused results, tagged with
+// their collection in the metadata.
+func (uc *usecase) federatedSearch(ctx context.Context, mode string, filter *domain.Filter, input *domain.SearchTextInput) ([]*domain.VectorRepoQueryResult, error) {
+	collections := make([]*Collection, len(input.Federation.Collections))
+	for i, federationCollection := range input.Federation.Collections {
+		collection, ok := uc.collection(federationCollection.Name)
+		if !ok {
+			err := internal_error.NewValidationError(fmt.Errorf("unknown collection %q", federationCollection.Name))
+			uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+			return nil, err
+		}
+		collections[i] = collection
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]*domain.VectorRepoQueryResult, len(collections))
+		errs    = make([]error, len(collections))
+	)
+	for i, collection := range collections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = uc.searchCollection(ctx, collection, mode, filter, input)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "failed to search collection", slog.String("collection", input.Federation.Collections[i].Name), slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	var fused []*domain.VectorRepoQueryResult
+	for i, collectionResults := range results {
+		federationCollection := input.Federation.Collections[i]
+
+		weight := federationCollection.Weight
+		if weight == 0 {
+			weight = 1
+		}
+
+		normalized := normalizeScores(collectionResults)
+		for rank, result := range collectionResults {
+			switch input.Federation.Fusion {
+			case domain.CollectionFusionRRF:
+				result.Score = weight / float32(rrfK+rank+1)
+			default:
+				result.Score = weight * normalized[rank]
+			}
+			result.Metadata = lo.Assign(result.Metadata, map[string]any{domain.MetadataCollection: federationCollection.Name})
+		}
+
+		fused = append(fused, collectionResults...)
+	}
+
+	slices.SortStableFunc(fused, func(a, b *domain.VectorRepoQueryResult) int { return cmp.Compare(b.Score, a.Score) })
+
+	return fused[:min(len(fused), input.TopK)], nil
+}
+
+// normalizeScores min-max scales the scores of results to [0, 1], as the
+// scores of different embedding models are not comparable. The results whose
+// scores do not spread, a single result or equal scores, keep their scores
+// clamped to [0, 1], so a weak result is not scaled up to the best score.
+func normalizeScores(results []*domain.VectorRepoQueryResult) []float32 {
+	normalized := make([]float32, len(results))
+	if len(results) == 0 {
+		return normalized
+	}
+
+	lowest, highest := results[0].Score, results[0].Score
+	for _, result := range results {
+		lowest = min(lowest, result.Score)
+		highest = max(highest, result.Score)
+	}
+
+	for i, result := range results {
+		if highest == lowest {
+			normalized[i] = min(max(result.Score, 0), 1)
+			continue
+		}
+		normalized[i] = (result.Score - lowest) / (highest - lowest)
+	}
+
+	return normalized
+}
@@ -1,6 +1,6 @@
 package usecase
 
-//go:generate mockgen -destination=mocks/mocks.go -package=mocks -typed . Embedder,IDGenerator,VectorRepo,UseCase
+//go:generate mockgen -destination=mocks/mocks.go -package=mocks -typed . Embedder,IDGenerator,VectorRepo,InjectionScanner,Redactor,SparseEncoder,Chunker,Parser,UseCase
 
 import (
 	"context"
@@ -10,20 +10,77 @@ import (
 
 type (
 	Embedder interface {
+		// Embed returns the embeddings of the texts. When only some texts
+		// failed, it returns a *domain.EmbedError with the embeddings of the
+		// others, nil for the failed ones.
 		Embed(ctx context.Context, texts []string) ([][]float32, error)
 	}
 
 	IDGenerator interface {
 		NewID() (string, error)
+		// IDFromClientID returns the id of the text with the client id: the
+		// id itself when it is a uuid and a name based uuid of it otherwise.
+		IDFromClientID(clientID string) string
+		// IDFromContent returns a name based uuid of the namespace and the text.
+		IDFromContent(namespace, text string) string
 	}
 
 	VectorRepo interface {
 		Insert(ctx context.Context, embeddings []*domain.VectorRepoInsertEmbedding) error
 		Query(ctx context.Context, query *domain.VectorRepoQueryInput) ([]*domain.VectorRepoQueryResult, error)
+		Lookup(ctx context.Context, input *domain.VectorRepoLookupInput) ([]*domain.VectorRepoLookupResult, error)
+		// Get returns the points of the ids in their order, without the missing ones.
+		Get(ctx context.Context, input *domain.VectorRepoGetInput) ([]*domain.VectorRepoPoint, error)
+		// Scroll returns a page of the points matching the filter ordered by their ids.
+		Scroll(ctx context.Context, input *domain.VectorRepoScrollInput) (*domain.VectorRepoScrollResult, error)
+		// Delete deletes the points of the ids and returns the number of the found ones.
+		Delete(ctx context.Context, input *domain.VectorRepoDeleteInput) (int, error)
+		// DeleteByFilter deletes the points matching the filter and returns their number.
+		DeleteByFilter(ctx context.Context, input *domain.VectorRepoDeleteByFilterInput) (int, error)
+	}
+
+	InjectionScanner interface {
+		Scan(text string) (score float32, rules []string)
+	}
+
+	Redactor interface {
+		// Redact returns text without the personal data, redacted with the policy of tenant.
+		Redact(ctx context.Context, tenant, text string) string
+	}
+
+	SparseEncoder interface {
+		// EncodeDocument weights the terms of an inserted text.
+		EncodeDocument(text string) *domain.SparseVector
+		// EncodeQuery weights the terms of a search query.
+		EncodeQuery(text string) *domain.SparseVector
+	}
+
+	Chunker interface {
+		// Chunk splits text into chunks of at most options.Size characters,
+		// dropping the blank ones.
+		Chunk(text string, options *domain.ChunkingOptions) []*domain.Chunk
+	}
+
+	Parser interface {
+		// Parse extracts the text of content of the mime type, failing on the
+		// unsupported mime types and the malformed contents.
+		Parse(content []byte, mimeType string) (*domain.ParsedDocument, error)
 	}
 
 	UseCase interface {
-		InsertTexts(ctx context.Context, input *domain.InsertTextsInput) error
+		InsertTexts(ctx context.Context, input *domain.InsertTextsInput) (*domain.InsertTextsResult, error)
+		// StreamInsertTexts inserts the texts received until receive returns
+		// io.EOF in batches, failing the invalid texts and the texts of the
+		// failed batches on their own. It returns the counts of the texts and
+		// only the failed ones. It fails when receive fails.
+		StreamInsertTexts(ctx context.Context, receive func() (*domain.InsertTextsInputText, error)) (*domain.StreamInsertTextsResult, error)
+		InsertDocuments(ctx context.Context, input *domain.InsertDocumentsInput) (*domain.InsertDocumentsResult, error)
+		UploadDocument(ctx context.Context, input *domain.UploadDocumentInput) (*domain.UploadDocumentResult, error)
 		SearchText(ctx context.Context, input *domain.SearchTextInput) (*domain.SearchTextResult, error)
+		LookupTexts(ctx context.Context, input *domain.LookupTextsInput) (*domain.LookupTextsResult, error)
+		GetTexts(ctx context.Context, input *domain.GetTextsInput) (*domain.GetTextsResult, error)
+		ListTexts(ctx context.Context, input *domain.ListTextsInput) (*domain.ListTextsResult, error)
+		DeleteTexts(ctx context.Context, input *domain.DeleteTextsInput) (*domain.DeleteTextsResult, error)
+		DeleteByFilter(ctx context.Context, input *domain.DeleteByFilterInput) (*domain.DeleteTextsResult, error)
 	}
 )
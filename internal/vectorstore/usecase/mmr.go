@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"math"
+
+	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
+)
+
+// maximalMarginalRelevance greedily selects topK of results, each maximizing
+// lambda times its similarity to the query minus 1-lambda times its highest
+// similarity to the already selected results. The similarity to the query is
+// the min-max normalized result score, as the fused and the sparse scores are
+// not on the scale of the cosine between the result vectors.
+func maximalMarginalRelevance(results []*domain.VectorRepoQueryResult, topK int, lambda float32) []*domain.VectorRepoQueryResult {
+	if len(results) <= 1 {
+		return results
+	}
+
+	selected := make([]*domain.VectorRepoQueryResult, 0, min(topK, len(results)))
+	// redundancy is the highest similarity of each candidate to the selected results, the opposite vectors counting as unrelated
+	redundancy := make([]float32, len(results))
+	taken := make([]bool, len(results))
+	relevance := normalizeScores(results)
+
+	for len(selected) < cap(selected) {
+		best := -1
+		var bestScore float32
+		for i := range results {
+			if taken[i] {
+				continue
+			}
+			score := lambda*relevance[i] - (1-lambda)*redundancy[i]
+			if best == -1 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		taken[best] = true
+		selected = append(selected, results[best])
+
+		for i, result := range results {
+			if !taken[i] {
+				redundancy[i] = max(redundancy[i], cosine(result.Vector, results[best].Vector))
+			}
+		}
+	}
+
+	return selected
+}
+
+func cosine(a, b []float32) float32 {
+	var dot, normA, normB float64
+	for i := range min(len(a), len(b)) {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / math.Sqrt(normA*normB))
+}
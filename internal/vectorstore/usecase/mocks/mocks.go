@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aria3ppp/rag-server/internal/vectorstore/usecase (interfaces: Embedder,IDGenerator,VectorRepo,UseCase)
+// Source: github.com/aria3ppp/rag-server/internal/vectorstore/usecase (interfaces: Embedder,IDGenerator,VectorRepo,InjectionScanner,Redactor,SparseEncoder,Chunker,Parser,UseCase)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mocks.go -package=mocks -typed . Embedder,IDGenerator,VectorRepo,UseCase
+//	mockgen -destination=mocks/mocks.go -package=mocks -typed . Embedder,IDGenerator,VectorRepo,InjectionScanner,Redactor,SparseEncoder,Chunker,Parser,UseCase
 //
 
 // Package mocks is a generated GoMock package.
@@ -104,6 +104,82 @@ func (m *MockIDGenerator) EXPECT() *MockIDGeneratorMockRecorder {
 	return m.recorder
 }
 
+// IDFromClientID mocks base method.
+func (m *MockIDGenerator) IDFromClientID(clientID string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IDFromClientID", clientID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// IDFromClientID indicates an expected call of IDFromClientID.
+func (mr *MockIDGeneratorMockRecorder) IDFromClientID(clientID any) *MockIDGeneratorIDFromClientIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IDFromClientID", reflect.TypeOf((*MockIDGenerator)(nil).IDFromClientID), clientID)
+	return &MockIDGeneratorIDFromClientIDCall{Call: call}
+}
+
+// MockIDGeneratorIDFromClientIDCall wrap *gomock.Call
+type MockIDGeneratorIDFromClientIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIDGeneratorIDFromClientIDCall) Return(arg0 string) *MockIDGeneratorIDFromClientIDCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIDGeneratorIDFromClientIDCall) Do(f func(string) string) *MockIDGeneratorIDFromClientIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIDGeneratorIDFromClientIDCall) DoAndReturn(f func(string) string) *MockIDGeneratorIDFromClientIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// IDFromContent mocks base method.
+func (m *MockIDGenerator) IDFromContent(namespace, text string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IDFromContent", namespace, text)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// IDFromContent indicates an expected call of IDFromContent.
+func (mr *MockIDGeneratorMockRecorder) IDFromContent(namespace, text any) *MockIDGeneratorIDFromContentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IDFromContent", reflect.TypeOf((*MockIDGenerator)(nil).IDFromContent), namespace, text)
+	return &MockIDGeneratorIDFromContentCall{Call: call}
+}
+
+// MockIDGeneratorIDFromContentCall wrap *gomock.Call
+type MockIDGeneratorIDFromContentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockIDGeneratorIDFromContentCall) Return(arg0 string) *MockIDGeneratorIDFromContentCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockIDGeneratorIDFromContentCall) Do(f func(string, string) string) *MockIDGeneratorIDFromContentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockIDGeneratorIDFromContentCall) DoAndReturn(f func(string, string) string) *MockIDGeneratorIDFromContentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // NewID mocks base method.
 func (m *MockIDGenerator) NewID() (string, error) {
 	m.ctrl.T.Helper()
@@ -167,6 +243,123 @@ func (m *MockVectorRepo) EXPECT() *MockVectorRepoMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockVectorRepo) Delete(ctx context.Context, input *domain.VectorRepoDeleteInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVectorRepoMockRecorder) Delete(ctx, input any) *MockVectorRepoDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVectorRepo)(nil).Delete), ctx, input)
+	return &MockVectorRepoDeleteCall{Call: call}
+}
+
+// MockVectorRepoDeleteCall wrap *gomock.Call
+type MockVectorRepoDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVectorRepoDeleteCall) Return(arg0 int, arg1 error) *MockVectorRepoDeleteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVectorRepoDeleteCall) Do(f func(context.Context, *domain.VectorRepoDeleteInput) (int, error)) *MockVectorRepoDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVectorRepoDeleteCall) DoAndReturn(f func(context.Context, *domain.VectorRepoDeleteInput) (int, error)) *MockVectorRepoDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteByFilter mocks base method.
+func (m *MockVectorRepo) DeleteByFilter(ctx context.Context, input *domain.VectorRepoDeleteByFilterInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByFilter", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByFilter indicates an expected call of DeleteByFilter.
+func (mr *MockVectorRepoMockRecorder) DeleteByFilter(ctx, input any) *MockVectorRepoDeleteByFilterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByFilter", reflect.TypeOf((*MockVectorRepo)(nil).DeleteByFilter), ctx, input)
+	return &MockVectorRepoDeleteByFilterCall{Call: call}
+}
+
+// MockVectorRepoDeleteByFilterCall wrap *gomock.Call
+type MockVectorRepoDeleteByFilterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVectorRepoDeleteByFilterCall) Return(arg0 int, arg1 error) *MockVectorRepoDeleteByFilterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVectorRepoDeleteByFilterCall) Do(f func(context.Context, *domain.VectorRepoDeleteByFilterInput) (int, error)) *MockVectorRepoDeleteByFilterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVectorRepoDeleteByFilterCall) DoAndReturn(f func(context.Context, *domain.VectorRepoDeleteByFilterInput) (int, error)) *MockVectorRepoDeleteByFilterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockVectorRepo) Get(ctx context.Context, input *domain.VectorRepoGetInput) ([]*domain.VectorRepoPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, input)
+	ret0, _ := ret[0].([]*domain.VectorRepoPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVectorRepoMockRecorder) Get(ctx, input any) *MockVectorRepoGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVectorRepo)(nil).Get), ctx, input)
+	return &MockVectorRepoGetCall{Call: call}
+}
+
+// MockVectorRepoGetCall wrap *gomock.Call
+type MockVectorRepoGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVectorRepoGetCall) Return(arg0 []*domain.VectorRepoPoint, arg1 error) *MockVectorRepoGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVectorRepoGetCall) Do(f func(context.Context, *domain.VectorRepoGetInput) ([]*domain.VectorRepoPoint, error)) *MockVectorRepoGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVectorRepoGetCall) DoAndReturn(f func(context.Context, *domain.VectorRepoGetInput) ([]*domain.VectorRepoPoint, error)) *MockVectorRepoGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Insert mocks base method.
 func (m *MockVectorRepo) Insert(ctx context.Context, embeddings []*domain.VectorRepoInsertEmbedding) error {
 	m.ctrl.T.Helper()
@@ -205,6 +398,45 @@ func (c *MockVectorRepoInsertCall) DoAndReturn(f func(context.Context, []*domain
 	return c
 }
 
+// Lookup mocks base method.
+func (m *MockVectorRepo) Lookup(ctx context.Context, input *domain.VectorRepoLookupInput) ([]*domain.VectorRepoLookupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, input)
+	ret0, _ := ret[0].([]*domain.VectorRepoLookupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockVectorRepoMockRecorder) Lookup(ctx, input any) *MockVectorRepoLookupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockVectorRepo)(nil).Lookup), ctx, input)
+	return &MockVectorRepoLookupCall{Call: call}
+}
+
+// MockVectorRepoLookupCall wrap *gomock.Call
+type MockVectorRepoLookupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVectorRepoLookupCall) Return(arg0 []*domain.VectorRepoLookupResult, arg1 error) *MockVectorRepoLookupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVectorRepoLookupCall) Do(f func(context.Context, *domain.VectorRepoLookupInput) ([]*domain.VectorRepoLookupResult, error)) *MockVectorRepoLookupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVectorRepoLookupCall) DoAndReturn(f func(context.Context, *domain.VectorRepoLookupInput) ([]*domain.VectorRepoLookupResult, error)) *MockVectorRepoLookupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Query mocks base method.
 func (m *MockVectorRepo) Query(ctx context.Context, query *domain.VectorRepoQueryInput) ([]*domain.VectorRepoQueryResult, error) {
 	m.ctrl.T.Helper()
@@ -244,103 +476,805 @@ func (c *MockVectorRepoQueryCall) DoAndReturn(f func(context.Context, *domain.Ve
 	return c
 }
 
-// MockUseCase is a mock of UseCase interface.
-type MockUseCase struct {
+// Scroll mocks base method.
+func (m *MockVectorRepo) Scroll(ctx context.Context, input *domain.VectorRepoScrollInput) (*domain.VectorRepoScrollResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scroll", ctx, input)
+	ret0, _ := ret[0].(*domain.VectorRepoScrollResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scroll indicates an expected call of Scroll.
+func (mr *MockVectorRepoMockRecorder) Scroll(ctx, input any) *MockVectorRepoScrollCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scroll", reflect.TypeOf((*MockVectorRepo)(nil).Scroll), ctx, input)
+	return &MockVectorRepoScrollCall{Call: call}
+}
+
+// MockVectorRepoScrollCall wrap *gomock.Call
+type MockVectorRepoScrollCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockVectorRepoScrollCall) Return(arg0 *domain.VectorRepoScrollResult, arg1 error) *MockVectorRepoScrollCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockVectorRepoScrollCall) Do(f func(context.Context, *domain.VectorRepoScrollInput) (*domain.VectorRepoScrollResult, error)) *MockVectorRepoScrollCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockVectorRepoScrollCall) DoAndReturn(f func(context.Context, *domain.VectorRepoScrollInput) (*domain.VectorRepoScrollResult, error)) *MockVectorRepoScrollCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockInjectionScanner is a mock of InjectionScanner interface.
+type MockInjectionScanner struct {
 	ctrl     *gomock.Controller
-	recorder *MockUseCaseMockRecorder
+	recorder *MockInjectionScannerMockRecorder
 	isgomock struct{}
 }
 
-// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
-type MockUseCaseMockRecorder struct {
-	mock *MockUseCase
+// MockInjectionScannerMockRecorder is the mock recorder for MockInjectionScanner.
+type MockInjectionScannerMockRecorder struct {
+	mock *MockInjectionScanner
 }
 
-// NewMockUseCase creates a new mock instance.
-func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
-	mock := &MockUseCase{ctrl: ctrl}
-	mock.recorder = &MockUseCaseMockRecorder{mock}
+// NewMockInjectionScanner creates a new mock instance.
+func NewMockInjectionScanner(ctrl *gomock.Controller) *MockInjectionScanner {
+	mock := &MockInjectionScanner{ctrl: ctrl}
+	mock.recorder = &MockInjectionScannerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+func (m *MockInjectionScanner) EXPECT() *MockInjectionScannerMockRecorder {
 	return m.recorder
 }
 
-// InsertTexts mocks base method.
-func (m *MockUseCase) InsertTexts(ctx context.Context, input *domain.InsertTextsInput) error {
+// Scan mocks base method.
+func (m *MockInjectionScanner) Scan(text string) (float32, []string) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertTexts", ctx, input)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Scan", text)
+	ret0, _ := ret[0].(float32)
+	ret1, _ := ret[1].([]string)
+	return ret0, ret1
 }
 
-// InsertTexts indicates an expected call of InsertTexts.
-func (mr *MockUseCaseMockRecorder) InsertTexts(ctx, input any) *MockUseCaseInsertTextsCall {
+// Scan indicates an expected call of Scan.
+func (mr *MockInjectionScannerMockRecorder) Scan(text any) *MockInjectionScannerScanCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTexts", reflect.TypeOf((*MockUseCase)(nil).InsertTexts), ctx, input)
-	return &MockUseCaseInsertTextsCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockInjectionScanner)(nil).Scan), text)
+	return &MockInjectionScannerScanCall{Call: call}
 }
 
-// MockUseCaseInsertTextsCall wrap *gomock.Call
-type MockUseCaseInsertTextsCall struct {
+// MockInjectionScannerScanCall wrap *gomock.Call
+type MockInjectionScannerScanCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockUseCaseInsertTextsCall) Return(arg0 error) *MockUseCaseInsertTextsCall {
-	c.Call = c.Call.Return(arg0)
+func (c *MockInjectionScannerScanCall) Return(score float32, rules []string) *MockInjectionScannerScanCall {
+	c.Call = c.Call.Return(score, rules)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockUseCaseInsertTextsCall) Do(f func(context.Context, *domain.InsertTextsInput) error) *MockUseCaseInsertTextsCall {
+func (c *MockInjectionScannerScanCall) Do(f func(string) (float32, []string)) *MockInjectionScannerScanCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockUseCaseInsertTextsCall) DoAndReturn(f func(context.Context, *domain.InsertTextsInput) error) *MockUseCaseInsertTextsCall {
+func (c *MockInjectionScannerScanCall) DoAndReturn(f func(string) (float32, []string)) *MockInjectionScannerScanCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// SearchText mocks base method.
-func (m *MockUseCase) SearchText(ctx context.Context, input *domain.SearchTextInput) (*domain.SearchTextResult, error) {
+// MockRedactor is a mock of Redactor interface.
+type MockRedactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedactorMockRecorder
+	isgomock struct{}
+}
+
+// MockRedactorMockRecorder is the mock recorder for MockRedactor.
+type MockRedactorMockRecorder struct {
+	mock *MockRedactor
+}
+
+// NewMockRedactor creates a new mock instance.
+func NewMockRedactor(ctrl *gomock.Controller) *MockRedactor {
+	mock := &MockRedactor{ctrl: ctrl}
+	mock.recorder = &MockRedactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedactor) EXPECT() *MockRedactorMockRecorder {
+	return m.recorder
+}
+
+// Redact mocks base method.
+func (m *MockRedactor) Redact(ctx context.Context, tenant, text string) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchText", ctx, input)
-	ret0, _ := ret[0].(*domain.SearchTextResult)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Redact", ctx, tenant, text)
+	ret0, _ := ret[0].(string)
+	return ret0
 }
 
-// SearchText indicates an expected call of SearchText.
-func (mr *MockUseCaseMockRecorder) SearchText(ctx, input any) *MockUseCaseSearchTextCall {
+// Redact indicates an expected call of Redact.
+func (mr *MockRedactorMockRecorder) Redact(ctx, tenant, text any) *MockRedactorRedactCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchText", reflect.TypeOf((*MockUseCase)(nil).SearchText), ctx, input)
-	return &MockUseCaseSearchTextCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redact", reflect.TypeOf((*MockRedactor)(nil).Redact), ctx, tenant, text)
+	return &MockRedactorRedactCall{Call: call}
 }
 
-// MockUseCaseSearchTextCall wrap *gomock.Call
-type MockUseCaseSearchTextCall struct {
+// MockRedactorRedactCall wrap *gomock.Call
+type MockRedactorRedactCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockUseCaseSearchTextCall) Return(arg0 *domain.SearchTextResult, arg1 error) *MockUseCaseSearchTextCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *MockRedactorRedactCall) Return(arg0 string) *MockRedactorRedactCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockUseCaseSearchTextCall) Do(f func(context.Context, *domain.SearchTextInput) (*domain.SearchTextResult, error)) *MockUseCaseSearchTextCall {
+func (c *MockRedactorRedactCall) Do(f func(context.Context, string, string) string) *MockRedactorRedactCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockUseCaseSearchTextCall) DoAndReturn(f func(context.Context, *domain.SearchTextInput) (*domain.SearchTextResult, error)) *MockUseCaseSearchTextCall {
+func (c *MockRedactorRedactCall) DoAndReturn(f func(context.Context, string, string) string) *MockRedactorRedactCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSparseEncoder is a mock of SparseEncoder interface.
+type MockSparseEncoder struct {
+	ctrl     *gomock.Controller
+	recorder *MockSparseEncoderMockRecorder
+	isgomock struct{}
+}
+
+// MockSparseEncoderMockRecorder is the mock recorder for MockSparseEncoder.
+type MockSparseEncoderMockRecorder struct {
+	mock *MockSparseEncoder
+}
+
+// NewMockSparseEncoder creates a new mock instance.
+func NewMockSparseEncoder(ctrl *gomock.Controller) *MockSparseEncoder {
+	mock := &MockSparseEncoder{ctrl: ctrl}
+	mock.recorder = &MockSparseEncoderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSparseEncoder) EXPECT() *MockSparseEncoderMockRecorder {
+	return m.recorder
+}
+
+// EncodeDocument mocks base method.
+func (m *MockSparseEncoder) EncodeDocument(text string) *domain.SparseVector {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncodeDocument", text)
+	ret0, _ := ret[0].(*domain.SparseVector)
+	return ret0
+}
+
+// EncodeDocument indicates an expected call of EncodeDocument.
+func (mr *MockSparseEncoderMockRecorder) EncodeDocument(text any) *MockSparseEncoderEncodeDocumentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncodeDocument", reflect.TypeOf((*MockSparseEncoder)(nil).EncodeDocument), text)
+	return &MockSparseEncoderEncodeDocumentCall{Call: call}
+}
+
+// MockSparseEncoderEncodeDocumentCall wrap *gomock.Call
+type MockSparseEncoderEncodeDocumentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSparseEncoderEncodeDocumentCall) Return(arg0 *domain.SparseVector) *MockSparseEncoderEncodeDocumentCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSparseEncoderEncodeDocumentCall) Do(f func(string) *domain.SparseVector) *MockSparseEncoderEncodeDocumentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSparseEncoderEncodeDocumentCall) DoAndReturn(f func(string) *domain.SparseVector) *MockSparseEncoderEncodeDocumentCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// EncodeQuery mocks base method.
+func (m *MockSparseEncoder) EncodeQuery(text string) *domain.SparseVector {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncodeQuery", text)
+	ret0, _ := ret[0].(*domain.SparseVector)
+	return ret0
+}
+
+// EncodeQuery indicates an expected call of EncodeQuery.
+func (mr *MockSparseEncoderMockRecorder) EncodeQuery(text any) *MockSparseEncoderEncodeQueryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncodeQuery", reflect.TypeOf((*MockSparseEncoder)(nil).EncodeQuery), text)
+	return &MockSparseEncoderEncodeQueryCall{Call: call}
+}
+
+// MockSparseEncoderEncodeQueryCall wrap *gomock.Call
+type MockSparseEncoderEncodeQueryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSparseEncoderEncodeQueryCall) Return(arg0 *domain.SparseVector) *MockSparseEncoderEncodeQueryCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSparseEncoderEncodeQueryCall) Do(f func(string) *domain.SparseVector) *MockSparseEncoderEncodeQueryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSparseEncoderEncodeQueryCall) DoAndReturn(f func(string) *domain.SparseVector) *MockSparseEncoderEncodeQueryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockChunker is a mock of Chunker interface.
+type MockChunker struct {
+	ctrl     *gomock.Controller
+	recorder *MockChunkerMockRecorder
+	isgomock struct{}
+}
+
+// MockChunkerMockRecorder is the mock recorder for MockChunker.
+type MockChunkerMockRecorder struct {
+	mock *MockChunker
+}
+
+// NewMockChunker creates a new mock instance.
+func NewMockChunker(ctrl *gomock.Controller) *MockChunker {
+	mock := &MockChunker{ctrl: ctrl}
+	mock.recorder = &MockChunkerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChunker) EXPECT() *MockChunkerMockRecorder {
+	return m.recorder
+}
+
+// Chunk mocks base method.
+func (m *MockChunker) Chunk(text string, options *domain.ChunkingOptions) []*domain.Chunk {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Chunk", text, options)
+	ret0, _ := ret[0].([]*domain.Chunk)
+	return ret0
+}
+
+// Chunk indicates an expected call of Chunk.
+func (mr *MockChunkerMockRecorder) Chunk(text, options any) *MockChunkerChunkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chunk", reflect.TypeOf((*MockChunker)(nil).Chunk), text, options)
+	return &MockChunkerChunkCall{Call: call}
+}
+
+// MockChunkerChunkCall wrap *gomock.Call
+type MockChunkerChunkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockChunkerChunkCall) Return(arg0 []*domain.Chunk) *MockChunkerChunkCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockChunkerChunkCall) Do(f func(string, *domain.ChunkingOptions) []*domain.Chunk) *MockChunkerChunkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockChunkerChunkCall) DoAndReturn(f func(string, *domain.ChunkingOptions) []*domain.Chunk) *MockChunkerChunkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockParser is a mock of Parser interface.
+type MockParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserMockRecorder
+	isgomock struct{}
+}
+
+// MockParserMockRecorder is the mock recorder for MockParser.
+type MockParserMockRecorder struct {
+	mock *MockParser
+}
+
+// NewMockParser creates a new mock instance.
+func NewMockParser(ctrl *gomock.Controller) *MockParser {
+	mock := &MockParser{ctrl: ctrl}
+	mock.recorder = &MockParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockParser) EXPECT() *MockParserMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockParser) Parse(content []byte, mimeType string) (*domain.ParsedDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", content, mimeType)
+	ret0, _ := ret[0].(*domain.ParsedDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockParserMockRecorder) Parse(content, mimeType any) *MockParserParseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockParser)(nil).Parse), content, mimeType)
+	return &MockParserParseCall{Call: call}
+}
+
+// MockParserParseCall wrap *gomock.Call
+type MockParserParseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockParserParseCall) Return(arg0 *domain.ParsedDocument, arg1 error) *MockParserParseCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockParserParseCall) Do(f func([]byte, string) (*domain.ParsedDocument, error)) *MockParserParseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockParserParseCall) DoAndReturn(f func([]byte, string) (*domain.ParsedDocument, error)) *MockParserParseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteByFilter mocks base method.
+func (m *MockUseCase) DeleteByFilter(ctx context.Context, input *domain.DeleteByFilterInput) (*domain.DeleteTextsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByFilter", ctx, input)
+	ret0, _ := ret[0].(*domain.DeleteTextsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByFilter indicates an expected call of DeleteByFilter.
+func (mr *MockUseCaseMockRecorder) DeleteByFilter(ctx, input any) *MockUseCaseDeleteByFilterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByFilter", reflect.TypeOf((*MockUseCase)(nil).DeleteByFilter), ctx, input)
+	return &MockUseCaseDeleteByFilterCall{Call: call}
+}
+
+// MockUseCaseDeleteByFilterCall wrap *gomock.Call
+type MockUseCaseDeleteByFilterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseDeleteByFilterCall) Return(arg0 *domain.DeleteTextsResult, arg1 error) *MockUseCaseDeleteByFilterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseDeleteByFilterCall) Do(f func(context.Context, *domain.DeleteByFilterInput) (*domain.DeleteTextsResult, error)) *MockUseCaseDeleteByFilterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseDeleteByFilterCall) DoAndReturn(f func(context.Context, *domain.DeleteByFilterInput) (*domain.DeleteTextsResult, error)) *MockUseCaseDeleteByFilterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteTexts mocks base method.
+func (m *MockUseCase) DeleteTexts(ctx context.Context, input *domain.DeleteTextsInput) (*domain.DeleteTextsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTexts", ctx, input)
+	ret0, _ := ret[0].(*domain.DeleteTextsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTexts indicates an expected call of DeleteTexts.
+func (mr *MockUseCaseMockRecorder) DeleteTexts(ctx, input any) *MockUseCaseDeleteTextsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTexts", reflect.TypeOf((*MockUseCase)(nil).DeleteTexts), ctx, input)
+	return &MockUseCaseDeleteTextsCall{Call: call}
+}
+
+// MockUseCaseDeleteTextsCall wrap *gomock.Call
+type MockUseCaseDeleteTextsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseDeleteTextsCall) Return(arg0 *domain.DeleteTextsResult, arg1 error) *MockUseCaseDeleteTextsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseDeleteTextsCall) Do(f func(context.Context, *domain.DeleteTextsInput) (*domain.DeleteTextsResult, error)) *MockUseCaseDeleteTextsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseDeleteTextsCall) DoAndReturn(f func(context.Context, *domain.DeleteTextsInput) (*domain.DeleteTextsResult, error)) *MockUseCaseDeleteTextsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTexts mocks base method.
+func (m *MockUseCase) GetTexts(ctx context.Context, input *domain.GetTextsInput) (*domain.GetTextsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTexts", ctx, input)
+	ret0, _ := ret[0].(*domain.GetTextsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTexts indicates an expected call of GetTexts.
+func (mr *MockUseCaseMockRecorder) GetTexts(ctx, input any) *MockUseCaseGetTextsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTexts", reflect.TypeOf((*MockUseCase)(nil).GetTexts), ctx, input)
+	return &MockUseCaseGetTextsCall{Call: call}
+}
+
+// MockUseCaseGetTextsCall wrap *gomock.Call
+type MockUseCaseGetTextsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseGetTextsCall) Return(arg0 *domain.GetTextsResult, arg1 error) *MockUseCaseGetTextsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseGetTextsCall) Do(f func(context.Context, *domain.GetTextsInput) (*domain.GetTextsResult, error)) *MockUseCaseGetTextsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseGetTextsCall) DoAndReturn(f func(context.Context, *domain.GetTextsInput) (*domain.GetTextsResult, error)) *MockUseCaseGetTextsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertDocuments mocks base method.
+func (m *MockUseCase) InsertDocuments(ctx context.Context, input *domain.InsertDocumentsInput) (*domain.InsertDocumentsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDocuments", ctx, input)
+	ret0, _ := ret[0].(*domain.InsertDocumentsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertDocuments indicates an expected call of InsertDocuments.
+func (mr *MockUseCaseMockRecorder) InsertDocuments(ctx, input any) *MockUseCaseInsertDocumentsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDocuments", reflect.TypeOf((*MockUseCase)(nil).InsertDocuments), ctx, input)
+	return &MockUseCaseInsertDocumentsCall{Call: call}
+}
+
+// MockUseCaseInsertDocumentsCall wrap *gomock.Call
+type MockUseCaseInsertDocumentsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseInsertDocumentsCall) Return(arg0 *domain.InsertDocumentsResult, arg1 error) *MockUseCaseInsertDocumentsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseInsertDocumentsCall) Do(f func(context.Context, *domain.InsertDocumentsInput) (*domain.InsertDocumentsResult, error)) *MockUseCaseInsertDocumentsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseInsertDocumentsCall) DoAndReturn(f func(context.Context, *domain.InsertDocumentsInput) (*domain.InsertDocumentsResult, error)) *MockUseCaseInsertDocumentsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InsertTexts mocks base method.
+func (m *MockUseCase) InsertTexts(ctx context.Context, input *domain.InsertTextsInput) (*domain.InsertTextsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTexts", ctx, input)
+	ret0, _ := ret[0].(*domain.InsertTextsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertTexts indicates an expected call of InsertTexts.
+func (mr *MockUseCaseMockRecorder) InsertTexts(ctx, input any) *MockUseCaseInsertTextsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTexts", reflect.TypeOf((*MockUseCase)(nil).InsertTexts), ctx, input)
+	return &MockUseCaseInsertTextsCall{Call: call}
+}
+
+// MockUseCaseInsertTextsCall wrap *gomock.Call
+type MockUseCaseInsertTextsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseInsertTextsCall) Return(arg0 *domain.InsertTextsResult, arg1 error) *MockUseCaseInsertTextsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseInsertTextsCall) Do(f func(context.Context, *domain.InsertTextsInput) (*domain.InsertTextsResult, error)) *MockUseCaseInsertTextsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseInsertTextsCall) DoAndReturn(f func(context.Context, *domain.InsertTextsInput) (*domain.InsertTextsResult, error)) *MockUseCaseInsertTextsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListTexts mocks base method.
+func (m *MockUseCase) ListTexts(ctx context.Context, input *domain.ListTextsInput) (*domain.ListTextsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTexts", ctx, input)
+	ret0, _ := ret[0].(*domain.ListTextsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTexts indicates an expected call of ListTexts.
+func (mr *MockUseCaseMockRecorder) ListTexts(ctx, input any) *MockUseCaseListTextsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTexts", reflect.TypeOf((*MockUseCase)(nil).ListTexts), ctx, input)
+	return &MockUseCaseListTextsCall{Call: call}
+}
+
+// MockUseCaseListTextsCall wrap *gomock.Call
+type MockUseCaseListTextsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseListTextsCall) Return(arg0 *domain.ListTextsResult, arg1 error) *MockUseCaseListTextsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseListTextsCall) Do(f func(context.Context, *domain.ListTextsInput) (*domain.ListTextsResult, error)) *MockUseCaseListTextsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseListTextsCall) DoAndReturn(f func(context.Context, *domain.ListTextsInput) (*domain.ListTextsResult, error)) *MockUseCaseListTextsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LookupTexts mocks base method.
+func (m *MockUseCase) LookupTexts(ctx context.Context, input *domain.LookupTextsInput) (*domain.LookupTextsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupTexts", ctx, input)
+	ret0, _ := ret[0].(*domain.LookupTextsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupTexts indicates an expected call of LookupTexts.
+func (mr *MockUseCaseMockRecorder) LookupTexts(ctx, input any) *MockUseCaseLookupTextsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupTexts", reflect.TypeOf((*MockUseCase)(nil).LookupTexts), ctx, input)
+	return &MockUseCaseLookupTextsCall{Call: call}
+}
+
+// MockUseCaseLookupTextsCall wrap *gomock.Call
+type MockUseCaseLookupTextsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseLookupTextsCall) Return(arg0 *domain.LookupTextsResult, arg1 error) *MockUseCaseLookupTextsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseLookupTextsCall) Do(f func(context.Context, *domain.LookupTextsInput) (*domain.LookupTextsResult, error)) *MockUseCaseLookupTextsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseLookupTextsCall) DoAndReturn(f func(context.Context, *domain.LookupTextsInput) (*domain.LookupTextsResult, error)) *MockUseCaseLookupTextsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SearchText mocks base method.
+func (m *MockUseCase) SearchText(ctx context.Context, input *domain.SearchTextInput) (*domain.SearchTextResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchText", ctx, input)
+	ret0, _ := ret[0].(*domain.SearchTextResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchText indicates an expected call of SearchText.
+func (mr *MockUseCaseMockRecorder) SearchText(ctx, input any) *MockUseCaseSearchTextCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchText", reflect.TypeOf((*MockUseCase)(nil).SearchText), ctx, input)
+	return &MockUseCaseSearchTextCall{Call: call}
+}
+
+// MockUseCaseSearchTextCall wrap *gomock.Call
+type MockUseCaseSearchTextCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseSearchTextCall) Return(arg0 *domain.SearchTextResult, arg1 error) *MockUseCaseSearchTextCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseSearchTextCall) Do(f func(context.Context, *domain.SearchTextInput) (*domain.SearchTextResult, error)) *MockUseCaseSearchTextCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseSearchTextCall) DoAndReturn(f func(context.Context, *domain.SearchTextInput) (*domain.SearchTextResult, error)) *MockUseCaseSearchTextCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StreamInsertTexts mocks base method.
+func (m *MockUseCase) StreamInsertTexts(ctx context.Context, receive func() (*domain.InsertTextsInputText, error)) (*domain.StreamInsertTextsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamInsertTexts", ctx, receive)
+	ret0, _ := ret[0].(*domain.StreamInsertTextsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamInsertTexts indicates an expected call of StreamInsertTexts.
+func (mr *MockUseCaseMockRecorder) StreamInsertTexts(ctx, receive any) *MockUseCaseStreamInsertTextsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamInsertTexts", reflect.TypeOf((*MockUseCase)(nil).StreamInsertTexts), ctx, receive)
+	return &MockUseCaseStreamInsertTextsCall{Call: call}
+}
+
+// MockUseCaseStreamInsertTextsCall wrap *gomock.Call
+type MockUseCaseStreamInsertTextsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseStreamInsertTextsCall) Return(arg0 *domain.StreamInsertTextsResult, arg1 error) *MockUseCaseStreamInsertTextsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseStreamInsertTextsCall) Do(f func(context.Context, func() (*domain.InsertTextsInputText, error)) (*domain.StreamInsertTextsResult, error)) *MockUseCaseStreamInsertTextsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseStreamInsertTextsCall) DoAndReturn(f func(context.Context, func() (*domain.InsertTextsInputText, error)) (*domain.StreamInsertTextsResult, error)) *MockUseCaseStreamInsertTextsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UploadDocument mocks base method.
+func (m *MockUseCase) UploadDocument(ctx context.Context, input *domain.UploadDocumentInput) (*domain.UploadDocumentResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadDocument", ctx, input)
+	ret0, _ := ret[0].(*domain.UploadDocumentResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadDocument indicates an expected call of UploadDocument.
+func (mr *MockUseCaseMockRecorder) UploadDocument(ctx, input any) *MockUseCaseUploadDocumentCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDocument", reflect.TypeOf((*MockUseCase)(nil).UploadDocument), ctx, input)
+	return &MockUseCaseUploadDocumentCall{Call: call}
+}
+
+// MockUseCaseUploadDocumentCall wrap *gomock.Call
+type MockUseCaseUploadDocumentCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockUseCaseUploadDocumentCall) Return(arg0 *domain.UploadDocumentResult, arg1 error) *MockUseCaseUploadDocumentCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockUseCaseUploadDocumentCall) Do(f func(context.Context, *domain.UploadDocumentInput) (*domain.UploadDocumentResult, error)) *MockUseCaseUploadDocumentCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockUseCaseUploadDocumentCall) DoAndReturn(f func(context.Context, *domain.UploadDocumentInput) (*domain.UploadDocumentResult, error)) *MockUseCaseUploadDocumentCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
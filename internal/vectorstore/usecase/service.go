@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 
+	internal_error "github.com/aria3ppp/rag-server/internal/pkg/error"
+	"github.com/aria3ppp/rag-server/internal/pkg/injection"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/config"
 	"github.com/aria3ppp/rag-server/internal/vectorstore/domain"
 
@@ -14,35 +18,62 @@ import (
 )
 
 type usecase struct {
-	embedder    Embedder
-	vectorRepo  VectorRepo
-	idGenerator IDGenerator
-	config      *config.Config
-	tracer      trace.Tracer
-	logger      *slog.Logger
+	embedder         Embedder
+	vectorRepo       VectorRepo
+	idGenerator      IDGenerator
+	injectionScanner InjectionScanner
+	redactor         Redactor
+	sparseEncoder    SparseEncoder
+	chunker          Chunker
+	parser           Parser
+	collections      map[string]*Collection
+	config           *config.Config
+	tracer           trace.Tracer
+	logger           *slog.Logger
 }
 
 var _ UseCase = (*usecase)(nil)
 
+// NewUseCase returns the vectorstore usecase. A nil injectionScanner disables
+// flagging the inserted prompt injections, a nil redactor disables redacting
+// the personal data in the inserted texts, a nil sparseEncoder disables the
+// sparse and hybrid searches, a nil chunker disables inserting and uploading
+// documents and a nil parser disables uploading documents. The federated
+// searches find the collections by name in collections, besides the default
+// collection.
 func NewUseCase(
 	embedder Embedder,
 	idGenerator IDGenerator,
 	vectorRepo VectorRepo,
+	injectionScanner InjectionScanner,
+	redactor Redactor,
+	sparseEncoder SparseEncoder,
+	chunker Chunker,
+	parser Parser,
+	collections map[string]*Collection,
 	config *config.Config,
 	tracer trace.Tracer,
 	logger *slog.Logger,
 ) *usecase {
 	return &usecase{
-		embedder:    embedder,
-		idGenerator: idGenerator,
-		vectorRepo:  vectorRepo,
-		config:      config,
-		tracer:      tracer,
-		logger:      logger,
+		embedder:         embedder,
+		idGenerator:      idGenerator,
+		vectorRepo:       vectorRepo,
+		injectionScanner: injectionScanner,
+		redactor:         redactor,
+		sparseEncoder:    sparseEncoder,
+		chunker:          chunker,
+		parser:           parser,
+		collections:      collections,
+		config:           config,
+		tracer:           tracer,
+		logger:           logger,
 	}
 }
 
-func (uc *usecase) InsertTexts(ctx context.Context, input *domain.InsertTextsInput) (err error) {
+// InsertTexts inserts all the texts or none of them: when the embedder fails
+// on some texts, it returns a ValidationError listing their indexes.
+func (uc *usecase) InsertTexts(ctx context.Context, input *domain.InsertTextsInput) (_ *domain.InsertTextsResult, err error) {
 	ctx, span := uc.tracer.Start(ctx, "usecase.InsertTexts")
 	defer func() {
 		defer span.End()
@@ -52,31 +83,87 @@ func (uc *usecase) InsertTexts(ctx context.Context, input *domain.InsertTextsInp
 		}
 	}()
 
+	result, embedError, err := uc.insertTexts(ctx, uc.defaultCollection(), input, false)
+	if err != nil {
+		return nil, err
+	}
+	if embedError != nil {
+		return nil, internal_error.NewValidationError(embedError)
+	}
+
+	return result, nil
+}
+
+// insertTexts inserts the texts into the collection. When the embedder fails
+// on some texts, they are returned in the embed error and, with partial, the
+// others are inserted with an empty id at the failed indexes, otherwise none
+// is inserted.
+func (uc *usecase) insertTexts(ctx context.Context, collection *Collection, input *domain.InsertTextsInput, partial bool) (*domain.InsertTextsResult, *domain.EmbedError, error) {
 	if err := input.Validate(ctx); err != nil {
 		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
-		return err
+		return nil, nil, err
 	}
 
-	textsString := lo.Map(input.Texts, func(item *domain.InsertTextsInputText, _ int) string { return item.Text })
+	// the personal data is redacted before it reaches the embedder and the payloads
+	texts := input.Texts
+	if uc.redactor != nil {
+		texts = lo.Map(texts, func(text *domain.InsertTextsInputText, _ int) *domain.InsertTextsInputText {
+			return uc.redactText(ctx, text)
+		})
+	}
 
-	embeddings, err := uc.embedder.Embed(ctx, textsString)
-	if err != nil {
+	textsString := lo.Map(texts, func(item *domain.InsertTextsInputText, _ int) string { return item.Text })
+
+	embeddings, err := collection.Embedder.Embed(ctx, textsString)
+	embedError, _ := err.(*domain.EmbedError)
+	if err != nil && (embedError == nil || !partial) {
 		uc.logger.ErrorContext(ctx, "failed to embed text", slog.String("error", err.Error()))
-		return err
+		if embedError != nil {
+			return nil, embedError, nil
+		}
+		return nil, nil, err
+	}
+
+	if len(embeddings) != len(texts) {
+		uc.logger.ErrorContext(ctx, "invalid embeddings length", slog.Int("texts length", len(texts)), slog.Int("embeddings length", len(embeddings)))
+		return nil, nil, fmt.Errorf("invalid embeddings length: texts length = %d, embeddings length = %d", len(texts), len(embeddings))
 	}
 
-	if len(embeddings) != len(input.Texts) {
-		uc.logger.ErrorContext(ctx, "invalid embeddings length", slog.Int("texts length", len(input.Texts)), slog.Int("embeddings length", len(embeddings)))
-		return fmt.Errorf("invalid embeddings length: texts length = %d, embeddings length = %d", len(input.Texts), len(embeddings))
+	failed := make(map[int]bool)
+	if embedError != nil {
+		uc.logger.WarnContext(ctx, "failed to embed texts, inserting the others", slog.Int("failed texts", len(embedError.Failures)))
+		for _, failure := range embedError.Failures {
+			failed[failure.Index] = true
+		}
 	}
 
-	vectorRepoInsertEmbeddings := make([]*domain.VectorRepoInsertEmbedding, 0, len(input.Texts))
+	vectorRepoInsertEmbeddings := make([]*domain.VectorRepoInsertEmbedding, 0, len(texts))
+	ids := make([]string, 0, len(texts))
+	var skipped []*domain.InsertTextsResultSkipped
+
+	for index, text := range texts {
+		if failed[index] {
+			ids = append(ids, "")
+			continue
+		}
 
-	for index, text := range input.Texts {
-		id, err := uc.idGenerator.NewID()
+		id, err := uc.textID(input, text)
 		if err != nil {
 			uc.logger.ErrorContext(ctx, "failed to generate new id", slog.String("error", err.Error()))
-			return err
+			return nil, nil, err
+		}
+
+		if input.DedupThreshold > 0 {
+			duplicateID, score, err := uc.nearDuplicate(ctx, collection.VectorRepo, id, embeddings[index], vectorRepoInsertEmbeddings, input.DedupThreshold)
+			if err != nil {
+				return nil, nil, err
+			}
+			if duplicateID != "" {
+				uc.logger.InfoContext(ctx, "skipped near duplicate text", slog.Int("index", index), slog.String("duplicate id", duplicateID), slog.Float64("score", float64(score)))
+				ids = append(ids, duplicateID)
+				skipped = append(skipped, &domain.InsertTextsResultSkipped{Index: index, DuplicateID: duplicateID, Score: score})
+				continue
+			}
 		}
 
 		metadata := lo.Assign(
@@ -84,26 +171,151 @@ func (uc *usecase) InsertTexts(ctx context.Context, input *domain.InsertTextsInp
 			map[string]any{"text": text.Text},
 		)
 
+		var sparse *domain.SparseVector
+		if uc.sparseEncoder != nil {
+			sparse = uc.sparseEncoder.EncodeDocument(text.Text)
+		}
+
+		if uc.injectionScanner != nil {
+			if score, rules := uc.injectionScanner.Scan(text.Text); score >= uc.config.ScreeningConfig.Threshold {
+				uc.logger.WarnContext(ctx, "flagged inserted text as prompt injection", slog.String("id", id), slog.Any("rules", rules))
+				metadata[injection.MetadataFlagged] = true
+				metadata[injection.MetadataScore] = score
+				// the qdrant payload only takes untyped lists
+				metadata[injection.MetadataRules] = lo.ToAnySlice(rules)
+			}
+		}
+
+		ids = append(ids, id)
 		vectorRepoInsertEmbeddings = append(
 			vectorRepoInsertEmbeddings,
 			&domain.VectorRepoInsertEmbedding{
 				ID:       id,
 				Vector:   embeddings[index],
+				Sparse:   sparse,
 				Metadata: metadata,
 			},
 		)
 	}
 
-	if err := uc.vectorRepo.Insert(ctx, vectorRepoInsertEmbeddings); err != nil {
-		uc.logger.ErrorContext(ctx, "failed to repo insert", slog.String("error", err.Error()))
-		return err
+	// the texts of the existing ids are replaced
+	if len(vectorRepoInsertEmbeddings) > 0 {
+		if err := collection.VectorRepo.Insert(ctx, vectorRepoInsertEmbeddings); err != nil {
+			uc.logger.ErrorContext(ctx, "failed to repo insert", slog.String("error", err.Error()))
+			return nil, nil, err
+		}
 	}
 
-	return nil
+	return &domain.InsertTextsResult{IDs: ids, Skipped: skipped}, embedError, nil
 }
 
-func (uc *usecase) SearchText(ctx context.Context, input *domain.SearchTextInput) (_ *domain.SearchTextResult, err error) {
-	ctx, span := uc.tracer.Start(ctx, "usecase.SearchText")
+func (uc *usecase) StreamInsertTexts(ctx context.Context, receive func() (*domain.InsertTextsInputText, error)) (_ *domain.StreamInsertTextsResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.StreamInsertTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	result := &domain.StreamInsertTextsResult{}
+
+	// only a batch of texts and the failed texts are held at a time
+	var (
+		batch      = make([]*domain.InsertTextsInputText, 0, uc.config.StreamingConfig.BatchSize)
+		batchItems = make([]*domain.StreamInsertTextsResultItem, 0, uc.config.StreamingConfig.BatchSize)
+		batchIDs   = make(map[string]struct{}, uc.config.StreamingConfig.BatchSize)
+	)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		// only the texts the embedder failed on fail, the others are inserted
+		_, embedError, err := uc.insertTexts(ctx, uc.defaultCollection(), &domain.InsertTextsInput{Texts: batch}, true)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "failed to insert batch", slog.Int("first index", batchItems[0].Index), slog.Int("batch size", len(batch)), slog.String("error", err.Error()))
+		}
+
+		failures := make(map[int]error)
+		if embedError != nil {
+			for _, failure := range embedError.Failures {
+				failures[failure.Index] = failure.Err
+			}
+		}
+
+		for i, item := range batchItems {
+			switch {
+			case err != nil:
+				item.Error = err.Error()
+			case failures[i] != nil:
+				item.Error = failures[i].Error()
+			default:
+				result.Inserted++
+				continue
+			}
+			result.FailedItems = append(result.FailedItems, item)
+			result.Failed++
+		}
+
+		batch, batchItems = batch[:0], batchItems[:0]
+		clear(batchIDs)
+	}
+
+	for index := 0; ; index++ {
+		text, err := receive()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "failed to receive text", slog.Int("index", index), slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		item := &domain.StreamInsertTextsResultItem{Index: index}
+
+		if err := text.Validate(ctx); err != nil {
+			item.Error = err.Error()
+			result.FailedItems = append(result.FailedItems, item)
+			result.Failed++
+			continue
+		}
+
+		// a text replacing a text of the batch is inserted after it
+		if text.ID != "" {
+			if _, ok := batchIDs[text.ID]; ok {
+				flush()
+			}
+			batchIDs[text.ID] = struct{}{}
+		}
+
+		batch = append(batch, text)
+		batchItems = append(batchItems, item)
+		if len(batch) >= uc.config.StreamingConfig.BatchSize {
+			flush()
+		}
+	}
+	flush()
+
+	return result, nil
+}
+
+// textID returns the id of text from its client id or, when it has none, from
+// the id mode of input.
+func (uc *usecase) textID(input *domain.InsertTextsInput, text *domain.InsertTextsInputText) (string, error) {
+	switch {
+	case text.ID != "":
+		return uc.idGenerator.IDFromClientID(text.ID), nil
+	case input.IDMode == domain.IDModeContent:
+		return uc.idGenerator.IDFromContent(input.IDNamespace, text.Text), nil
+	default:
+		return uc.idGenerator.NewID()
+	}
+}
+
+func (uc *usecase) InsertDocuments(ctx context.Context, input *domain.InsertDocumentsInput) (_ *domain.InsertDocumentsResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.InsertDocuments")
 	defer func() {
 		defer span.End()
 		if err != nil {
@@ -117,33 +329,332 @@ func (uc *usecase) SearchText(ctx context.Context, input *domain.SearchTextInput
 		return nil, err
 	}
 
-	vectors, err := uc.embedder.Embed(ctx, []string{input.Text})
+	if uc.chunker == nil {
+		err := internal_error.NewValidationError(errors.New("inserting documents is disabled"))
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	collection, err := uc.insertCollection(ctx, input.Collection)
 	if err != nil {
-		uc.logger.ErrorContext(ctx, "failed to embed text", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	if len(vectors) != 1 {
-		uc.logger.ErrorContext(ctx, "invalid vectors length", slog.Int("must", 1), slog.Int("got", len(vectors)))
-		return nil, fmt.Errorf("invalid vectors length: vector length must be 1 got %d", len(vectors))
+	options, err := uc.chunkingOptions(collection, input.Chunking)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
 	}
 
-	vectorRepoQueryInput := &domain.VectorRepoQueryInput{
-		Vector:   vectors[0],
-		TopK:     input.TopK,
-		MinScore: input.MinScore,
-		Filter:   input.Filter,
+	documents := make([]*document, len(input.Documents))
+	for index, inputDocument := range input.Documents {
+		documents[index] = &document{
+			id:       inputDocument.ID,
+			metadata: inputDocument.Metadata,
+			sections: []*domain.ParsedSection{{Text: inputDocument.Text}},
+		}
 	}
 
-	queryResults, err := uc.vectorRepo.Query(ctx, vectorRepoQueryInput)
+	results, err := uc.insertDocuments(ctx, collection, documents, options)
 	if err != nil {
-		uc.logger.ErrorContext(ctx, "failed to repo query", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	if len(queryResults) > input.TopK {
-		uc.logger.ErrorContext(ctx, "query results length couldn't exceed topk", slog.Int("query result length", len(queryResults)), slog.Int("topk", input.TopK))
-		return nil, fmt.Errorf("query results length couldn't exceed topk: query result length = %d, topk = %d", len(queryResults), input.TopK)
+	return &domain.InsertDocumentsResult{Documents: results}, nil
+}
+
+func (uc *usecase) UploadDocument(ctx context.Context, input *domain.UploadDocumentInput) (_ *domain.UploadDocumentResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.UploadDocument")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if err := input.Validate(ctx); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if uc.chunker == nil || uc.parser == nil {
+		err := internal_error.NewValidationError(errors.New("uploading documents is disabled"))
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	collection, err := uc.insertCollection(ctx, input.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := uc.parser.Parse(input.Content, input.MIMEType)
+	if err != nil {
+		err := internal_error.NewValidationError(fmt.Errorf("content: %w", err))
+		uc.logger.ErrorContext(ctx, "failed to parse document", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// the chunker suiting the parsed text is the default of the upload
+	chunking := &domain.InsertDocumentsChunking{}
+	if input.Chunking != nil {
+		*chunking = *input.Chunking
+	}
+	if chunking.Chunker == "" {
+		chunking.Chunker = parsed.Chunker
+	}
+
+	options, err := uc.chunkingOptions(collection, chunking)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	results, err := uc.insertDocuments(
+		ctx,
+		collection,
+		[]*document{{
+			id:       input.ID,
+			metadata: lo.Assign(input.Metadata, parsed.Metadata),
+			sections: parsed.Sections,
+		}},
+		options,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UploadDocumentResult{ID: results[0].ID, ChunkIDs: results[0].ChunkIDs}, nil
+}
+
+// document is a document to insert, chunked section by section.
+type document struct {
+	// id is the client id of the document, a random id is generated when it is empty.
+	id       string
+	metadata map[string]any
+	sections []*domain.ParsedSection
+}
+
+// insertDocuments inserts the chunks of the documents into the collection in
+// a single batch and deletes the chunks left over from the longer versions of
+// the documents with a client id. The chunks are indexed across the sections
+// of their document and their offsets are in the text of their section.
+func (uc *usecase) insertDocuments(ctx context.Context, collection *Collection, documents []*document, options *domain.ChunkingOptions) ([]*domain.InsertDocumentsResultDocument, error) {
+	results := make([]*domain.InsertDocumentsResultDocument, 0, len(documents))
+	var texts []*domain.InsertTextsInputText
+
+	for _, document := range documents {
+		documentID := document.id
+		if documentID == "" {
+			var err error
+			documentID, err = uc.idGenerator.NewID()
+			if err != nil {
+				uc.logger.ErrorContext(ctx, "failed to generate new id", slog.String("error", err.Error()))
+				return nil, err
+			}
+		}
+
+		chunkIndex := 0
+		for _, section := range document.sections {
+			for _, chunk := range uc.chunker.Chunk(section.Text, options) {
+				metadata := lo.Assign(
+					document.metadata,
+					section.Metadata,
+					map[string]any{
+						domain.MetadataDocumentID: documentID,
+						domain.MetadataChunkIndex: chunkIndex,
+						domain.MetadataChunkStart: chunk.Start,
+						domain.MetadataChunkEnd:   chunk.End,
+					},
+				)
+				if len(chunk.Headings) > 0 {
+					// the qdrant payload only takes untyped lists
+					metadata[domain.MetadataHeadings] = lo.ToAnySlice(chunk.Headings)
+				}
+
+				texts = append(texts, &domain.InsertTextsInputText{
+					ID:       fmt.Sprintf("%s#%d", documentID, chunkIndex),
+					Text:     chunk.Text,
+					Metadata: metadata,
+				})
+				chunkIndex++
+			}
+		}
+
+		if chunkIndex == 0 {
+			err := internal_error.NewValidationError(fmt.Errorf("document %q has no text to chunk", documentID))
+			uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		results = append(results, &domain.InsertDocumentsResultDocument{ID: documentID, ChunkIDs: make([]string, chunkIndex)})
+	}
+
+	insertTextsResult, embedError, err := uc.insertTexts(ctx, collection, &domain.InsertTextsInput{Texts: texts}, false)
+	if err == nil && embedError != nil {
+		err = internal_error.NewValidationError(embedError)
+	}
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to insert chunks", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	ids := insertTextsResult.IDs
+	for index, result := range results {
+		ids = ids[copy(result.ChunkIDs, ids):]
+
+		// a replaced document may have had more chunks
+		if documents[index].id == "" {
+			continue
+		}
+		_, err := collection.VectorRepo.DeleteByFilter(ctx, &domain.VectorRepoDeleteByFilterInput{
+			Filter: &domain.Filter{
+				Must: []*domain.FilterCondition{
+					{Key: domain.MetadataDocumentID, Match: result.ID},
+					{Key: domain.MetadataChunkIndex, Range: &domain.FilterRange{Gte: lo.ToPtr(float64(len(result.ChunkIDs)))}},
+				},
+			},
+		})
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "failed to repo delete stale chunks", slog.String("document id", result.ID), slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// insertCollection returns the collection named name the documents are
+// inserted into, the default collection when name is empty.
+func (uc *usecase) insertCollection(ctx context.Context, name string) (*Collection, error) {
+	if name == "" {
+		return uc.defaultCollection(), nil
+	}
+
+	collection, ok := uc.collection(name)
+	if !ok {
+		err := internal_error.NewValidationError(fmt.Errorf("unknown collection %q", name))
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// chunkingOptions overrides the chunking config of the collection, or the
+// default one when it has none, with the fields of chunking.
+func (uc *usecase) chunkingOptions(collection *Collection, chunking *domain.InsertDocumentsChunking) (*domain.ChunkingOptions, error) {
+	chunkingConfig := collection.ChunkingConfig
+	if chunkingConfig == nil {
+		chunkingConfig = &uc.config.ChunkingConfig
+	}
+
+	options := &domain.ChunkingOptions{
+		Chunker: chunkingConfig.Chunker,
+		Size:    chunkingConfig.Size,
+		Overlap: chunkingConfig.Overlap,
+	}
+
+	if chunking != nil {
+		if chunking.Chunker != "" {
+			options.Chunker = chunking.Chunker
+		}
+		if chunking.Size != 0 {
+			options.Size = chunking.Size
+		}
+		if chunking.Overlap != nil {
+			options.Overlap = *chunking.Overlap
+		}
+	}
+
+	if options.Overlap >= options.Size {
+		return nil, internal_error.NewValidationError(fmt.Errorf("chunking overlap %d is not less than size %d", options.Overlap, options.Size))
+	}
+
+	return options, nil
+}
+
+// redactText redacts the text and the string metadata values with the policy
+// of the tenant named by the tenant field of the metadata.
+func (uc *usecase) redactText(ctx context.Context, text *domain.InsertTextsInputText) *domain.InsertTextsInputText {
+	tenantField := uc.config.PIIConfig.TenantField
+	tenant, _ := text.Metadata[tenantField].(string)
+
+	var redact func(value any) any
+	redact = func(value any) any {
+		switch value := value.(type) {
+		case string:
+			return uc.redactor.Redact(ctx, tenant, value)
+		case []any:
+			return lo.Map(value, func(item any, _ int) any { return redact(item) })
+		case map[string]any:
+			return lo.MapValues(value, func(item any, _ string) any { return redact(item) })
+		default:
+			return value
+		}
+	}
+
+	var metadata map[string]any
+	if text.Metadata != nil {
+		metadata = make(map[string]any, len(text.Metadata))
+		for key, value := range text.Metadata {
+			if key == tenantField {
+				metadata[key] = value
+				continue
+			}
+			metadata[key] = redact(value)
+		}
+	}
+
+	return &domain.InsertTextsInputText{
+		ID:       text.ID,
+		Text:     uc.redactor.Redact(ctx, tenant, text.Text),
+		Metadata: metadata,
+	}
+}
+
+func (uc *usecase) SearchText(ctx context.Context, input *domain.SearchTextInput) (_ *domain.SearchTextResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.SearchText")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if err := input.Validate(ctx); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	mode := input.Mode
+	if mode == "" {
+		mode = domain.SearchModeDense
+	}
+
+	if mode != domain.SearchModeDense && uc.sparseEncoder == nil {
+		err := internal_error.NewValidationError(fmt.Errorf("the %s search mode requires the sparse vectors", mode))
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// the filter was validated with the input
+	filter, err := domain.ParseFilter(input.Filter)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to parse filter", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	var queryResults []*domain.VectorRepoQueryResult
+	if input.Federation != nil {
+		queryResults, err = uc.federatedSearch(ctx, mode, filter, input)
+	} else {
+		queryResults, err = uc.searchCollection(ctx, uc.defaultCollection(), mode, filter, input)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	similarTexts := make([]*domain.SearchTextResultItem, 0, len(queryResults))
@@ -179,3 +690,296 @@ func (uc *usecase) SearchText(ctx context.Context, input *domain.SearchTextInput
 
 	return searchTextResults, nil
 }
+
+// searchCollection returns the top k results of input in collection, searched in mode.
+func (uc *usecase) searchCollection(ctx context.Context, collection *Collection, mode string, filter *domain.Filter, input *domain.SearchTextInput) ([]*domain.VectorRepoQueryResult, error) {
+	// mmr re-selects the top k from more candidates
+	fetchK := input.TopK
+	if input.MMR != nil {
+		fetchK = input.MMR.FetchK
+	}
+
+	vectorRepoQueryInput := &domain.VectorRepoQueryInput{
+		TopK:     fetchK,
+		MinScore: input.MinScore,
+		Filter:   filter,
+	}
+
+	if mode != domain.SearchModeSparse {
+		vectors, err := collection.Embedder.Embed(ctx, []string{input.Text})
+		if err != nil {
+			uc.logger.ErrorContext(ctx, "failed to embed text", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		if len(vectors) != 1 {
+			uc.logger.ErrorContext(ctx, "invalid vectors length", slog.Int("must", 1), slog.Int("got", len(vectors)))
+			return nil, fmt.Errorf("invalid vectors length: vector length must be 1 got %d", len(vectors))
+		}
+
+		vectorRepoQueryInput.Vector = vectors[0]
+	}
+
+	if mode != domain.SearchModeDense {
+		sparse := uc.sparseEncoder.EncodeQuery(input.Text)
+		switch {
+		case len(sparse.Indices) > 0:
+			vectorRepoQueryInput.Sparse = sparse
+		case mode == domain.SearchModeSparse:
+			// a query without terms matches no text
+			uc.logger.WarnContext(ctx, "sparse query has no terms")
+			return nil, nil
+		default:
+			// the hybrid search of a query without terms is the dense search
+			uc.logger.WarnContext(ctx, "sparse query has no terms, searching dense only")
+		}
+	}
+
+	if vectorRepoQueryInput.Vector != nil && vectorRepoQueryInput.Sparse != nil {
+		vectorRepoQueryInput.Fusion = input.Fusion
+		if vectorRepoQueryInput.Fusion == "" {
+			vectorRepoQueryInput.Fusion = domain.FusionRRF
+		}
+	}
+
+	queryResults, err := collection.VectorRepo.Query(ctx, vectorRepoQueryInput)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to repo query", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if len(queryResults) > fetchK {
+		uc.logger.ErrorContext(ctx, "query results length couldn't exceed topk", slog.Int("query result length", len(queryResults)), slog.Int("topk", fetchK))
+		return nil, fmt.Errorf("query results length couldn't exceed topk: query result length = %d, topk = %d", len(queryResults), fetchK)
+	}
+
+	if input.MMR != nil {
+		queryResults = maximalMarginalRelevance(queryResults, input.TopK, input.MMR.Lambda)
+	}
+
+	return queryResults, nil
+}
+
+func (uc *usecase) LookupTexts(ctx context.Context, input *domain.LookupTextsInput) (_ *domain.LookupTextsResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.LookupTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if err := input.Validate(ctx); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	vectorRepoLookupInput := &domain.VectorRepoLookupInput{
+		Filter: input.Filter(),
+		Limit:  input.Limit,
+	}
+
+	lookupResults, err := uc.vectorRepo.Lookup(ctx, vectorRepoLookupInput)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to repo lookup", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	texts := make([]*domain.LookupTextsResultItem, 0, len(lookupResults))
+
+	for _, lr := range lookupResults {
+		text, assertionOk := lr.Metadata["text"].(string)
+		if !assertionOk {
+			uc.logger.ErrorContext(ctx, "metadata field text is not a string", slog.String("record id", lr.ID), slog.String("got type", fmt.Sprintf("%T", lr.Metadata["text"])))
+			return nil, fmt.Errorf("metadata field text is not a string for record with id %s: got type %T", lr.ID, lr.Metadata["text"])
+		}
+
+		delete(lr.Metadata, "text")
+
+		texts = append(
+			texts,
+			&domain.LookupTextsResultItem{
+				Text:     text,
+				Metadata: lr.Metadata,
+			},
+		)
+	}
+
+	return &domain.LookupTextsResult{Texts: texts}, nil
+}
+
+func (uc *usecase) GetTexts(ctx context.Context, input *domain.GetTextsInput) (_ *domain.GetTextsResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.GetTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if err := input.Validate(ctx); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	points, err := uc.vectorRepo.Get(ctx, &domain.VectorRepoGetInput{IDs: uc.textIDs(input.IDs), WithVectors: input.WithVectors})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to repo get", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	texts, err := uc.storedTexts(ctx, points)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.GetTextsResult{Texts: texts}, nil
+}
+
+func (uc *usecase) ListTexts(ctx context.Context, input *domain.ListTextsInput) (_ *domain.ListTextsResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.ListTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if err := input.Validate(ctx); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// the filter and the cursor were validated with the input
+	filter, err := domain.ParseFilter(input.Filter)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to parse filter", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	offset, err := domain.ParseCursor(input.Cursor)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to parse cursor", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	scrollResult, err := uc.vectorRepo.Scroll(ctx, &domain.VectorRepoScrollInput{
+		Filter:      filter,
+		Limit:       input.Limit,
+		Offset:      offset,
+		WithVectors: input.WithVectors,
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to repo scroll", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	texts, err := uc.storedTexts(ctx, scrollResult.Points)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor string
+	if scrollResult.NextOffset != "" {
+		nextCursor = domain.NewCursor(scrollResult.NextOffset)
+	}
+
+	return &domain.ListTextsResult{Texts: texts, NextCursor: nextCursor}, nil
+}
+
+// textIDs maps the client ids to the ids the texts were inserted with, the
+// same way as InsertTexts; the uuid ids map to themselves.
+func (uc *usecase) textIDs(clientIDs []string) []string {
+	return lo.Map(clientIDs, func(clientID string, _ int) string {
+		return uc.idGenerator.IDFromClientID(clientID)
+	})
+}
+
+// storedTexts moves the text out of the metadata of the points.
+func (uc *usecase) storedTexts(ctx context.Context, points []*domain.VectorRepoPoint) ([]*domain.StoredText, error) {
+	texts := make([]*domain.StoredText, 0, len(points))
+
+	for _, point := range points {
+		text, assertionOk := point.Metadata["text"].(string)
+		if !assertionOk {
+			uc.logger.ErrorContext(ctx, "metadata field text is not a string", slog.String("record id", point.ID), slog.String("got type", fmt.Sprintf("%T", point.Metadata["text"])))
+			return nil, fmt.Errorf("metadata field text is not a string for record with id %s: got type %T", point.ID, point.Metadata["text"])
+		}
+
+		delete(point.Metadata, "text")
+
+		texts = append(
+			texts,
+			&domain.StoredText{
+				ID:       point.ID,
+				Text:     text,
+				Metadata: point.Metadata,
+				Vector:   point.Vector,
+			},
+		)
+	}
+
+	return texts, nil
+}
+
+func (uc *usecase) DeleteTexts(ctx context.Context, input *domain.DeleteTextsInput) (_ *domain.DeleteTextsResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.DeleteTexts")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if err := input.Validate(ctx); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	deleted, err := uc.vectorRepo.Delete(ctx, &domain.VectorRepoDeleteInput{IDs: uc.textIDs(input.IDs), DryRun: input.DryRun})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to repo delete", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "deleted texts", slog.Int("deleted", deleted), slog.Bool("dry_run", input.DryRun))
+
+	return &domain.DeleteTextsResult{Deleted: deleted}, nil
+}
+
+func (uc *usecase) DeleteByFilter(ctx context.Context, input *domain.DeleteByFilterInput) (_ *domain.DeleteTextsResult, err error) {
+	ctx, span := uc.tracer.Start(ctx, "usecase.DeleteByFilter")
+	defer func() {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	if err := input.Validate(ctx); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to validate input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// the filter was validated with the input
+	filter, err := domain.ParseFilter(input.Filter)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to parse filter", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	deleted, err := uc.vectorRepo.DeleteByFilter(ctx, &domain.VectorRepoDeleteByFilterInput{Filter: filter, DryRun: input.DryRun})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, "failed to repo delete by filter", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "deleted texts by filter", slog.Int("deleted", deleted), slog.Bool("dry_run", input.DryRun))
+
+	return &domain.DeleteTextsResult{Deleted: deleted}, nil
+}
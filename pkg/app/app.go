@@ -30,15 +30,19 @@ type App struct {
 	done   chan struct{}
 
 	// Hooks for custom implementation
-	onStart func(context.Context) error
+	onStart  func(context.Context) error
+	cleanups []func() error
 }
 
-// New creates a new instance of BaseApp
-func New(onStart func(context.Context) error, logger *slog.Logger) *App {
+// New creates a new instance of BaseApp. The cleanups release the app
+// resources, e.g. flush and close its stores, in reverse order once the app
+// has shut down.
+func New(onStart func(context.Context) error, logger *slog.Logger, cleanups ...func() error) *App {
 	app := &App{
-		logger:  logger,
-		onStart: onStart,
-		done:    make(chan struct{}),
+		logger:   logger,
+		onStart:  onStart,
+		cleanups: cleanups,
+		done:     make(chan struct{}),
 	}
 	app.state.Store(StateNew)
 	return app
@@ -95,11 +99,22 @@ func (app *App) Start(ctx context.Context) error {
 		}
 	}
 
+	app.cleanup(ctx)
+
 	app.logger.InfoContext(ctx, "shutdown completed")
 
 	return nil
 }
 
+// cleanup runs the cleanups in reverse order, logging their errors.
+func (app *App) cleanup(ctx context.Context) {
+	for i := len(app.cleanups) - 1; i >= 0; i-- {
+		if err := app.cleanups[i](); err != nil {
+			app.logger.ErrorContext(ctx, "failed to clean up", slog.String("error", err.Error()))
+		}
+	}
+}
+
 // Shutdown gracefully stops the application
 func (app *App) Shutdown(ctx context.Context) error {
 	select {
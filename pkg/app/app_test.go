@@ -264,3 +264,32 @@ func TestStartShutdownRace(t *testing.T) {
 		t.Error("expected error when starting during shutdown")
 	}
 }
+
+func TestCleanups(t *testing.T) {
+	logger := slog.Default()
+
+	var cleaned []int
+	cleanup := func(i int, err error) func() error {
+		return func() error {
+			cleaned = append(cleaned, i)
+			return err
+		}
+	}
+
+	application := app.New(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, logger, cleanup(1, nil), cleanup(2, errors.New("cleanup failure")), cleanup(3, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := application.Start(ctx); err != nil {
+		t.Errorf("unexpected error on start: %v", err)
+	}
+
+	// the cleanups run in reverse order, even after a failed one
+	if len(cleaned) != 3 || cleaned[0] != 3 || cleaned[1] != 2 || cleaned[2] != 1 {
+		t.Errorf("expected cleanups [3 2 1], got %v", cleaned)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
@@ -25,3 +27,20 @@ func InitTracer() (shutdown func(context.Context) error, err error) {
 
 	return provider.Shutdown, nil
 }
+
+// InitMeter initializes OpenTelemetry metrics
+func InitMeter() (shutdown func(context.Context) error, err error) {
+	exporter, err := stdoutmetric.New(stdoutmetric.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+		sdkmetric.WithResource(resource.Default()),
+	)
+
+	otel.SetMeterProvider(provider)
+
+	return provider.Shutdown, nil
+}